@@ -9,237 +9,3197 @@
 
 package excelize
 
-import "strings"
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
 
-type adjustDirection bool
+// AdjustDirection defined the direction of inserting or deleting, which is
+// either the rows or the columns.
+type AdjustDirection bool
 
+// Adjust directions.
 const (
-	columns adjustDirection = false
-	rows    adjustDirection = true
+	// AdjustColumns inserts or deletes the columns.
+	AdjustColumns AdjustDirection = false
+	// AdjustRows inserts or deletes the rows.
+	AdjustRows AdjustDirection = true
+
+	columns = AdjustColumns
+	rows    = AdjustRows
 )
 
 // adjustHelper provides a function to adjust rows and columns dimensions,
-// hyperlinks, merged cells and auto filter when inserting or deleting rows or
-// columns.
+// hyperlinks, merged cells, auto filter, conditional formats, protected
+// ranges, frozen panes, comments, drawings, tables, defined names and formulas
+// when inserting or deleting rows or columns.
 //
 // sheet: Worksheet name that we're editing
 // column: Index number of the column we're inserting/deleting before
 // row: Index number of the row we're inserting/deleting before
 // offset: Number of rows/column to insert/delete negative values indicate deletion
 //
-// TODO: adjustCalcChain, adjustPageBreaks, adjustComments,
-// adjustDataValidations, adjustProtectedCells
+// TODO: adjustCalcChain, adjustPageBreaks
 //
-func (f *File) adjustHelper(sheet string, dir adjustDirection, num, offset int) error {
+func (f *File) adjustHelper(sheet string, dir AdjustDirection, num, offset int) error {
 	xlsx, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	cache := cellCoordinatesCache{}
+	stats := newAdjustStatsCollector(xlsx, cache, dir, num)
 	if dir == rows {
-		f.adjustRowDimensions(xlsx, num, offset)
+		stats.cellsShifted = f.adjustRowDimensions(xlsx, num, offset)
 	} else {
-		f.adjustColDimensions(xlsx, num, offset)
+		stats.cellsShifted = f.adjustColDimensions(xlsx, num, offset)
+		f.adjustCols(xlsx, num, offset)
+		adjustColOutlines(xlsx, num, offset)
 	}
-	f.adjustHyperlinks(xlsx, sheet, dir, num, offset)
-	if err = f.adjustMergeCells(xlsx, dir, num, offset); err != nil {
+	m := newAdjustMapping(num, offset)
+	if err = f.adjustCellReferences(sheet, xlsx, cache, dir, m); err != nil {
 		return err
 	}
-	if err = f.adjustAutoFilter(xlsx, dir, num, offset); err != nil {
+	checkSheet(xlsx)
+	checkRow(xlsx)
+	f.adjustLockedCells(xlsx, dir, num, offset)
+	if err = f.adjustTables(sheet, xlsx, dir, m); err != nil {
 		return err
 	}
+	if dir == rows {
+		adjustRowOutlines(xlsx, num, offset)
+	}
+	stats.collect(f.adjustStatsReader(f.sheetMap[trimSheetName(sheet)]), xlsx)
+	return nil
+}
 
-	checkSheet(xlsx)
-	checkRow(xlsx)
+// adjustCellReferences provides a function to adjust the references to the
+// cells of the worksheet, such as hyperlinks, merged cells, auto filter,
+// conditional formats, protected ranges, scenarios, data validations, ignored
+// errors, frozen panes, comments, drawings, form controls, defined names and
+// formulas when inserting or deleting rows or columns. The cells of the
+// worksheet are not moved. Each reference is mapped by the mapping of the
+// row or column numbers once, however many rows or columns are deleted. When
+// inserting or deleting cells, the mapping is limited to the moved part of
+// the worksheet.
+func (f *File) adjustCellReferences(sheet string, xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) error {
+	hasAutoFilter := xlsx.AutoFilter != nil
+	f.adjustHyperlinks(xlsx, cache, sheet, dir, m)
+	if err := f.adjustMergeCells(xlsx, cache, dir, m); err != nil {
+		return err
+	}
+	if err := f.adjustAutoFilter(sheet, xlsx, dir, m); err != nil {
+		return err
+	}
+	if err := f.adjustConditionalFormats(sheet, xlsx, cache, dir, m); err != nil {
+		return err
+	}
+	if err := f.adjustProtectedCells(xlsx, cache, dir, m); err != nil {
+		return err
+	}
+	if err := adjustScenarios(xlsx, cache, dir, m); err != nil {
+		return err
+	}
+	if err := f.adjustDataValidations(sheet, xlsx, cache, dir, m); err != nil {
+		return err
+	}
+	if err := adjustIgnoredErrors(xlsx, cache, dir, m); err != nil {
+		return err
+	}
+	// The frozen panes and the drawings are positioned by the entire rows and
+	// columns, and they are left unchanged when inserting or deleting cells.
+	if !m.limited() {
+		f.adjustPanes(xlsx, dir, m)
+	}
+	f.adjustComments(sheet, xlsx, dir, m)
+	if !m.limited() {
+		f.adjustDrawings(sheet, xlsx, dir, m)
+	}
+	f.adjustHyperlinkLocations(sheet, dir, m)
+	f.adjustFormControls(sheet, dir, m)
+	f.adjustCharts(sheet, dir, m)
+	f.adjustPivotSource(sheet, dir, m)
+	definedNamesChanged := f.adjustDefinedNames(sheet, dir, m)
+	if f.adjustFilterDatabase(sheet, xlsx, hasAutoFilter) {
+		definedNamesChanged = true
+	}
+	if f.adjustFormulas(sheet, dir, m) || definedNamesChanged {
+		f.setFullCalcOnLoad()
+	}
 	return nil
 }
 
-// adjustColDimensions provides a function to update column dimensions when
-// inserting or deleting rows or columns.
-func (f *File) adjustColDimensions(xlsx *xlsxWorksheet, col, offset int) {
-	for rowIdx := range xlsx.SheetData.Row {
-		for colIdx, v := range xlsx.SheetData.Row[rowIdx].C {
-			cellCol, cellRow, _ := CellNameToCoordinates(v.R)
-			if col <= cellCol {
-				if newCol := cellCol + offset; newCol > 0 {
-					xlsx.SheetData.Row[rowIdx].C[colIdx].R, _ = CoordinatesToCellName(newCol, cellRow)
-				}
-			}
-		}
+// adjustMapping maps the row or column numbers before inserting or deleting
+// rows or columns to the numbers after that. The num is the first row or
+// column number inserted or deleted, and the offset is the number of the
+// inserted rows or columns, which is negative for deletion. The deleted
+// numbers are given in ascending order when they are not consecutive, such as
+// removing multiple rows in one pass, and the shift of a number is found by a
+// binary search in them. The mapping is limited to the rows or columns in
+// the other direction from bandFirst to bandLast when inserting or deleting
+// cells, and the areas which are not wholly inside of them are left
+// unchanged.
+type adjustMapping struct {
+	num, offset         int
+	deleted             []int
+	bandFirst, bandLast int
+}
+
+// newAdjustMapping provides a function to get the mapping of inserting the
+// offset rows or columns before num, or deleting -offset rows or columns from
+// num if the offset is negative.
+func newAdjustMapping(num, offset int) adjustMapping {
+	return adjustMapping{num: num, offset: offset}
+}
+
+// newRemoveMapping provides a function to get the mapping of deleting the
+// rows or columns by given sorted and unique numbers.
+func newRemoveMapping(deleted []int) adjustMapping {
+	return adjustMapping{num: deleted[0], offset: -len(deleted), deleted: deleted}
+}
+
+// shift returns the number of the deleted rows or columns before n.
+func (m adjustMapping) shift(n int) int {
+	if m.offset > 0 {
+		return 0
+	}
+	if m.deleted != nil {
+		return sort.SearchInts(m.deleted, n)
 	}
+	if n <= m.num {
+		return 0
+	}
+	if n-m.num > -m.offset {
+		return -m.offset
+	}
+	return n - m.num
 }
 
-// adjustRowDimensions provides a function to update row dimensions when
-// inserting or deleting rows or columns.
-func (f *File) adjustRowDimensions(xlsx *xlsxWorksheet, row, offset int) {
-	for i, r := range xlsx.SheetData.Row {
-		if newRow := r.R + offset; r.R >= row && newRow > 0 {
-			f.ajustSingleRowDimensions(&xlsx.SheetData.Row[i], newRow)
+// adjust returns the row or column number after inserting or deleting rows
+// or columns by given number before that. The deleted number is mapped to
+// the number of the first row or column after it which is left.
+func (m adjustMapping) adjust(n int) int {
+	if m.offset > 0 {
+		if n >= m.num {
+			return n + m.offset
 		}
+		return n
 	}
+	return n - m.shift(n)
 }
 
-// ajustSingleRowDimensions provides a function to ajust single row dimensions.
-func (f *File) ajustSingleRowDimensions(r *xlsxRow, num int) {
-	r.R = num
-	for i, col := range r.C {
-		colName, _, _ := SplitCellName(col.R)
-		r.C[i].R, _ = JoinCellName(colName, num)
-	}
+// limited reports whether the mapping is limited to a part of the rows or
+// columns in the other direction.
+func (m adjustMapping) limited() bool {
+	return m.bandLast != 0
 }
 
-// adjustHyperlinks provides a function to update hyperlinks when inserting or
-// deleting rows or columns.
-func (f *File) adjustHyperlinks(xlsx *xlsxWorksheet, sheet string, dir adjustDirection, num, offset int) {
-	// short path
-	if xlsx.Hyperlinks == nil || len(xlsx.Hyperlinks.Hyperlink) == 0 {
-		return
+// inBand reports whether the area from the first to the last row or column
+// number in the other direction is inside of the rows or columns the mapping
+// is limited to.
+func (m adjustMapping) inBand(first, last int) bool {
+	return !m.limited() || (m.bandFirst <= first && last <= m.bandLast)
+}
+
+// adjustArea provides a function to update the coordinates of the area when
+// inserting or deleting rows or columns, the area isn't changed if it's not
+// inside of the rows or columns the mapping is limited to. It reports whether
+// any part of the area is left after deletion.
+func (m adjustMapping) adjustArea(area []int, dir AdjustDirection) bool {
+	idx := 1
+	if dir == columns {
+		idx = 0
+	}
+	if !m.inBand(area[1-idx], area[3-idx]) {
+		return true
 	}
+	var ok bool
+	area[idx], area[idx+2], ok = m.adjustRange(area[idx], area[idx+2])
+	return ok
+}
 
-	// order is important
-	if offset < 0 {
-		for rowIdx, linkData := range xlsx.Hyperlinks.Hyperlink {
-			colNum, rowNum, _ := CellNameToCoordinates(linkData.Ref)
+// isDeleted reports whether the row or column of the given number is
+// deleted.
+func (m adjustMapping) isDeleted(n int) bool {
+	return m.offset < 0 && m.shift(n+1) > m.shift(n)
+}
 
-			if (dir == rows && num == rowNum) || (dir == columns && num == colNum) {
-				f.deleteSheetRelationships(sheet, linkData.RID)
-				if len(xlsx.Hyperlinks.Hyperlink) > 1 {
-					xlsx.Hyperlinks.Hyperlink = append(xlsx.Hyperlinks.Hyperlink[:rowIdx],
-						xlsx.Hyperlinks.Hyperlink[rowIdx+1:]...)
-				} else {
-					xlsx.Hyperlinks = nil
-				}
-			}
+// adjustRange provides a function to update the first and last index of a
+// range of rows or columns when inserting or deleting rows or columns. The
+// last return value reports whether any part of the range is left after
+// deletion.
+func (m adjustMapping) adjustRange(first, last int) (int, int, bool) {
+	if m.offset > 0 {
+		return m.adjust(first), m.adjust(last), true
+	}
+	newFirst, newLast := m.adjust(first), last-m.shift(last+1)
+	if newFirst > newLast {
+		return first, last, false
+	}
+	return newFirst, newLast, true
+}
+
+// splitRange provides a function to get the ranges of the rows or columns
+// left after deletion by given first and last index of a range, the range is
+// split at the deleted rows or columns inside of it. The range is not split
+// when inserting rows or columns, and nothing is returned if all of the range
+// is deleted.
+func (m adjustMapping) splitRange(first, last int) [][2]int {
+	var ranges [][2]int
+	start := first
+	for _, run := range m.deletedRuns(first, last) {
+		if start < run[0] {
+			ranges = append(ranges, [2]int{m.adjust(start), m.adjust(run[0] - 1)})
 		}
+		start = run[1] + 1
+	}
+	if start <= last {
+		ranges = append(ranges, [2]int{m.adjust(start), m.adjust(last)})
 	}
+	return ranges
+}
 
-	if xlsx.Hyperlinks == nil {
-		return
+// deletedRuns provides a function to get the runs of the consecutive deleted
+// rows or columns between first and last.
+func (m adjustMapping) deletedRuns(first, last int) [][2]int {
+	if m.deleted == nil {
+		if m.num > first {
+			first = m.num
+		}
+		if end := m.num - m.offset - 1; end < last {
+			last = end
+		}
+		if first > last {
+			return nil
+		}
+		return [][2]int{{first, last}}
+	}
+	var runs [][2]int
+	for i := sort.SearchInts(m.deleted, first); i < len(m.deleted) && m.deleted[i] <= last; i++ {
+		if n := len(runs); n > 0 && runs[n-1][1]+1 == m.deleted[i] {
+			runs[n-1][1]++
+			continue
+		}
+		runs = append(runs, [2]int{m.deleted[i], m.deleted[i]})
 	}
+	return runs
+}
 
-	for i := range xlsx.Hyperlinks.Hyperlink {
-		link := &xlsx.Hyperlinks.Hyperlink[i] // get reference
-		colNum, rowNum, _ := CellNameToCoordinates(link.Ref)
+// cellCoordinatesCache memoizes the coordinates of the cell names parsed in
+// one adjusting pass, the same references are often parsed by several adjust
+// functions. A nil cache parses the cell names without memoizing.
+type cellCoordinatesCache map[string][2]int
 
-		if dir == rows {
-			if rowNum >= num {
-				link.Ref, _ = CoordinatesToCellName(colNum, rowNum+offset)
-			}
-		} else {
-			if colNum >= num {
-				link.Ref, _ = CoordinatesToCellName(colNum+offset, rowNum)
-			}
-		}
+// cellNameToCoordinates provides a function to convert the cell name to the
+// coordinates with the cache.
+func (c cellCoordinatesCache) cellNameToCoordinates(cell string) (int, int, error) {
+	if coordinates, ok := c[cell]; ok {
+		return coordinates[0], coordinates[1], nil
 	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err == nil && c != nil {
+		c[cell] = [2]int{col, row}
+	}
+	return col, row, err
 }
 
-// adjustAutoFilter provides a function to update the auto filter when
-// inserting or deleting rows or columns.
-func (f *File) adjustAutoFilter(xlsx *xlsxWorksheet, dir adjustDirection, num, offset int) error {
-	if xlsx.AutoFilter == nil {
-		return nil
+// areaRefToCoordinates provides a function to convert the area reference to
+// the sorted coordinates with the cache.
+func (c cellCoordinatesCache) areaRefToCoordinates(ref string) ([]int, error) {
+	rng := strings.Split(ref, ":")
+	if len(rng) != 2 {
+		return nil, fmt.Errorf("invalid area %q", ref)
+	}
+	firstCol, firstRow, err := c.cellNameToCoordinates(rng[0])
+	if err != nil {
+		return nil, err
+	}
+	lastCol, lastRow, err := c.cellNameToCoordinates(rng[1])
+	if err != nil {
+		return nil, err
+	}
+	if lastCol < firstCol {
+		firstCol, lastCol = lastCol, firstCol
 	}
+	if lastRow < firstRow {
+		firstRow, lastRow = lastRow, firstRow
+	}
+	return []int{firstCol, firstRow, lastCol, lastRow}, nil
+}
 
-	rng := strings.Split(xlsx.AutoFilter.Ref, ":")
-	firstCell := rng[0]
-	lastCell := rng[1]
+// AdjustStats directly maps the cumulative counters of the changes of a
+// worksheet by inserting or deleting rows or columns.
+type AdjustStats struct {
+	CellsShifted       int
+	MergeCellsAdjusted int
+	MergeCellsDropped  int
+	HyperlinksAdjusted int
+	HyperlinksDropped  int
+	AutoFiltersCleared int
+}
 
-	firstCol, firstRow, err := CellNameToCoordinates(firstCell)
-	if err != nil {
-		return err
+// GetAdjustStats provides a function to get the cumulative counters of the
+// changes of the worksheet by inserting or deleting rows or columns since
+// the last reset. For example:
+//
+//    stats, err := f.GetAdjustStats("Sheet1")
+//
+func (f *File) GetAdjustStats(sheet string) (AdjustStats, error) {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return AdjustStats{}, err
 	}
+	return *f.adjustStatsReader(f.sheetMap[trimSheetName(sheet)]), nil
+}
 
-	lastCol, lastRow, err := CellNameToCoordinates(lastCell)
-	if err != nil {
+// ResetAdjustStats provides a function to reset the cumulative counters of
+// the changes of the worksheet by inserting or deleting rows or columns.
+func (f *File) ResetAdjustStats(sheet string) error {
+	if _, err := f.workSheetReader(sheet); err != nil {
 		return err
 	}
+	delete(f.adjustStats, f.sheetMap[trimSheetName(sheet)])
+	return nil
+}
 
-	if (dir == rows && firstRow == num && offset < 0) || (dir == columns && firstCol == num && lastCol == num) {
-		xlsx.AutoFilter = nil
-		for rowIdx := range xlsx.SheetData.Row {
-			rowData := &xlsx.SheetData.Row[rowIdx]
-			if rowData.R > firstRow && rowData.R <= lastRow {
-				rowData.Hidden = false
-			}
-		}
-		return nil
+// adjustStatsDelta provides a function to get the changes of the worksheet
+// made by the given function which inserts or deletes rows or columns.
+func (f *File) adjustStatsDelta(sheet string, fn func() error) (AdjustStats, error) {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return AdjustStats{}, err
 	}
+	path := f.sheetMap[trimSheetName(sheet)]
+	before := *f.adjustStatsReader(path)
+	err := fn()
+	after := *f.adjustStatsReader(path)
+	return AdjustStats{
+		CellsShifted:       after.CellsShifted - before.CellsShifted,
+		MergeCellsAdjusted: after.MergeCellsAdjusted - before.MergeCellsAdjusted,
+		MergeCellsDropped:  after.MergeCellsDropped - before.MergeCellsDropped,
+		HyperlinksAdjusted: after.HyperlinksAdjusted - before.HyperlinksAdjusted,
+		HyperlinksDropped:  after.HyperlinksDropped - before.HyperlinksDropped,
+		AutoFiltersCleared: after.AutoFiltersCleared - before.AutoFiltersCleared,
+	}, err
+}
 
-	if dir == rows {
-		if firstRow >= num {
-			firstCell, _ = CoordinatesToCellName(firstCol, firstRow+offset)
+// adjustStatsReader provides a function to get the pointer to the counters
+// of the worksheet by given path of the worksheet.
+func (f *File) adjustStatsReader(path string) *AdjustStats {
+	if f.adjustStats == nil {
+		f.adjustStats = make(map[string]*AdjustStats)
+	}
+	if f.adjustStats[path] == nil {
+		f.adjustStats[path] = &AdjustStats{}
+	}
+	return f.adjustStats[path]
+}
+
+// adjustStatsCollector records the state of the worksheet before adjusting
+// to count the changes of the worksheet after adjusting.
+type adjustStatsCollector struct {
+	cellsShifted  int
+	linksToShift  int
+	linksCount    int
+	mergeCells    map[*xlsxMergeCell]string
+	hasAutoFilter bool
+}
+
+// newAdjustStatsCollector provides a function to record the state of the
+// worksheet before inserting or deleting rows or columns.
+func newAdjustStatsCollector(xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, num int) *adjustStatsCollector {
+	c := adjustStatsCollector{
+		mergeCells:    make(map[*xlsxMergeCell]string),
+		hasAutoFilter: xlsx.AutoFilter != nil,
+	}
+	if xlsx.Hyperlinks != nil {
+		c.linksCount = len(xlsx.Hyperlinks.Hyperlink)
+		for _, link := range xlsx.Hyperlinks.Hyperlink {
+			col, row, err := cache.cellNameToCoordinates(link.Ref)
+			if err == nil && ((dir == rows && row >= num) || (dir == columns && col >= num)) {
+				c.linksToShift++
+			}
 		}
-		if lastRow >= num {
-			lastCell, _ = CoordinatesToCellName(lastCol, lastRow+offset)
+	}
+	if xlsx.MergeCells != nil {
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			c.mergeCells[mergeCell] = mergeCell.Ref
 		}
-	} else {
-		if lastCol >= num {
-			lastCell, _ = CoordinatesToCellName(lastCol+offset, lastRow)
+	}
+	return &c
+}
+
+// collect provides a function to add the changes of the worksheet after
+// inserting or deleting rows or columns to the counters.
+func (c *adjustStatsCollector) collect(stats *AdjustStats, xlsx *xlsxWorksheet) {
+	stats.CellsShifted += c.cellsShifted
+	var linksCount, mergeCellsCount int
+	if xlsx.Hyperlinks != nil {
+		linksCount = len(xlsx.Hyperlinks.Hyperlink)
+	}
+	stats.HyperlinksDropped += c.linksCount - linksCount
+	stats.HyperlinksAdjusted += c.linksToShift - (c.linksCount - linksCount)
+	if xlsx.MergeCells != nil {
+		mergeCellsCount = len(xlsx.MergeCells.Cells)
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			if ref, ok := c.mergeCells[mergeCell]; ok && ref != mergeCell.Ref {
+				stats.MergeCellsAdjusted++
+			}
 		}
 	}
+	stats.MergeCellsDropped += len(c.mergeCells) - mergeCellsCount
+	if c.hasAutoFilter && xlsx.AutoFilter == nil {
+		stats.AutoFiltersCleared++
+	}
+}
 
-	xlsx.AutoFilter.Ref = firstCell + ":" + lastCell
-	return nil
+// AdjustOp defined the operation of inserting or deleting rows or columns
+// applied to the worksheets by ApplyAcrossSheets, such as the operations
+// returned by InsertRowOp, RemoveRowOp, InsertColOp and RemoveColOp.
+type AdjustOp func(f *File, sheet string) error
+
+// InsertRowOp returns the operation to insert a new row before given Excel
+// row number like InsertRow.
+func InsertRowOp(row int) AdjustOp {
+	return func(f *File, sheet string) error { return f.InsertRow(sheet, row) }
 }
 
-// adjustMergeCells provides a function to update merged cells when inserting
-// or deleting rows or columns.
-func (f *File) adjustMergeCells(xlsx *xlsxWorksheet, dir adjustDirection, num, offset int) error {
-	if xlsx.MergeCells == nil {
-		return nil
-	}
+// RemoveRowOp returns the operation to remove the row by given Excel row
+// number like RemoveRow.
+func RemoveRowOp(row int) AdjustOp {
+	return func(f *File, sheet string) error { return f.RemoveRow(sheet, row) }
+}
+
+// InsertColOp returns the operation to insert a new column before given
+// column name like InsertCol.
+func InsertColOp(col string) AdjustOp {
+	return func(f *File, sheet string) error { return f.InsertCol(sheet, col) }
+}
 
-	for i, areaData := range xlsx.MergeCells.Cells {
-		rng := strings.Split(areaData.Ref, ":")
-		firstCell := rng[0]
-		lastCell := rng[1]
+// RemoveColOp returns the operation to remove the column by given column
+// name like RemoveCol.
+func RemoveColOp(col string) AdjustOp {
+	return func(f *File, sheet string) error { return f.RemoveCol(sheet, col) }
+}
 
-		firstCol, firstRow, err := CellNameToCoordinates(firstCell)
-		if err != nil {
-			return err
+// ApplyAcrossSheets provides a function to apply the same operation to the
+// worksheets by given worksheet names in order, each worksheet is adjusted
+// independently. It stops at the first worksheet failed and returns the
+// error with the name of the worksheet, the worksheets before it are left
+// adjusted. For example, remove row 3 in Sheet1, Sheet2 and Sheet3:
+//
+//    err := f.ApplyAcrossSheets([]string{"Sheet1", "Sheet2", "Sheet3"}, excelize.RemoveRowOp(3))
+//
+func (f *File) ApplyAcrossSheets(sheets []string, op AdjustOp) error {
+	for _, sheet := range sheets {
+		if err := op(f, sheet); err != nil {
+			return fmt.Errorf("sheet %s: %v", sheet, err)
 		}
+	}
+	return nil
+}
 
-		lastCol, lastRow, err := CellNameToCoordinates(lastCell)
-		if err != nil {
-			return err
+// WillAdjust provides a function to report whether inserting or deleting
+// rows or columns would change the workbook, so that the callers can skip the
+// unnecessary work, such as inserting rows below all of the data and the
+// metadata of the worksheet. The dir is AdjustRows or AdjustColumns, num is
+// the number of the row or column inserting or deleting before, and the
+// negative offset indicates deletion. It checks the cells and
+// the structures of the worksheet, and the references to the worksheet in the
+// formulas, defined names, hyperlinks, form controls, charts and pivot caches
+// of the workbook without changing them, the parts of the workbook not loaded
+// yet are read without being loaded. The result is conservative, it may
+// report true for the unparsable references which would be left unchanged.
+// For example, check inserting 2 rows before row 100 in Sheet1:
+//
+//    if f.WillAdjust("Sheet1", excelize.AdjustRows, 100, 2) {
+//        err := f.InsertRow("Sheet1", 100)
+//    }
+//
+func (f *File) WillAdjust(sheet string, dir AdjustDirection, num, offset int) bool {
+	xlsx, err := f.peekWorkSheet(sheet)
+	if err != nil || num < 1 || offset == 0 {
+		return false
+	}
+	return willAdjustCells(xlsx, dir, num) ||
+		f.willAdjustStructures(sheet, xlsx, dir, newAdjustMapping(num, offset)) ||
+		f.willAdjustReferences(sheet, dir, newAdjustMapping(num, offset))
+}
+
+// willAdjustCells provides a function to report whether there is any row,
+// column or cell of the worksheet on or after the row or column inserting or
+// deleting before.
+func willAdjustCells(xlsx *xlsxWorksheet, dir AdjustDirection, num int) bool {
+	if dir == rows {
+		for _, row := range xlsx.SheetData.Row {
+			if row.R >= num {
+				return true
+			}
+		}
+		return false
+	}
+	if xlsx.Cols != nil {
+		for _, col := range xlsx.Cols.Col {
+			if col.Max >= num {
+				return true
+			}
+		}
+	}
+	for _, row := range xlsx.SheetData.Row {
+		for _, c := range row.C {
+			if col, _, err := CellNameToCoordinates(c.R); err != nil || col >= num {
+				return true
+			}
 		}
+	}
+	return false
+}
 
-		adjust := func(v int) int {
-			if v >= num {
-				v += offset
-				if v < 1 {
-					return 1
+// willAdjustStructures provides a function to report whether inserting or
+// deleting rows or columns would change the structures of the worksheet, such
+// as the merged cells, hyperlinks, auto filter, conditional formats,
+// protected ranges, data validations, ignored errors, scenarios, frozen panes,
+// comments, drawings and tables.
+func (f *File) willAdjustStructures(sheet string, xlsx *xlsxWorksheet, dir AdjustDirection, m adjustMapping) bool {
+	var sqrefs []string
+	if xlsx.MergeCells != nil {
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			sqrefs = append(sqrefs, mergeCell.Ref)
+		}
+	}
+	if xlsx.Hyperlinks != nil {
+		for _, link := range xlsx.Hyperlinks.Hyperlink {
+			sqrefs = append(sqrefs, link.Ref)
+		}
+	}
+	if xlsx.AutoFilter != nil {
+		sqrefs = append(sqrefs, xlsx.AutoFilter.Ref)
+	}
+	for _, cf := range xlsx.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			for _, formula := range cfRuleFormulas(rule) {
+				if adjustReferences(*formula, sheet, true, dir, m) != *formula {
+					return true
 				}
-				return v
 			}
-			return v
 		}
-
-		if dir == rows {
-			firstRow = adjust(firstRow)
-			lastRow = adjust(lastRow)
-		} else {
-			firstCol = adjust(firstCol)
-			lastCol = adjust(lastCol)
+		sqrefs = append(sqrefs, cf.SQRef)
+	}
+	if xlsx.ExtLst != nil {
+		for _, match := range x14SqrefRegexp.FindAllStringSubmatch(xlsx.ExtLst.Ext, -1) {
+			sqrefs = append(sqrefs, match[2])
+		}
+	}
+	if xlsx.ProtectedRanges != nil {
+		for _, protectedRange := range xlsx.ProtectedRanges.ProtectedRange {
+			sqrefs = append(sqrefs, protectedRange.Sqref)
+		}
+	}
+	if xlsx.DataValidations != nil {
+		for _, dataValidation := range xlsx.DataValidations.DataValidation {
+			if adjustReferences(dataValidation.Formula1, sheet, true, dir, m) != dataValidation.Formula1 ||
+				adjustReferences(dataValidation.Formula2, sheet, true, dir, m) != dataValidation.Formula2 {
+				return true
+			}
+			sqrefs = append(sqrefs, dataValidation.Sqref)
+		}
+	}
+	if xlsx.IgnoredErrors != nil {
+		for _, ignoredError := range xlsx.IgnoredErrors.IgnoredError {
+			sqrefs = append(sqrefs, ignoredError.Sqref)
+		}
+	}
+	if xlsx.Scenarios != nil {
+		sqrefs = append(sqrefs, xlsx.Scenarios.Sqref)
+		for _, scenario := range xlsx.Scenarios.Scenario {
+			for _, inputCell := range scenario.InputCells {
+				sqrefs = append(sqrefs, inputCell.R)
+			}
+		}
+	}
+	for _, view := range xlsx.SheetViews.SheetView {
+		if view.Pane == nil {
+			continue
+		}
+		split := int(view.Pane.YSplit)
+		if dir == columns {
+			split = int(view.Pane.XSplit)
+		}
+		if split >= m.num {
+			return true
+		}
+		if view.Pane.TopLeftCell != "" {
+			sqrefs = append(sqrefs, view.Pane.TopLeftCell)
+		}
+	}
+	rels := f.peekWorkSheetRels(sheet)
+	for _, rel := range rels.Relationships {
+		if rel.Type != SourceRelationshipComments {
+			continue
 		}
+		if comments := f.peekComments(strings.Replace(rel.Target, "..", "xl", -1)); comments != nil {
+			for _, comment := range comments.CommentList.Comment {
+				sqrefs = append(sqrefs, comment.Ref)
+			}
+		}
+	}
+	if xlsx.LegacyDrawing != nil {
+		cellRegexp := vmlShapeRowRegexp
+		if dir == columns {
+			cellRegexp = vmlShapeColumnRegexp
+		}
+		for _, content := range f.peekVMLDrawing(relationshipTarget(rels, xlsx.LegacyDrawing.RID)) {
+			for _, match := range cellRegexp.FindAllStringSubmatch(content, -1) {
+				if value, _ := strconv.Atoi(match[1]); value+1 >= m.num {
+					return true
+				}
+			}
+		}
+	}
+	if xlsx.Drawing != nil {
+		if f.willAdjustDrawing(relationshipTarget(rels, xlsx.Drawing.RID), dir, m.num) {
+			return true
+		}
+	}
+	if xlsx.TableParts != nil {
+		for _, tablePart := range xlsx.TableParts.TableParts {
+			var t xlsxTable
+			if content, ok := f.XLSX[relationshipTarget(rels, tablePart.RID)]; ok {
+				if err := xml.Unmarshal(namespaceStrictToTransitional(content), &t); err != nil {
+					return true
+				}
+				sqrefs = append(sqrefs, t.Ref)
+			}
+		}
+	}
+	for _, sqref := range sqrefs {
+		if adjusted, err := adjustSqref(sqref, nil, dir, m); err != nil || adjusted != sqref {
+			return true
+		}
+	}
+	return false
+}
 
-		if firstCol == lastCol && firstRow == lastRow {
-			if len(xlsx.MergeCells.Cells) > 1 {
-				xlsx.MergeCells.Cells = append(xlsx.MergeCells.Cells[:i], xlsx.MergeCells.Cells[i+1:]...)
-				xlsx.MergeCells.Count = len(xlsx.MergeCells.Cells)
-			} else {
-				xlsx.MergeCells = nil
+// willAdjustDrawing provides a function to report whether inserting or
+// deleting rows or columns would move the anchors of the drawing by given
+// path. The drawing not loaded yet is read without being loaded.
+func (f *File) willAdjustDrawing(path string, dir AdjustDirection, num int) bool {
+	var contents []string
+	if wsDr := f.Drawings[path]; wsDr != nil {
+		for _, anchor := range append(wsDr.OneCellAnchor, wsDr.TwoCellAnchor...) {
+			if anchor.EditAs == "absolute" {
+				continue
+			}
+			if (anchor.From != nil && willAdjustDrawingCell(anchor.From.Col, anchor.From.Row, dir, num)) ||
+				(anchor.To != nil && willAdjustDrawingCell(anchor.To.Col, anchor.To.Row, dir, num)) {
+				return true
+			}
+			contents = append(contents, anchor.GraphicFrame)
+		}
+	} else if content, ok := f.XLSX[path]; ok {
+		var wsDr decodeWsDr
+		_ = xml.Unmarshal(namespaceStrictToTransitional(content), &wsDr)
+		for _, anchor := range append(wsDr.OneCellAnchor, wsDr.TwoCellAnchor...) {
+			if anchor.EditAs != "absolute" {
+				contents = append(contents, anchor.Content)
+			}
+		}
+	}
+	for _, content := range contents {
+		for _, values := range drawingAnchorRegexp.FindAllStringSubmatch(content, -1) {
+			col, _ := strconv.Atoi(values[3])
+			row, _ := strconv.Atoi(values[5])
+			if willAdjustDrawingCell(col, row, dir, num) {
+				return true
 			}
 		}
+	}
+	return false
+}
 
-		if firstCell, err = CoordinatesToCellName(firstCol, firstRow); err != nil {
-			return err
+// peekWorkSheet provides a function to get the structure of the worksheet
+// for reading only by given worksheet name. The loaded worksheet is returned,
+// otherwise the worksheet is deserialized without being loaded, so that
+// reading it leaves the workbook unchanged.
+func (f *File) peekWorkSheet(sheet string) (*xlsxWorksheet, error) {
+	name, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return nil, fmt.Errorf("sheet %s is not exist", sheet)
+	}
+	if xlsx := f.Sheet[name]; xlsx != nil {
+		return xlsx, nil
+	}
+	var xlsx xlsxWorksheet
+	_ = xml.Unmarshal(namespaceStrictToTransitional(f.readXML(name)), &xlsx)
+	return &xlsx, nil
+}
+
+// peekWorkSheetRels provides a function to get the relationships of the
+// worksheet for reading only by given worksheet name, like peekWorkSheet.
+func (f *File) peekWorkSheetRels(sheet string) *xlsxWorkbookRels {
+	path := "xl/worksheets/_rels/" + strings.TrimPrefix(f.sheetMap[trimSheetName(sheet)], "xl/worksheets/") + ".rels"
+	if rels := f.WorkSheetRels[path]; rels != nil {
+		return rels
+	}
+	var rels xlsxWorkbookRels
+	if content, ok := f.XLSX[path]; ok {
+		_ = xml.Unmarshal(namespaceStrictToTransitional(content), &rels)
+	}
+	return &rels
+}
+
+// peekComments provides a function to get the comments for reading only by
+// given path, like peekWorkSheet. It returns nil if the comments don't exist.
+func (f *File) peekComments(path string) *xlsxComments {
+	if comments := f.Comments[path]; comments != nil {
+		return comments
+	}
+	content, ok := f.XLSX[path]
+	if !ok {
+		return nil
+	}
+	var comments xlsxComments
+	_ = xml.Unmarshal(namespaceStrictToTransitional(content), &comments)
+	return &comments
+}
+
+// peekVMLDrawing provides a function to get the contents of the shapes of
+// the VML drawing for reading only by given path. The contents of the shapes
+// of the loaded drawing are returned, otherwise the raw content of the
+// drawing is returned as a whole without loading it.
+func (f *File) peekVMLDrawing(path string) []string {
+	if vml := f.VMLDrawing[path]; vml != nil {
+		contents := make([]string, 0, len(vml.Shape))
+		for _, shape := range vml.Shape {
+			contents = append(contents, shape.Val)
 		}
+		return contents
+	}
+	if content, ok := f.XLSX[path]; ok {
+		return []string{string(content)}
+	}
+	return nil
+}
 
-		if lastCell, err = CoordinatesToCellName(lastCol, lastRow); err != nil {
-			return err
+// relationshipTarget provides a function to get the path of the part by given
+// relationships and relationship ID, it returns an empty string if the
+// relationship doesn't exist.
+func relationshipTarget(rels *xlsxWorkbookRels, rID string) string {
+	for _, rel := range rels.Relationships {
+		if rel.ID == rID {
+			return strings.Replace(rel.Target, "..", "xl", -1)
 		}
+	}
+	return ""
+}
 
-		areaData.Ref = firstCell + ":" + lastCell
+// willAdjustDrawingCell provides a function to report whether the cell of
+// the drawing anchor given by the zero-based column and row number is on or
+// after the row or column inserting or deleting before.
+func willAdjustDrawingCell(col, row int, dir AdjustDirection, num int) bool {
+	if dir == rows {
+		return row+1 >= num
 	}
-	return nil
+	return col+1 >= num
+}
+
+// willAdjustReferences provides a function to report whether inserting or
+// deleting rows or columns would change the references to the worksheet in
+// the formulas, defined names, hyperlink locations and form controls of all
+// worksheets, and the charts and the pivot caches of the workbook.
+func (f *File) willAdjustReferences(sheet string, dir AdjustDirection, m adjustMapping) bool {
+	if wb := f.workbookReader(); wb.DefinedNames != nil {
+		for _, definedName := range wb.DefinedNames.DefinedName {
+			if adjustReferences(definedName.Data, sheet, false, dir, m) != definedName.Data {
+				return true
+			}
+		}
+	}
+	for name := range f.sheetMap {
+		xlsx, err := f.peekWorkSheet(name)
+		if err != nil {
+			continue
+		}
+		local := name == trimSheetName(sheet)
+		changed := func(formula string) bool {
+			return adjustReferences(formula, sheet, local, dir, m) != formula
+		}
+		for _, row := range xlsx.SheetData.Row {
+			for _, c := range row.C {
+				if c.F == nil {
+					continue
+				}
+				if changed(c.F.Content) {
+					return true
+				}
+				if !local || c.F.Ref == "" {
+					continue
+				}
+				if ref, ok := adjustCellReference(c.F.Ref, dir, m); !ok || ref != c.F.Ref {
+					return true
+				}
+			}
+		}
+		if xlsx.Hyperlinks != nil {
+			for _, link := range xlsx.Hyperlinks.Hyperlink {
+				if changed(link.Location) {
+					return true
+				}
+			}
+		}
+		for _, rel := range f.peekWorkSheetRels(name).Relationships {
+			var contents []string
+			switch rel.Type {
+			case SourceRelationshipCtrlProp:
+				for _, match := range ctrlPropFormulaRegexp.FindAllSubmatch(f.XLSX[strings.Replace(rel.Target, "..", "xl", -1)], -1) {
+					contents = append(contents, string(match[2]))
+				}
+			case SourceRelationshipDrawingVML:
+				for _, content := range f.peekVMLDrawing(strings.Replace(rel.Target, "..", "xl", -1)) {
+					for _, match := range vmlFormulaRegexp.FindAllStringSubmatch(content, -1) {
+						contents = append(contents, match[2])
+					}
+				}
+			}
+			for _, content := range contents {
+				if changed(html.UnescapeString(content)) {
+					return true
+				}
+			}
+		}
+	}
+	for path, content := range f.XLSX {
+		if strings.HasPrefix(path, "xl/charts/chart") {
+			for _, match := range chartFormulaRegexp.FindAllSubmatch(content, -1) {
+				formula := html.UnescapeString(string(match[2]))
+				if adjustReferences(formula, sheet, false, dir, m) != formula {
+					return true
+				}
+			}
+		}
+		if strings.HasPrefix(path, "xl/pivotCache/pivotCacheDefinition") {
+			for _, element := range pivotSourceRegexp.FindAll(content, -1) {
+				attrs := map[string]string{}
+				for _, match := range pivotSourceAttrRegexp.FindAllSubmatch(element, -1) {
+					attrs[string(match[1])] = html.UnescapeString(string(match[2]))
+				}
+				if attrs["ref"] == "" || !strings.EqualFold(attrs["sheet"], trimSheetName(sheet)) {
+					continue
+				}
+				if ref, ok := adjustCellReference(attrs["ref"], dir, m); ok && ref != attrs["ref"] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// AdjustColumnDimensions provides a low-level function to shift the cells
+// on or after given column by given offset columns in the worksheet, the
+// negative offset shifts the cells to the left. For example, shift the cells
+// in the column C and after it right by 2 columns in Sheet1:
+//
+//    err := f.AdjustColumnDimensions("Sheet1", "C", 2)
+//
+// This function only updates the references of the cells, the metadata of
+// the worksheet such as the column widths, merged cells, hyperlinks,
+// formulas, tables and defined names are left unchanged. The cells shifted
+// onto the occupied cells replace them. It returns an error if the cells
+// would be shifted to the left of the column A or beyond the last column of
+// the worksheet. Use InsertCol and RemoveCol to adjust the whole workbook.
+func (f *File) AdjustColumnDimensions(sheet, col string, offset int) error {
+	num, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	if _, err = ColumnNumberToName(num + offset); err != nil {
+		return err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	for _, rowData := range xlsx.SheetData.Row {
+		if len(rowData.C) == 0 {
+			continue
+		}
+		lastCol, _, err := CellNameToCoordinates(rowData.C[len(rowData.C)-1].R)
+		if err == nil && lastCol >= num && lastCol+offset > TotalColumns {
+			return newInvalidColumnNumberError(lastCol + offset)
+		}
+	}
+	f.adjustColDimensions(xlsx, num, offset)
+	return checkRow(xlsx)
+}
+
+// adjustColDimensions provides a function to update column dimensions when
+// inserting or deleting rows or columns. The cells of each row are sorted by
+// column, so the cells are walked backwards and only the cells after the
+// inserted or deleted column are visited. It returns the number of the cells
+// moved.
+func (f *File) adjustColDimensions(xlsx *xlsxWorksheet, col, offset int) int {
+	var count int
+	for rowIdx := range xlsx.SheetData.Row {
+		cells := xlsx.SheetData.Row[rowIdx].C
+		for colIdx := len(cells) - 1; colIdx >= 0; colIdx-- {
+			cellCol, cellRow, err := CellNameToCoordinates(cells[colIdx].R)
+			if err != nil {
+				continue
+			}
+			if cellCol < col {
+				break
+			}
+			if newCol := cellCol + offset; newCol > 0 {
+				cells[colIdx].R, _ = CoordinatesToCellName(newCol, cellRow)
+				count++
+			}
+		}
+		if offset < 0 {
+			xlsx.SheetData.Row[rowIdx].C = uniqueCells(cells)
+		}
+	}
+	return count
+}
+
+// uniqueCells provides a function to remove the cells with duplicate
+// references in a row, which may be produced by shifting a cell onto an
+// occupied reference when deleting columns. The cell shifted from the right
+// wins, and the cells are kept sorted by column.
+func uniqueCells(cells []xlsxC) []xlsxC {
+	positions := make(map[string]int, len(cells))
+	unique := cells[:0]
+	for _, c := range cells {
+		if idx, ok := positions[c.R]; ok {
+			unique[idx] = c
+			continue
+		}
+		positions[c.R] = len(unique)
+		unique = append(unique, c)
+	}
+	if len(unique) == len(cells) {
+		return unique
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		col1, _, _ := CellNameToCoordinates(unique[i].R)
+		col2, _, _ := CellNameToCoordinates(unique[j].R)
+		return col1 < col2
+	})
+	return unique
+}
+
+// adjustCols provides a function to update the columns information (width,
+// style, visibility and outline level) when inserting or deleting columns.
+// The entry which spans the inserted or deleted columns is extended or
+// shortened, and the entry will be removed if all of its columns are deleted.
+func (f *File) adjustCols(xlsx *xlsxWorksheet, col, offset int) {
+	if xlsx.Cols == nil {
+		return
+	}
+	cols := xlsx.Cols.Col[:0]
+	for _, c := range xlsx.Cols.Col {
+		var ok bool
+		if c.Min, c.Max, ok = newAdjustMapping(col, offset).adjustRange(c.Min, c.Max); ok {
+			cols = append(cols, c)
+		}
+	}
+	if len(cols) == 0 {
+		xlsx.Cols = nil
+		return
+	}
+	xlsx.Cols.Col = cols
+}
+
+// adjustColOutlines provides a function to keep the outline of the grouped
+// columns consistent after inserting or deleting columns. The inserted
+// columns inside of a group are added into the group, and hidden if the group
+// is collapsed. The collapsed flag of the summary column is cleared if it
+// doesn't have the detail columns anymore.
+func adjustColOutlines(xlsx *xlsxWorksheet, num, offset int) {
+	if xlsx.Cols == nil {
+		return
+	}
+	summaryRight := xlsx.SheetPr == nil || xlsx.SheetPr.OutlinePr == nil || defaultTrue(xlsx.SheetPr.OutlinePr.SummaryRight)
+	findCol := func(col int) *xlsxCol {
+		var found *xlsxCol
+		for idx := range xlsx.Cols.Col {
+			if c := &xlsx.Cols.Col[idx]; c.Min <= col && col <= c.Max {
+				found = c
+			}
+		}
+		return found
+	}
+	left, right := findCol(num-1), findCol(num+offset)
+	if offset > 0 && left != nil && right != nil && findCol(num) == nil {
+		level, hidden := left.OutlineLevel, left.Hidden && right.Hidden
+		if summaryRight && right.Collapsed && right.OutlineLevel < level {
+			// Inserting between the collapsed group and its summary column.
+			hidden = left.Hidden
+		} else if !summaryRight && left.Collapsed && left.OutlineLevel < right.OutlineLevel {
+			level, hidden = right.OutlineLevel, right.Hidden
+		} else if right.OutlineLevel < level {
+			level = right.OutlineLevel
+		}
+		if level > 0 {
+			inserted := *left
+			inserted.Min, inserted.Max = num, num+offset-1
+			inserted.OutlineLevel, inserted.Hidden, inserted.Collapsed = level, hidden, false
+			xlsx.Cols.Col = append(xlsx.Cols.Col, inserted)
+			sort.SliceStable(xlsx.Cols.Col, func(i, j int) bool {
+				return xlsx.Cols.Col[i].Min < xlsx.Cols.Col[j].Min
+			})
+		}
+	}
+	for idx := range xlsx.Cols.Col {
+		c := &xlsx.Cols.Col[idx]
+		if !c.Collapsed {
+			continue
+		}
+		detail := findCol(c.Min - 1)
+		if !summaryRight {
+			detail = findCol(c.Max + 1)
+		}
+		if detail == nil || detail.OutlineLevel <= c.OutlineLevel {
+			c.Collapsed = false
+		}
+	}
+}
+
+// AdjustRowDimensions provides a low-level function to shift the rows on or
+// after given Excel row number by given offset rows in the worksheet, the
+// negative offset shifts the rows up. For example, shift the row 3 and the
+// rows after it down by 2 rows in Sheet1:
+//
+//    err := f.AdjustRowDimensions("Sheet1", 3, 2)
+//
+// This function only updates the row numbers and the references of the
+// cells, the metadata of the worksheet such as the merged cells,
+// hyperlinks, formulas, tables and defined names are left unchanged. The
+// rows shifted onto the occupied rows replace them, so the rows to be
+// deleted should be removed before shifting the rows up. It returns an
+// error if the rows would be shifted above the row 1 or beyond the last row
+// of the worksheet. Use InsertRow and RemoveRow to adjust the whole workbook.
+func (f *File) AdjustRowDimensions(sheet string, row, offset int) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	if row+offset < 1 {
+		return newInvalidRowNumberError(row + offset)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	for _, rowData := range xlsx.SheetData.Row {
+		if rowData.R >= row && rowData.R+offset > TotalRows {
+			return newInvalidRowNumberError(rowData.R + offset)
+		}
+	}
+	f.adjustRowDimensions(xlsx, row, offset)
+	checkSheet(xlsx)
+	return checkRow(xlsx)
+}
+
+// adjustRowDimensions provides a function to update row dimensions when
+// inserting or deleting rows or columns. It returns the number of the cells
+// moved.
+func (f *File) adjustRowDimensions(xlsx *xlsxWorksheet, row, offset int) int {
+	var count int
+	for i, r := range xlsx.SheetData.Row {
+		if newRow := r.R + offset; r.R >= row && newRow > 0 {
+			f.ajustSingleRowDimensions(&xlsx.SheetData.Row[i], newRow)
+			count += len(r.C)
+		}
+	}
+	return count
+}
+
+// removeRowDimensions provides a function to remove the rows by given sorted
+// row numbers in one pass, and renumber each of the rows after them by the
+// number of the rows removed before it. It returns the number of the cells
+// moved.
+func (f *File) removeRowDimensions(xlsx *xlsxWorksheet, removed []int) int {
+	var count, idx int
+	rowData := xlsx.SheetData.Row[:0]
+	for _, r := range xlsx.SheetData.Row {
+		for idx < len(removed) && removed[idx] < r.R {
+			idx++
+		}
+		if idx < len(removed) && removed[idx] == r.R {
+			continue
+		}
+		if idx > 0 {
+			f.ajustSingleRowDimensions(&r, r.R-idx)
+			count += len(r.C)
+		}
+		rowData = append(rowData, r)
+	}
+	xlsx.SheetData.Row = rowData
+	return count
+}
+
+// ajustSingleRowDimensions provides a function to ajust single row dimensions.
+func (f *File) ajustSingleRowDimensions(r *xlsxRow, num int) {
+	r.R = num
+	for i, col := range r.C {
+		colName, _, _ := SplitCellName(col.R)
+		r.C[i].R, _ = JoinCellName(colName, num)
+	}
+}
+
+// adjustRowOutlines provides a function to keep the outline of the grouped
+// rows consistent after inserting or deleting rows. The inserted rows inside
+// of a group are added into the group, and hidden if the group is collapsed.
+// The collapsed flag of the summary row is cleared if it doesn't have the
+// detail rows anymore.
+func adjustRowOutlines(xlsx *xlsxWorksheet, num, offset int) {
+	rowData := xlsx.SheetData.Row
+	summaryBelow := xlsx.SheetPr == nil || xlsx.SheetPr.OutlinePr == nil || xlsx.SheetPr.OutlinePr.SummaryBelow
+	if offset > 0 && num > 1 && num+offset <= len(rowData) {
+		above, below := rowData[num-2], rowData[num+offset-1]
+		level, hidden := above.OutlineLevel, above.Hidden && below.Hidden
+		if summaryBelow && below.Collapsed && below.OutlineLevel < level {
+			// Inserting between the collapsed group and its summary row.
+			hidden = above.Hidden
+		} else if !summaryBelow && above.Collapsed && above.OutlineLevel < below.OutlineLevel {
+			level, hidden = below.OutlineLevel, below.Hidden
+		} else if below.OutlineLevel < level {
+			level = below.OutlineLevel
+		}
+		for idx := num - 1; level > 0 && idx < num+offset-1; idx++ {
+			rowData[idx].OutlineLevel = level
+			rowData[idx].Hidden = hidden
+		}
+	}
+	for idx := range rowData {
+		if !rowData[idx].Collapsed {
+			continue
+		}
+		detail := idx - 1
+		if !summaryBelow {
+			detail = idx + 1
+		}
+		if detail < 0 || detail >= len(rowData) || rowData[detail].OutlineLevel <= rowData[idx].OutlineLevel {
+			rowData[idx].Collapsed = false
+		}
+	}
+}
+
+// ShiftHyperlinks provides a low-level function to update only the cell
+// references of the hyperlinks in the worksheet by given worksheet name,
+// shift direction, Excel row number (for ShiftCellsDown) or column number
+// (for ShiftCellsRight) and offset, as inserting or deleting rows or columns
+// does. A negative offset deletes the rows or columns, and the hyperlinks in
+// them are removed with their relationships. For example, fix the hyperlinks
+// of Sheet1 after moving the cells from row 3 two rows down by hand:
+//
+//    err := f.ShiftHyperlinks("Sheet1", excelize.ShiftCellsDown, 3, 2)
+//
+// The other structures of the worksheet are left unchanged, use InsertRow,
+// RemoveRow, InsertCol and RemoveCol to adjust all of them.
+func (f *File) ShiftHyperlinks(sheet string, shift ShiftDirection, num, offset int) error {
+	if shift != ShiftCellsRight && shift != ShiftCellsDown {
+		return fmt.Errorf("invalid shift direction %d", shift)
+	}
+	if num < 1 && shift == ShiftCellsRight {
+		return newInvalidColumnNumberError(num)
+	}
+	if num < 1 {
+		return newInvalidRowNumberError(num)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	dir := columns
+	if shift == ShiftCellsDown {
+		dir = rows
+	}
+	f.adjustHyperlinks(xlsx, nil, sheet, dir, newAdjustMapping(num, offset))
+	return nil
+}
+
+// adjustHyperlinks provides a function to update hyperlinks when inserting or
+// deleting rows or columns. The hyperlinks of the deleted cells will be
+// removed with their relationships.
+func (f *File) adjustHyperlinks(xlsx *xlsxWorksheet, cache cellCoordinatesCache, sheet string, dir AdjustDirection, m adjustMapping) {
+	// short path
+	if xlsx.Hyperlinks == nil || len(xlsx.Hyperlinks.Hyperlink) == 0 {
+		return
+	}
+
+	// order is important
+	if m.offset < 0 {
+		hyperlinks := xlsx.Hyperlinks.Hyperlink[:0]
+		for _, linkData := range xlsx.Hyperlinks.Hyperlink {
+			colNum, rowNum, _ := cache.cellNameToCoordinates(linkData.Ref)
+			value, other := colNum, rowNum
+			if dir == rows {
+				value, other = rowNum, colNum
+			}
+			if m.isDeleted(value) && m.inBand(other, other) {
+				f.deleteSheetRelationships(sheet, linkData.RID)
+				continue
+			}
+			hyperlinks = append(hyperlinks, linkData)
+		}
+		if len(hyperlinks) == 0 {
+			xlsx.Hyperlinks = nil
+		} else {
+			xlsx.Hyperlinks.Hyperlink = hyperlinks
+		}
+	}
+
+	if xlsx.Hyperlinks == nil {
+		return
+	}
+
+	for i := range xlsx.Hyperlinks.Hyperlink {
+		link := &xlsx.Hyperlinks.Hyperlink[i] // get reference
+		colNum, rowNum, _ := cache.cellNameToCoordinates(link.Ref)
+
+		if dir == rows && m.inBand(colNum, colNum) {
+			if newRowNum := m.adjust(rowNum); newRowNum != rowNum {
+				link.Ref, _ = CoordinatesToCellName(colNum, newRowNum)
+			}
+		} else if dir == columns && m.inBand(rowNum, rowNum) {
+			if newColNum := m.adjust(colNum); newColNum != colNum {
+				link.Ref, _ = CoordinatesToCellName(newColNum, rowNum)
+			}
+		}
+	}
+}
+
+// adjustHyperlinkLocations provides a function to update the cell references
+// to the worksheet in the locations of the internal hyperlinks in all
+// worksheets when inserting or deleting rows or columns, such as the location
+// Sheet1!A10 becomes Sheet1!A11 after inserting a row above row 10 of
+// Sheet1. The locations without a worksheet name are treated as the
+// references to the worksheet of the hyperlink.
+func (f *File) adjustHyperlinkLocations(sheet string, dir AdjustDirection, m adjustMapping) {
+	for name := range f.sheetMap {
+		local := name == trimSheetName(sheet)
+		xlsx, err := f.referencingWorkSheetReader(name, func(formula string) bool {
+			return adjustReferences(formula, sheet, local, dir, m) != formula
+		})
+		if err != nil || xlsx == nil || xlsx.Hyperlinks == nil {
+			continue
+		}
+		for i := range xlsx.Hyperlinks.Hyperlink {
+			link := &xlsx.Hyperlinks.Hyperlink[i]
+			if link.Location != "" {
+				link.Location = adjustReferences(link.Location, sheet, local, dir, m)
+			}
+		}
+	}
+}
+
+// adjustAutoFilter provides a function to update the auto filter of the
+// worksheet when inserting or deleting rows or columns. The auto filters of
+// the tables are updated independently by adjustTables. The auto filter is
+// removed when its header row or all of its columns are deleted. The rows of
+// the worksheet are already moved, so only the data rows of the auto filter
+// left after deleting rows are unhidden.
+func (f *File) adjustAutoFilter(sheet string, xlsx *xlsxWorksheet, dir AdjustDirection, m adjustMapping) error {
+	if xlsx.AutoFilter == nil {
+		return nil
+	}
+
+	firstCol, firstRow, lastCol, lastRow, err := RangeToCoordinates(xlsx.AutoFilter.Ref)
+	if err != nil {
+		return err
+	}
+	if (dir == rows && !m.inBand(firstCol, lastCol)) || (dir == columns && !m.inBand(firstRow, lastRow)) {
+		return nil
+	}
+	if dir == rows && m.isDeleted(firstRow) {
+		xlsx.AutoFilter = nil
+		if newFirstRow, newLastRow, ok := m.adjustRange(firstRow, lastRow); ok {
+			f.unhideFilteredRows(sheet, xlsx, newFirstRow-1, newLastRow, "")
+		}
+		return nil
+	}
+
+	var firstCell, lastCell string
+	if dir == rows {
+		newFirstRow, newLastRow, _ := m.adjustRange(firstRow, lastRow)
+		firstCell, _ = CoordinatesToCellName(firstCol, newFirstRow)
+		lastCell, _ = CoordinatesToCellName(lastCol, newLastRow)
+	} else {
+		newFirstCol, newLastCol, ok := m.adjustRange(firstCol, lastCol)
+		if !ok {
+			xlsx.AutoFilter = nil
+			f.unhideFilteredRows(sheet, xlsx, firstRow, lastRow, "")
+			return nil
+		}
+		firstCell, _ = CoordinatesToCellName(newFirstCol, firstRow)
+		lastCell, _ = CoordinatesToCellName(newLastCol, lastRow)
+		if !adjustFilterColumn(xlsx.AutoFilter, firstCol, newFirstCol, m) {
+			f.unhideFilteredRows(sheet, xlsx, firstRow, lastRow, "")
+		}
+	}
+
+	xlsx.AutoFilter.Ref = firstCell + ":" + lastCell
+	return f.adjustSortState(xlsx.AutoFilter, dir, m)
+}
+
+// unhideFilteredRows provides a function to unhide the data rows of the auto
+// filter with the given header row and last row, when the filter criteria of
+// the auto filter is removed. The rows in the range of the other auto filters
+// with filter criteria, of the worksheet or the tables, are left hidden since
+// they may be hidden by these auto filters. The auto filter of the table in
+// the given part is skipped, an empty part means the auto filter of the
+// worksheet.
+func (f *File) unhideFilteredRows(sheet string, xlsx *xlsxWorksheet, firstRow, lastRow int, tableXML string) {
+	var filtered [][]int
+	if tableXML != "" && xlsx.AutoFilter != nil && xlsx.AutoFilter.FilterColumn != nil {
+		if coordinates, err := areaRefToCoordinates(xlsx.AutoFilter.Ref); err == nil {
+			filtered = append(filtered, coordinates)
+		}
+	}
+	if xlsx.TableParts != nil {
+		for _, tablePart := range xlsx.TableParts.TableParts {
+			target := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, tablePart.RID), "..", "xl", -1)
+			var t xlsxTable
+			if target == tableXML || xml.Unmarshal(namespaceStrictToTransitional(f.XLSX[target]), &t) != nil {
+				continue
+			}
+			if t.AutoFilter == nil || t.AutoFilter.FilterColumn == nil {
+				continue
+			}
+			if coordinates, err := areaRefToCoordinates(t.AutoFilter.Ref); err == nil {
+				filtered = append(filtered, coordinates)
+			}
+		}
+	}
+	for rowIdx := range xlsx.SheetData.Row {
+		rowData := &xlsx.SheetData.Row[rowIdx]
+		if rowData.R <= firstRow || rowData.R > lastRow {
+			continue
+		}
+		hidden := false
+		for _, coordinates := range filtered {
+			if rowData.R > coordinates[1] && rowData.R <= coordinates[3] {
+				hidden = true
+			}
+		}
+		rowData.Hidden = hidden && rowData.Hidden
+	}
+}
+
+// adjustFilterColumn provides a function to renumber the column of the
+// filter criteria of the auto filter, which is relative to the first column
+// of the auto filter, when inserting or deleting columns. The criteria such
+// as the color filter and the icon filter are kept. The filter column will be
+// removed if its column is deleted, and it reports whether the filter column
+// is left. The firstCol and the newFirstCol are the first column of the auto
+// filter before and after adjusting.
+func adjustFilterColumn(autoFilter *xlsxAutoFilter, firstCol, newFirstCol int, m adjustMapping) bool {
+	if autoFilter.FilterColumn == nil {
+		return true
+	}
+	col := firstCol + autoFilter.FilterColumn.ColID
+	if m.isDeleted(col) {
+		autoFilter.FilterColumn = nil
+		return false
+	}
+	autoFilter.FilterColumn.ColID = m.adjust(col) - newFirstCol
+	return true
+}
+
+// adjustSortState provides a function to update the sort state of the auto
+// filter when inserting or deleting rows or columns. The sort conditions of
+// the deleted cells will be removed, and the sort state will be cleared if
+// its range or all of its sort conditions are deleted.
+func (f *File) adjustSortState(autoFilter *xlsxAutoFilter, dir AdjustDirection, m adjustMapping) error {
+	sortState := autoFilter.SortState
+	if sortState == nil {
+		return nil
+	}
+	ref, err := adjustSqref(sortState.Ref, nil, dir, m)
+	if err != nil {
+		return err
+	}
+	if ref == "" {
+		autoFilter.SortState = nil
+		return nil
+	}
+	sortState.Ref = ref
+	sortConditions := sortState.SortCondition[:0]
+	for _, sortCondition := range sortState.SortCondition {
+		if sortCondition.Ref, err = adjustSqref(sortCondition.Ref, nil, dir, m); err != nil {
+			return err
+		}
+		if sortCondition.Ref != "" {
+			sortConditions = append(sortConditions, sortCondition)
+		}
+	}
+	if len(sortConditions) == 0 {
+		autoFilter.SortState = nil
+		return nil
+	}
+	sortState.SortCondition = sortConditions
+	return nil
+}
+
+// adjustMergeCells provides a function to update merged cells when inserting
+// or deleting rows or columns. The merged cells which the rows or columns are
+// inserted inside of will be expanded, so the top-left cell still holds the
+// value of the merged cells, or split into the merged cells before and after
+// the inserted rows or columns if the SplitMergesOnInsert option is set. The
+// merged cells which are deleted will be removed, and the merged cells which
+// become a single cell will be removed unless the CollapsePolicy option is
+// CollapsePolicyKeepSingle. The merged cells with the same span will be
+// united if deleting rows or columns between them makes them adjacent and
+// the MergeAdjacentOnDelete option is set.
+func (f *File) adjustMergeCells(xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) error {
+	if xlsx.MergeCells == nil {
+		return nil
+	}
+	idx := 1
+	if dir == columns {
+		idx = 0
+	}
+	collapsed := func(area []int) bool {
+		return area[0] == area[2] && area[1] == area[3] && f.adjustOptions.collapsePolicy != CollapsePolicyKeepSingle
+	}
+	// The merged cells left are collected into a new slice instead of being
+	// removed in place, so the collapsed and the shifted merged cells can be
+	// mixed in one pass.
+	var areas, origins [][]int
+	cells := make([]*xlsxMergeCell, 0, len(xlsx.MergeCells.Cells))
+	for _, areaData := range xlsx.MergeCells.Cells {
+		coordinates, err := cache.areaRefToCoordinates(areaData.Ref)
+		if err != nil {
+			return err
+		}
+		origin := append([]int{}, coordinates...)
+		if f.adjustOptions.splitMergesOnInsert && m.offset > 0 && origin[idx] < m.num && m.num <= origin[idx+2] && m.inBand(origin[1-idx], origin[3-idx]) {
+			// Split the merged cells straddling the inserted rows or columns.
+			before, after := append([]int{}, origin...), coordinates
+			before[idx+2], after[idx], after[idx+2] = m.num-1, m.num+m.offset, origin[idx+2]+m.offset
+			cell := areaData
+			for _, area := range [][]int{before, after} {
+				if collapsed(area) {
+					continue
+				}
+				if cell == nil {
+					cell = &xlsxMergeCell{}
+				}
+				areas, origins = append(areas, area), append(origins, origin)
+				cells, cell = append(cells, cell), nil
+			}
+			continue
+		}
+		if !m.adjustArea(coordinates, dir) || collapsed(coordinates) {
+			continue
+		}
+		areas, origins = append(areas, coordinates), append(origins, origin)
+		cells = append(cells, areaData)
+	}
+	if f.adjustOptions.mergeAdjacentOnDelete && m.offset < 0 {
+		areas, cells = mergeAdjacentCells(areas, origins, cells, dir)
+	}
+	for i, areaData := range cells {
+		firstCell, err := CoordinatesToCellName(areas[i][0], areas[i][1])
+		if err != nil {
+			return err
+		}
+		lastCell, err := CoordinatesToCellName(areas[i][2], areas[i][3])
+		if err != nil {
+			return err
+		}
+		areaData.Ref = firstCell + ":" + lastCell
+	}
+	xlsx.MergeCells.Cells = cells
+	xlsx.MergeCells.Count = len(cells)
+	if len(cells) == 0 {
+		xlsx.MergeCells = nil
+	}
+	return nil
+}
+
+// mergeAdjacentCells provides a function to unite the merged cells with the
+// same span which become adjacent after deleting rows or columns between
+// them. The origins are the areas of the merged cells before deletion, the
+// merged cells which are adjacent before deletion are left separated.
+func mergeAdjacentCells(areas, origins [][]int, cells []*xlsxMergeCell, dir AdjustDirection) ([][]int, []*xlsxMergeCell) {
+	first, last, spanFirst, spanLast := 0, 2, 1, 3
+	if dir == rows {
+		first, last, spanFirst, spanLast = 1, 3, 0, 2
+	}
+	starts := make(map[[3]int]int, len(areas))
+	for i, area := range areas {
+		starts[[3]int{area[first], area[spanFirst], area[spanLast]}] = i
+	}
+	united := make([]bool, len(areas))
+	for i := range areas {
+		for !united[i] {
+			j, ok := starts[[3]int{areas[i][last] + 1, areas[i][spanFirst], areas[i][spanLast]}]
+			if !ok || united[j] || j == i || origins[j][first] == origins[i][last]+1 {
+				break
+			}
+			areas[i][last], origins[i][last], united[j] = areas[j][last], origins[j][last], true
+		}
+	}
+	newAreas, newCells := areas[:0], cells[:0]
+	for i := range areas {
+		if !united[i] {
+			newAreas, newCells = append(newAreas, areas[i]), append(newCells, cells[i])
+		}
+	}
+	return newAreas, newCells
+}
+
+// adjustConditionalFormats provides a function to update the cell ranges of
+// conditional formats, and the references in the formulas and the values of
+// the rules, when inserting or deleting rows or columns, so that the rules
+// referencing the cells stay aligned with the cells and their comments. The
+// conditional format will be removed if all of its ranges are deleted, and
+// the priorities of the rules left are renumbered contiguously.
+func (f *File) adjustConditionalFormats(sheet string, xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) error {
+	conditionalFormats := xlsx.ConditionalFormatting[:0]
+	var removed bool
+	removedIDs := map[string]bool{}
+	for _, cf := range xlsx.ConditionalFormatting {
+		sqref, err := adjustSqref(cf.SQRef, cache, dir, m)
+		if err != nil {
+			return err
+		}
+		if sqref == "" {
+			removed = true
+			for _, rule := range cf.CfRule {
+				if rule.ExtLst == nil {
+					continue
+				}
+				for _, match := range x14IDRegexp.FindAllStringSubmatch(rule.ExtLst.Ext, -1) {
+					removedIDs[match[1]] = true
+				}
+			}
+			continue
+		}
+		cf.SQRef = sqref
+		for _, rule := range cf.CfRule {
+			for _, formula := range cfRuleFormulas(rule) {
+				*formula = adjustReferences(*formula, sheet, true, dir, m)
+			}
+		}
+		conditionalFormats = append(conditionalFormats, cf)
+	}
+	if len(conditionalFormats) == 0 {
+		conditionalFormats = nil
+	}
+	xlsx.ConditionalFormatting = conditionalFormats
+	if removed {
+		renumberCfRulePriorities(conditionalFormats)
+	}
+	return adjustConditionalFormatsExt(xlsx, cache, dir, m, removedIDs)
+}
+
+// cfRuleFormulas provides a function to get the pointers to the formulas and
+// the values of the conditional formatting rule, which may reference the
+// cells of the worksheet.
+func cfRuleFormulas(rule *xlsxCfRule) []*string {
+	var formulas []*string
+	for i := range rule.Formula {
+		formulas = append(formulas, &rule.Formula[i])
+	}
+	var cfvos []*xlsxCfvo
+	if rule.ColorScale != nil {
+		cfvos = append(cfvos, rule.ColorScale.Cfvo...)
+	}
+	if rule.DataBar != nil {
+		cfvos = append(cfvos, rule.DataBar.Cfvo...)
+	}
+	if rule.IconSet != nil {
+		cfvos = append(cfvos, rule.IconSet.Cfvo...)
+	}
+	for _, cfvo := range cfvos {
+		formulas = append(formulas, &cfvo.Val)
+	}
+	return formulas
+}
+
+// x14IDRegexp matches the ID in the extension list of the conditional
+// formatting rule, which links the rule to its extended settings in the
+// extension list of the worksheet.
+var x14IDRegexp = regexp.MustCompile(`<(?:\w+:)?id>([^<]*)</(?:\w+:)?id>`)
+
+// x14CfRegexp matches the conditional formatting in the extension list of the
+// worksheet.
+var x14CfRegexp = regexp.MustCompile(`(?s)<(?:\w+:)?conditionalFormatting\b[^>]*>.*?</(?:\w+:)?conditionalFormatting>`)
+
+// x14CfRuleRegexp matches the conditional formatting rule with the ID in the
+// extension list of the worksheet.
+var x14CfRuleRegexp = regexp.MustCompile(`(?s)<(?:\w+:)?cfRule\b[^>]*\bid="([^"]*)"[^>]*>.*?</(?:\w+:)?cfRule>`)
+
+// x14SqrefRegexp matches the range of the conditional formatting in the
+// extension list of the worksheet.
+var x14SqrefRegexp = regexp.MustCompile(`<((?:\w+:)?)sqref>([^<]*)</(?:\w+:)?sqref>`)
+
+// x14CfExtRegexp matches the extension of the worksheet without any
+// conditional formatting left.
+var x14CfExtRegexp = regexp.MustCompile(`(?s)<ext\b[^>]*>\s*<(?:\w+:)?conditionalFormattings>\s*</(?:\w+:)?conditionalFormattings>\s*</ext>`)
+
+// adjustConditionalFormatsExt provides a function to keep the conditional
+// formats in the extension list of the worksheet, such as the data bars with
+// the negative fill and the axis color, in sync with the conditional formats
+// when inserting or deleting rows or columns. The ranges are updated, and the
+// extended rules of the removed conditional formatting rules given by their
+// IDs are removed. The conditional formatting without range or rules left
+// will be removed.
+func adjustConditionalFormatsExt(xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping, removedIDs map[string]bool) error {
+	if xlsx.ExtLst == nil || !strings.Contains(xlsx.ExtLst.Ext, "conditionalFormatting") {
+		return nil
+	}
+	var err error
+	ext := x14CfRegexp.ReplaceAllStringFunc(xlsx.ExtLst.Ext, func(cf string) string {
+		var sqref string
+		cf = x14SqrefRegexp.ReplaceAllStringFunc(cf, func(element string) string {
+			match := x14SqrefRegexp.FindStringSubmatch(element)
+			if sqref, err = adjustSqref(match[2], cache, dir, m); err != nil {
+				return element
+			}
+			return "<" + match[1] + "sqref>" + sqref + "</" + match[1] + "sqref>"
+		})
+		cf = x14CfRuleRegexp.ReplaceAllStringFunc(cf, func(rule string) string {
+			if removedIDs[x14CfRuleRegexp.FindStringSubmatch(rule)[1]] {
+				return ""
+			}
+			return rule
+		})
+		if err == nil && (sqref == "" || !x14CfRuleRegexp.MatchString(cf)) {
+			return ""
+		}
+		return cf
+	})
+	if err != nil {
+		return err
+	}
+	xlsx.ExtLst.Ext = x14CfExtRegexp.ReplaceAllString(ext, "")
+	if strings.TrimSpace(xlsx.ExtLst.Ext) == "" {
+		xlsx.ExtLst = nil
+	}
+	return nil
+}
+
+// renumberCfRulePriorities provides a function to renumber the priorities of
+// the conditional formatting rules from 1 contiguously, in the order of
+// their priorities. The rules without priority are left unchanged.
+func renumberCfRulePriorities(conditionalFormats []*xlsxConditionalFormatting) {
+	var rules []*xlsxCfRule
+	for _, cf := range conditionalFormats {
+		for _, rule := range cf.CfRule {
+			if rule.Priority > 0 {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+	for i, rule := range rules {
+		rule.Priority = i + 1
+	}
+}
+
+// adjustDataValidations provides a function to update the cell ranges and
+// the references in the formulas of the data validations when inserting or
+// deleting rows or columns. The other settings of the data validations, such
+// as the input and error messages, are kept. The data validation will be
+// removed if all of its ranges are deleted. Deleting the middle rows or
+// columns of a range splits the data validation into the data validations
+// with the same settings, which cover the cells left before and after the
+// deleted cells respectively.
+func (f *File) adjustDataValidations(sheet string, xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) error {
+	if xlsx.DataValidations == nil {
+		return nil
+	}
+	var dataValidations []*DataValidation
+	for _, dataValidation := range xlsx.DataValidations.DataValidation {
+		sqrefs, err := splitSqref(dataValidation.Sqref, cache, dir, m)
+		if err != nil {
+			return err
+		}
+		dataValidation.Formula1 = adjustReferences(dataValidation.Formula1, sheet, true, dir, m)
+		dataValidation.Formula2 = adjustReferences(dataValidation.Formula2, sheet, true, dir, m)
+		for i, sqref := range sqrefs {
+			dv := dataValidation
+			if i > 0 {
+				split := *dataValidation
+				dv = &split
+			}
+			dv.Sqref = sqref
+			dataValidations = append(dataValidations, dv)
+		}
+	}
+	if len(dataValidations) == 0 {
+		xlsx.DataValidations = nil
+		return nil
+	}
+	xlsx.DataValidations.DataValidation = dataValidations
+	xlsx.DataValidations.Count = len(dataValidations)
+	return nil
+}
+
+// adjustIgnoredErrors provides a function to update the ranges of the
+// ignored errors of the worksheet when inserting or deleting rows or
+// columns. The ignored errors whose ranges are wholly deleted are removed.
+func adjustIgnoredErrors(xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) error {
+	if xlsx.IgnoredErrors == nil {
+		return nil
+	}
+	ignoredErrors := xlsx.IgnoredErrors.IgnoredError[:0]
+	for _, ignoredError := range xlsx.IgnoredErrors.IgnoredError {
+		sqref, err := adjustSqref(ignoredError.Sqref, cache, dir, m)
+		if err != nil {
+			return err
+		}
+		if sqref == "" {
+			continue
+		}
+		ignoredError.Sqref = sqref
+		ignoredErrors = append(ignoredErrors, ignoredError)
+	}
+	if len(ignoredErrors) == 0 {
+		xlsx.IgnoredErrors = nil
+		return nil
+	}
+	xlsx.IgnoredErrors.IgnoredError = ignoredErrors
+	return nil
+}
+
+// adjustLockedCells provides a function to keep the unlocked cells of the
+// worksheet continuous when inserting rows or columns between them. The
+// inserted cells between two unlocked cells get the style of the cell before
+// them, so that the unlocked region grows with the insertion and the inserted
+// cells can still be edited when the worksheet is protected.
+func (f *File) adjustLockedCells(xlsx *xlsxWorksheet, dir AdjustDirection, num, offset int) {
+	s := f.stylesReader()
+	if offset < 1 || num < 2 || s.CellXfs == nil {
+		return
+	}
+	unlocked := func(col, style int) bool {
+		style = f.prepareCellStyle(xlsx, col, style)
+		return style < len(s.CellXfs.Xf) && s.CellXfs.Xf[style].Protection != nil && !s.CellXfs.Xf[style].Protection.Locked
+	}
+	if dir == rows {
+		if num+offset > len(xlsx.SheetData.Row) {
+			return
+		}
+		before, after := xlsx.SheetData.Row[num-2].C, xlsx.SheetData.Row[num+offset-1].C
+		for col := 1; col <= len(before) && col <= len(after); col++ {
+			if !unlocked(col, before[col-1].S) || !unlocked(col, after[col-1].S) {
+				continue
+			}
+			style := f.prepareCellStyle(xlsx, col, before[col-1].S)
+			for row := num; row < num+offset; row++ {
+				prepareSheetXML(xlsx, col, row)
+				xlsx.SheetData.Row[row-1].C[col-1].S = style
+			}
+		}
+		return
+	}
+	for rowIdx := range xlsx.SheetData.Row {
+		cells := xlsx.SheetData.Row[rowIdx].C
+		if num+offset > len(cells) || !unlocked(num-1, cells[num-2].S) || !unlocked(num+offset, cells[num+offset-1].S) {
+			continue
+		}
+		style := f.prepareCellStyle(xlsx, num-1, cells[num-2].S)
+		for col := num; col < num+offset; col++ {
+			cells[col-1].S = style
+		}
+	}
+}
+
+// adjustProtectedCells provides a function to update the cell ranges of the
+// protected ranges, which are allowed to be edited when the sheet is
+// protected, when inserting or deleting rows or columns. The protected range
+// will be removed if all of its ranges are deleted.
+func (f *File) adjustProtectedCells(xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) error {
+	if xlsx.ProtectedRanges == nil {
+		return nil
+	}
+	protectedRanges := xlsx.ProtectedRanges.ProtectedRange[:0]
+	for _, protectedRange := range xlsx.ProtectedRanges.ProtectedRange {
+		sqref, err := adjustSqref(protectedRange.Sqref, cache, dir, m)
+		if err != nil {
+			return err
+		}
+		if sqref == "" {
+			continue
+		}
+		protectedRange.Sqref = sqref
+		protectedRanges = append(protectedRanges, protectedRange)
+	}
+	if len(protectedRanges) == 0 {
+		xlsx.ProtectedRanges = nil
+		return nil
+	}
+	xlsx.ProtectedRanges.ProtectedRange = protectedRanges
+	return nil
+}
+
+// adjustScenarios provides a function to update the input cells and the
+// result cells of the what-if scenarios of the worksheet when inserting or
+// deleting rows or columns. The scenario will be removed if any of its input
+// cells is deleted, and the indexes of the current and the shown scenario are
+// updated.
+func adjustScenarios(xlsx *xlsxWorksheet, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) error {
+	if xlsx.Scenarios == nil {
+		return nil
+	}
+	sqref, err := adjustSqref(xlsx.Scenarios.Sqref, cache, dir, m)
+	if err != nil {
+		return err
+	}
+	xlsx.Scenarios.Sqref = sqref
+	indexes := make(map[int]int, len(xlsx.Scenarios.Scenario))
+	scenarios := xlsx.Scenarios.Scenario[:0]
+	for idx, scenario := range xlsx.Scenarios.Scenario {
+		refs := make([]string, len(scenario.InputCells))
+		deleted := false
+		for i, inputCell := range scenario.InputCells {
+			if refs[i], err = adjustSqref(inputCell.R, cache, dir, m); err != nil {
+				return err
+			}
+			deleted = deleted || refs[i] == ""
+		}
+		if deleted {
+			continue
+		}
+		for i, inputCell := range scenario.InputCells {
+			inputCell.R = refs[i]
+		}
+		indexes[idx] = len(scenarios)
+		scenarios = append(scenarios, scenario)
+	}
+	if len(scenarios) == 0 {
+		xlsx.Scenarios = nil
+		return nil
+	}
+	xlsx.Scenarios.Scenario = scenarios
+	xlsx.Scenarios.Current, xlsx.Scenarios.Show = indexes[xlsx.Scenarios.Current], indexes[xlsx.Scenarios.Show]
+	return nil
+}
+
+// adjustSqref provides a function to update a space separated list of cell
+// references and areas when inserting or deleting rows or columns. The
+// references which are deleted entirely will be dropped from the list.
+func adjustSqref(sqref string, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) (string, error) {
+	var refs []string
+	for _, ref := range strings.Fields(sqref) {
+		area := ref
+		if !strings.Contains(area, ":") {
+			area = ref + ":" + ref
+		}
+		coordinates, err := cache.areaRefToCoordinates(area)
+		if err != nil {
+			return "", err
+		}
+		if !m.adjustArea(coordinates, dir) {
+			continue
+		}
+		refs = append(refs, coordinatesToSqref(coordinates))
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// splitSqref provides a function to update a space separated list of cell
+// references and areas when inserting or deleting rows or columns, the areas
+// are split at the deleted rows or columns inside of them. The nth list
+// returned consists of the nth parts of the split areas and the areas which
+// are not split are in the first list. The references which are deleted
+// entirely will be dropped.
+func splitSqref(sqref string, cache cellCoordinatesCache, dir AdjustDirection, m adjustMapping) ([]string, error) {
+	idx := 1
+	if dir == columns {
+		idx = 0
+	}
+	var lists [][]string
+	for _, ref := range strings.Fields(sqref) {
+		area := ref
+		if !strings.Contains(area, ":") {
+			area = ref + ":" + ref
+		}
+		coordinates, err := cache.areaRefToCoordinates(area)
+		if err != nil {
+			return nil, err
+		}
+		ranges := [][2]int{{coordinates[idx], coordinates[idx+2]}}
+		if m.inBand(coordinates[1-idx], coordinates[3-idx]) {
+			ranges = m.splitRange(coordinates[idx], coordinates[idx+2])
+		}
+		for i, r := range ranges {
+			coordinates[idx], coordinates[idx+2] = r[0], r[1]
+			if i == len(lists) {
+				lists = append(lists, nil)
+			}
+			lists[i] = append(lists[i], coordinatesToSqref(coordinates))
+		}
+	}
+	sqrefs := make([]string, len(lists))
+	for i, refs := range lists {
+		sqrefs[i] = strings.Join(refs, " ")
+	}
+	return sqrefs, nil
+}
+
+// coordinatesToSqref provides a function to convert the coordinates of an
+// area to the reference in the list of the cell references and areas, the
+// area of a single cell is converted to the cell reference.
+func coordinatesToSqref(coordinates []int) string {
+	firstCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+	if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+		return firstCell
+	}
+	lastCell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+	return firstCell + ":" + lastCell
+}
+
+// adjustPanes provides a function to update the frozen panes when inserting
+// or deleting rows or columns. The split is reduced when the frozen rows or
+// columns are deleted, and the top left cell of the bottom right pane is
+// kept below and to the right of the split. If all of the frozen rows or
+// columns are deleted, the panes which no longer exist are merged, and the
+// pane will be removed when there is no split left. The frozen panes given by
+// the top left cell only, without splits, are kept and their top left cell
+// is shifted.
+func (f *File) adjustPanes(xlsx *xlsxWorksheet, dir AdjustDirection, m adjustMapping) {
+	for i := range xlsx.SheetViews.SheetView {
+		view := &xlsx.SheetViews.SheetView[i]
+		pane := view.Pane
+		if pane == nil || (pane.State != "frozen" && pane.State != "frozenSplit") {
+			continue
+		}
+		xSplit, ySplit := int(pane.XSplit), int(pane.YSplit)
+		col, row, err := CellNameToCoordinates(pane.TopLeftCell)
+		if err != nil {
+			col, row = xSplit+1, ySplit+1
+		}
+		split, cell := &ySplit, &row
+		if dir == columns {
+			split, cell = &xSplit, &col
+		}
+		collapsed := false
+		if *split > 0 {
+			if _, last, ok := m.adjustRange(1, *split); ok {
+				*split = last
+			} else {
+				*split, collapsed = 0, true
+			}
+		}
+		*cell = m.adjust(*cell)
+		if *cell <= *split || collapsed {
+			*cell = *split + 1
+		}
+		pane.XSplit, pane.YSplit = float64(xSplit), float64(ySplit)
+		pane.TopLeftCell, _ = CoordinatesToCellName(col, row)
+		if collapsed {
+			adjustCollapsedPanes(view, dir)
+		}
+	}
+}
+
+// adjustCollapsedPanes provides a function to merge the panes of the sheet
+// view when all of the frozen rows or columns are deleted. The selection of
+// the active pane takes precedence over the selections of the other panes
+// which are merged into the same pane.
+func adjustCollapsedPanes(view *xlsxSheetView, dir AdjustDirection) {
+	pane := view.Pane
+	mergePanes := map[string]string{"bottomLeft": "topLeft", "bottomRight": "topRight"}
+	if dir == columns {
+		mergePanes = map[string]string{"topRight": "topLeft", "bottomRight": "bottomLeft"}
+	}
+	noSplit := pane.XSplit == 0 && pane.YSplit == 0
+	if noSplit {
+		view.Pane = nil
+	}
+	mergePane := func(p string) string {
+		if noSplit {
+			return ""
+		}
+		if merged, ok := mergePanes[p]; ok {
+			return merged
+		}
+		return p
+	}
+	var selections []*xlsxSelection
+	merged := map[string]int{}
+	for _, selection := range view.Selection {
+		active := selection.Pane == pane.ActivePane
+		selection.Pane = mergePane(selection.Pane)
+		if idx, ok := merged[selection.Pane]; ok {
+			if active {
+				selections[idx] = selection
+			}
+			continue
+		}
+		merged[selection.Pane] = len(selections)
+		selections = append(selections, selection)
+	}
+	view.Selection = selections
+	pane.ActivePane = mergePane(pane.ActivePane)
+}
+
+// adjustDefinedNames provides a function to update the references of the
+// defined names which refer to the worksheet, such as the print titles which
+// refer to the entire rows or columns, when inserting or deleting rows or
+// columns. The reference will be replaced by #REF! if all of its cells are
+// deleted. It reports whether any defined name is changed.
+func (f *File) adjustDefinedNames(sheet string, dir AdjustDirection, m adjustMapping) bool {
+	wb := f.workbookReader()
+	if wb.DefinedNames == nil {
+		return false
+	}
+	var changed bool
+	for i := range wb.DefinedNames.DefinedName {
+		definedName := &wb.DefinedNames.DefinedName[i]
+		if data := adjustReferences(definedName.Data, sheet, false, dir, m); data != definedName.Data {
+			definedName.Data, changed = data, true
+		}
+	}
+	return changed
+}
+
+// adjustFilterDatabase provides a function to keep the built-in defined name
+// _xlnm._FilterDatabase of the worksheet in sync with the auto filter of the
+// worksheet after the defined names have been adjusted. The defined name will
+// be removed if the auto filter is cleared or its range is deleted. It
+// reports whether the defined name is changed.
+func (f *File) adjustFilterDatabase(sheet string, xlsx *xlsxWorksheet, hasAutoFilter bool) bool {
+	wb := f.workbookReader()
+	if wb.DefinedNames == nil {
+		return false
+	}
+	sheetID, err := f.getSheetPosition(sheet)
+	if err != nil {
+		return false
+	}
+	var changed bool
+	definedNames := wb.DefinedNames.DefinedName[:0]
+	for _, definedName := range wb.DefinedNames.DefinedName {
+		if definedName.Name == "_xlnm._FilterDatabase" && definedName.LocalSheetID != nil && *definedName.LocalSheetID == sheetID {
+			if xlsx.AutoFilter != nil {
+				if data := absoluteAreaReference(sheet, xlsx.AutoFilter.Ref); data != definedName.Data {
+					definedName.Data, changed = data, true
+				}
+			} else if hasAutoFilter || strings.Contains(definedName.Data, "#REF!") {
+				changed = true
+				continue
+			}
+		}
+		definedNames = append(definedNames, definedName)
+	}
+	wb.DefinedNames.DefinedName = definedNames
+	if len(definedNames) == 0 {
+		wb.DefinedNames = nil
+	}
+	return changed
+}
+
+// absoluteAreaReference provides a function to get the absolute reference of
+// the area on the worksheet, such as Sheet1!$A$1:$C$10.
+func absoluteAreaReference(sheet, area string) string {
+	var cells []string
+	for _, cell := range strings.Split(area, ":") {
+		if col, row, err := SplitCellName(cell); err == nil {
+			cell = "$" + col + "$" + strconv.Itoa(row)
+		}
+		cells = append(cells, cell)
+	}
+	return quoteSheetName(sheet) + "!" + strings.Join(cells, ":")
+}
+
+// adjustFormulas provides a function to update the references to the
+// worksheet in the formulas of the cells in all worksheets when inserting or
+// deleting rows or columns, and the range of the shared and array formulas
+// on the worksheet. It reports whether any formula is changed.
+func (f *File) adjustFormulas(sheet string, dir AdjustDirection, m adjustMapping) bool {
+	var changed bool
+	for name := range f.sheetMap {
+		local := name == trimSheetName(sheet)
+		xlsx, err := f.referencingWorkSheetReader(name, func(formula string) bool {
+			return adjustReferences(formula, sheet, local, dir, m) != formula
+		})
+		if err != nil || xlsx == nil {
+			continue
+		}
+		for rowIdx := range xlsx.SheetData.Row {
+			for colIdx := range xlsx.SheetData.Row[rowIdx].C {
+				formula := xlsx.SheetData.Row[rowIdx].C[colIdx].F
+				if formula == nil {
+					continue
+				}
+				if content := adjustReferences(formula.Content, sheet, local, dir, m); content != formula.Content {
+					formula.Content, changed = content, true
+				}
+				if !local || formula.Ref == "" {
+					continue
+				}
+				if ref, ok := adjustCellReference(formula.Ref, dir, m); ok && ref != formula.Ref {
+					formula.Ref, changed = ref, true
+				}
+			}
+		}
+	}
+	return changed
+}
+
+// sheetFormulaRegexp matches the formulas of the cells and the locations of
+// the hyperlinks in the raw XML of the worksheet.
+var sheetFormulaRegexp = regexp.MustCompile(`<(?:\w+:)?f\b[^>]*>([^<]*)</(?:\w+:)?f>|<(?:\w+:)?hyperlink\b[^>]*\slocation="([^"]*)"`)
+
+// referencingWorkSheetReader provides a function to get the worksheet for
+// updating the references in it by given worksheet name and the function
+// which reports whether a formula will be changed. The worksheet already
+// loaded is returned directly. Otherwise the formulas and the hyperlink
+// locations are read from the raw XML of the worksheet, and the worksheet is
+// loaded only if any of them will be changed, so that the worksheets without
+// the references to be updated are kept as they are. It returns nil if the
+// worksheet doesn't need to be loaded.
+func (f *File) referencingWorkSheetReader(sheet string, changed func(formula string) bool) (*xlsxWorksheet, error) {
+	if xlsx := f.Sheet[f.sheetMap[trimSheetName(sheet)]]; xlsx != nil {
+		return xlsx, nil
+	}
+	for _, match := range sheetFormulaRegexp.FindAllSubmatch(f.XLSX[f.sheetMap[trimSheetName(sheet)]], -1) {
+		if changed(html.UnescapeString(string(match[1]) + string(match[2]))) {
+			return f.workSheetReader(sheet)
+		}
+	}
+	return nil, nil
+}
+
+// promoteSharedFormulas provides a function to promote a cell of the shared
+// formulas whose master cells are in the rows or columns to be deleted, to be
+// the new master cell. The first cell of the shared
+// formula left after deletion takes the formula derived from the formula of
+// the master cell, and the range of the cells left.
+func (f *File) promoteSharedFormulas(xlsx *xlsxWorksheet, dir AdjustDirection, m adjustMapping) error {
+	idx := 1
+	if dir == columns {
+		idx = 0
+	}
+	deleted := func(col, row int) bool {
+		return m.isDeleted([]int{col, row}[idx])
+	}
+	for rowIdx := range xlsx.SheetData.Row {
+		for colIdx := range xlsx.SheetData.Row[rowIdx].C {
+			master := xlsx.SheetData.Row[rowIdx].C[colIdx].F
+			if master == nil || master.T != STCellFormulaTypeShared || master.Ref == "" || !deleted(colIdx+1, rowIdx+1) {
+				continue
+			}
+			area, err := areaRefToCoordinates(master.Ref)
+			if err != nil {
+				return err
+			}
+			for area[idx] <= area[idx+2] && m.isDeleted(area[idx]) {
+				area[idx]++
+			}
+			if area[idx] > area[idx+2] {
+				continue
+			}
+			f.promoteSharedFormula(xlsx, master, colIdx+1, rowIdx+1, area, deleted)
+		}
+	}
+	return nil
+}
+
+// promoteSharedFormula provides a function to promote the first cell of the
+// shared formula in the area which is not deleted to be the master cell of
+// the shared formula, by given master cell formula and coordinates.
+func (f *File) promoteSharedFormula(xlsx *xlsxWorksheet, master *xlsxF, col, row int, area []int, deleted func(col, row int) bool) {
+	for r := area[1]; r <= area[3] && r <= len(xlsx.SheetData.Row); r++ {
+		cells := xlsx.SheetData.Row[r-1].C
+		for c := area[0]; c <= area[2] && c <= len(cells); c++ {
+			formula := cells[c-1].F
+			if deleted(c, r) || formula == nil || formula.T != STCellFormulaTypeShared || formula.Si != master.Si || formula.Ref != "" {
+				continue
+			}
+			formula.Content = f.offsetReferences(master.Content, c-col, r-row)
+			firstCell, _ := CoordinatesToCellName(area[0], area[1])
+			lastCell, _ := CoordinatesToCellName(area[2], area[3])
+			formula.Ref = firstCell + ":" + lastCell
+			return
+		}
+	}
+}
+
+// offsetReferences provides a function to move the relative references in
+// the formula by given numbers of columns and rows, as the formula of a
+// shared formula is derived for the cells other than the master cell. The
+// references moved out of the worksheet are replaced by #REF!.
+func (f *File) offsetReferences(formula string, colOffset, rowOffset int) string {
+	fn := func(ref string) string {
+		return offsetCellReference(ref, colOffset, rowOffset)
+	}
+	formula = replaceReferences(formula, "", true, fn)
+	for name := range f.sheetMap {
+		formula = replaceReferences(formula, name, false, fn)
+	}
+	return formula
+}
+
+// offsetCellReference provides a function to move the relative parts of the
+// cell reference, area reference or reference to the entire rows or columns
+// by given numbers of columns and rows.
+func offsetCellReference(ref string, colOffset, rowOffset int) string {
+	parts := strings.Split(ref, ":")
+	for i, part := range parts {
+		if coordinates := cellReferenceRegexp.FindStringSubmatch(part); coordinates != nil {
+			col, _ := ColumnNameToNumber(coordinates[2])
+			row, _ := strconv.Atoi(coordinates[4])
+			if coordinates[1] == "" {
+				col += colOffset
+			}
+			if coordinates[3] == "" {
+				row += rowOffset
+			}
+			colName, err := ColumnNumberToName(col)
+			if err != nil || col > TotalColumns || row < 1 || row > TotalRows {
+				return "#REF!"
+			}
+			parts[i] = coordinates[1] + colName + coordinates[3] + strconv.Itoa(row)
+			continue
+		}
+		whole := wholeReferenceRegexp.FindStringSubmatch(part)
+		if whole == nil || whole[1] != "" {
+			continue
+		}
+		if row, err := strconv.Atoi(whole[2]); err == nil {
+			if row += rowOffset; row < 1 || row > TotalRows {
+				return "#REF!"
+			}
+			parts[i] = strconv.Itoa(row)
+			continue
+		}
+		col, _ := ColumnNameToNumber(whole[2])
+		colName, err := ColumnNumberToName(col + colOffset)
+		if err != nil || col+colOffset > TotalColumns {
+			return "#REF!"
+		}
+		parts[i] = colName
+	}
+	return strings.Join(parts, ":")
+}
+
+// convertReferences provides a function to convert the cell references in
+// the formula to the absolute references if absolute is true, otherwise to
+// the relative references.
+func (f *File) convertReferences(formula string, absolute bool) string {
+	fn := func(ref string) string {
+		return convertCellReference(ref, absolute)
+	}
+	formula = replaceReferences(formula, "", true, fn)
+	for name := range f.sheetMap {
+		formula = replaceReferences(formula, name, false, fn)
+	}
+	return formula
+}
+
+// convertCellReference provides a function to convert a cell reference, an
+// area reference or a reference to the entire rows or columns to the absolute
+// reference if absolute is true, otherwise to the relative reference.
+func convertCellReference(ref string, absolute bool) string {
+	parts := strings.Split(ref, ":")
+	for i, part := range parts {
+		part = strings.Replace(part, "$", "", -1)
+		if absolute {
+			if coordinates := cellReferenceRegexp.FindStringSubmatch(part); coordinates != nil {
+				part = "$" + coordinates[2] + "$" + coordinates[4]
+			} else {
+				part = "$" + part
+			}
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ":")
+}
+
+// referenceRegexp matches the cell references, area references and the
+// references to the entire rows or columns with an optional worksheet name in
+// the formula, such as A1, $A$1:$B$2, Sheet1!A1, 'Sheet 1'!$A1:B$2,
+// Sheet1!$1:$2 and A:B.
+var referenceRegexp = regexp.MustCompile(`((?:'(?:[^']|'')+'|[\p{L}\p{N}_.]+)!)?(\$?[A-Z]{1,3}\$?[0-9]+(?::\$?[A-Z]{1,3}\$?[0-9]+)?|\$?[0-9]+:\$?[0-9]+|\$?[A-Z]{1,3}:\$?[A-Z]{1,3})`)
+
+// cellReferenceRegexp matches a single cell reference with optional absolute
+// reference markers.
+var cellReferenceRegexp = regexp.MustCompile(`^(\$?)([A-Z]{1,3})(\$?)([0-9]+)$`)
+
+// wholeReferenceRegexp matches a row number or a column name of the reference
+// to the entire rows or columns with optional absolute reference marker.
+var wholeReferenceRegexp = regexp.MustCompile(`^(\$?)([A-Z]{1,3}|[0-9]+)$`)
+
+// adjustReferences provides a function to update the references to the
+// worksheet in the formula when inserting or deleting rows or columns. The
+// references without a worksheet name are treated as the references to the
+// worksheet only if local is true. The references in string literals, to
+// other worksheets, across the worksheets and to external workbooks are left
+// unchanged.
+func adjustReferences(formula, sheet string, local bool, dir AdjustDirection, m adjustMapping) string {
+	return replaceReferences(formula, sheet, local, func(ref string) string {
+		ref, ok := adjustCellReference(ref, dir, m)
+		if !ok {
+			return "#REF!"
+		}
+		return ref
+	})
+}
+
+// replaceReferences provides a function to replace the references to the
+// worksheet in the formula by the result of the given function. The
+// references are selected in the same way as adjustReferences.
+func replaceReferences(formula, sheet string, local bool, fn func(ref string) string) string {
+	matches := referenceRegexp.FindAllStringSubmatchIndex(formula, -1)
+	if len(matches) == 0 {
+		return formula
+	}
+	literals := stringLiterals(formula)
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if literals[start] || (start > 0 && strings.ContainsAny(formula[start-1:start], "$!]_.")) ||
+			(start > 0 && isNameByte(formula[start-1])) ||
+			(end < len(formula) && (formula[end] == '(' || isNameByte(formula[end]))) {
+			continue
+		}
+		if m[2] < 0 && !local {
+			continue
+		}
+		// Skip the 3-D references across the worksheets, such as
+		// Sheet1:Sheet3!A1.
+		if m[2] >= 0 && start > 0 && formula[start-1] == ':' {
+			continue
+		}
+		if m[2] >= 0 && !strings.EqualFold(unquoteSheetName(formula[m[2]:m[3]-1]), sheet) {
+			continue
+		}
+		b.WriteString(formula[last:m[4]])
+		b.WriteString(fn(formula[m[4]:m[5]]))
+		last = m[5]
+	}
+	b.WriteString(formula[last:])
+	return b.String()
+}
+
+// renameSheetInFormula provides a function to replace the worksheet name of
+// the references to the worksheet in the formula with the new name, the new
+// name is quoted if required. The references in string literals and across
+// the worksheets are left unchanged.
+func renameSheetInFormula(formula, oldName, newName string) string {
+	matches := referenceRegexp.FindAllStringSubmatchIndex(formula, -1)
+	if len(matches) == 0 {
+		return formula
+	}
+	literals := stringLiterals(formula)
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start := m[0]
+		if m[2] < 0 || literals[start] || (start > 0 && (strings.ContainsAny(formula[start-1:start], "$!]_.:") || isNameByte(formula[start-1]))) {
+			continue
+		}
+		if !strings.EqualFold(unquoteSheetName(formula[m[2]:m[3]-1]), oldName) {
+			continue
+		}
+		b.WriteString(formula[last:m[2]])
+		b.WriteString(quoteSheetName(newName))
+		last = m[3] - 1
+	}
+	b.WriteString(formula[last:])
+	return b.String()
+}
+
+// adjustCellReference provides a function to update a cell reference or an
+// area reference when inserting or deleting rows or columns, the absolute
+// reference markers are kept. The second return value reports whether any
+// part of the reference is left after deletion.
+func adjustCellReference(ref string, dir AdjustDirection, m adjustMapping) (string, bool) {
+	parts := strings.Split(ref, ":")
+	coordinates := make([][]string, len(parts))
+	values := make([][2]int, len(parts))
+	for i, part := range parts {
+		coordinates[i] = cellReferenceRegexp.FindStringSubmatch(part)
+		if coordinates[i] == nil {
+			return adjustWholeReference(ref, dir, m)
+		}
+		col, _ := ColumnNameToNumber(coordinates[i][2])
+		row, _ := strconv.Atoi(coordinates[i][4])
+		values[i] = [2]int{col, row}
+	}
+	idx := 1
+	if dir == columns {
+		idx = 0
+	}
+	if !m.inBand(values[0][1-idx], values[len(values)-1][1-idx]) {
+		return ref, true
+	}
+	first, last := values[0][idx], values[len(values)-1][idx]
+	first, last, ok := m.adjustRange(first, last)
+	if !ok {
+		return "", false
+	}
+	values[0][idx], values[len(values)-1][idx] = first, last
+	for i := range parts {
+		colName, _ := ColumnNumberToName(values[i][0])
+		parts[i] = coordinates[i][1] + colName + coordinates[i][3] + strconv.Itoa(values[i][1])
+	}
+	return strings.Join(parts, ":"), true
+}
+
+// adjustWholeReference provides a function to update a reference to the
+// entire rows or columns, such as $1:$2 and A:B, when inserting or deleting
+// rows or columns, the absolute reference markers are kept. The second return
+// value reports whether any part of the reference is left after deletion.
+func adjustWholeReference(ref string, dir AdjustDirection, m adjustMapping) (string, bool) {
+	parts := strings.Split(ref, ":")
+	if len(parts) != 2 || m.limited() {
+		return ref, true
+	}
+	first, last := wholeReferenceRegexp.FindStringSubmatch(parts[0]), wholeReferenceRegexp.FindStringSubmatch(parts[1])
+	if first == nil || last == nil {
+		return ref, true
+	}
+	firstNum, firstErr := strconv.Atoi(first[2])
+	lastNum, lastErr := strconv.Atoi(last[2])
+	if dir == rows {
+		if firstErr != nil || lastErr != nil {
+			return ref, true
+		}
+		firstNum, lastNum, ok := m.adjustRange(firstNum, lastNum)
+		if !ok {
+			return "", false
+		}
+		return first[1] + strconv.Itoa(firstNum) + ":" + last[1] + strconv.Itoa(lastNum), true
+	}
+	if firstErr == nil || lastErr == nil {
+		return ref, true
+	}
+	firstNum, _ = ColumnNameToNumber(first[2])
+	lastNum, _ = ColumnNameToNumber(last[2])
+	firstNum, lastNum, ok := m.adjustRange(firstNum, lastNum)
+	if !ok {
+		return "", false
+	}
+	firstCol, _ := ColumnNumberToName(firstNum)
+	lastCol, _ := ColumnNumberToName(lastNum)
+	return first[1] + firstCol + ":" + last[1] + lastCol, true
+}
+
+// stringLiterals provides a function to mark the bytes of the formula which
+// are inside of the string literals.
+func stringLiterals(formula string) []bool {
+	literals := make([]bool, len(formula)+1)
+	inString := false
+	for i := 0; i < len(formula); i++ {
+		if formula[i] == '"' {
+			inString = !inString
+		}
+		literals[i] = inString
+	}
+	return literals
+}
+
+// isNameByte reports whether the byte can be a part of a function name,
+// defined name or cell reference.
+func isNameByte(c byte) bool {
+	return c >= 0x80 || c == '_' || (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// unquoteSheetName provides a function to remove the quotes of the worksheet
+// name in the reference, such as 'Sheet 1' and 'Bob''s Sheet'.
+func unquoteSheetName(name string) string {
+	if len(name) > 1 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+		return strings.Replace(name[1:len(name)-1], "''", "'", -1)
+	}
+	return name
+}
+
+// quoteSheetName provides a function to quote the worksheet name in the
+// reference if it starts with a digit or contains the characters other than
+// letters, digits and underscores, such as 'Sheet 1' and 'Bob''s Sheet'.
+func quoteSheetName(name string) string {
+	for i := 0; i < len(name); i++ {
+		if !isNameByte(name[i]) || (i == 0 && name[i] >= '0' && name[i] <= '9') {
+			return "'" + strings.Replace(name, "'", "''", -1) + "'"
+		}
+	}
+	return name
+}
+
+// vmlShapeAnchorRegexp matches the anchor of the shape of the comment, which
+// is a comma separated list of left column, left offset, top row, top offset,
+// right column, right offset, bottom row and bottom offset.
+var vmlShapeAnchorRegexp = regexp.MustCompile(`<x:Anchor>([^<]*)</x:Anchor>`)
+
+// adjustComments provides a function to update the cell references of the
+// comments and the threaded comments, and move the shapes of the comments
+// with the cells when inserting or deleting rows or columns. The absolute
+// position in the style of the shape will be recalculated by the new anchor
+// of the shape, and the comments of the deleted cells will be removed.
+func (f *File) adjustComments(sheet string, xlsx *xlsxWorksheet, dir AdjustDirection, m adjustMapping) {
+	adjustRef := func(ref string) (string, bool) {
+		col, row, err := CellNameToCoordinates(ref)
+		if err != nil {
+			return ref, true
+		}
+		ok := true
+		if dir == rows && m.inBand(col, col) {
+			row, _, ok = adjustCommentCell(row, m)
+		} else if dir == columns && m.inBand(row, row) {
+			col, _, ok = adjustCommentCell(col, m)
+		}
+		if !ok {
+			return ref, false
+		}
+		ref, _ = CoordinatesToCellName(col, row)
+		return ref, true
+	}
+	comments, vml := f.sheetCommentsReader(sheet, xlsx)
+	if comments != nil {
+		commentList := comments.CommentList.Comment[:0]
+		for _, comment := range comments.CommentList.Comment {
+			var ok bool
+			if comment.Ref, ok = adjustRef(comment.Ref); ok {
+				commentList = append(commentList, comment)
+			}
+		}
+		comments.CommentList.Comment = commentList
+	}
+	// The threaded comments are kept in sync with the legacy comments.
+	if threadedComments := f.sheetThreadedCommentsReader(sheet); threadedComments != nil {
+		adjustThreadedComments(threadedComments, adjustRef)
+	}
+	if vml == nil {
+		return
+	}
+	shapes := vml.Shape[:0]
+	var margins *vmlShapeMargins
+	for _, shape := range vml.Shape {
+		if col, row, ok := vmlShapeCell(shape); ok {
+			var delta int
+			if dir == rows && m.inBand(col, col) {
+				row, delta, ok = adjustCommentCell(row, m)
+			} else if dir == columns && m.inBand(row, row) {
+				col, delta, ok = adjustCommentCell(col, m)
+			}
+			if !ok {
+				continue
+			}
+			shape.Val = vmlShapeRowRegexp.ReplaceAllString(shape.Val, "<x:Row>"+strconv.Itoa(row-1)+"</x:Row>")
+			shape.Val = vmlShapeColumnRegexp.ReplaceAllString(shape.Val, "<x:Column>"+strconv.Itoa(col-1)+"</x:Column>")
+			shape.Val = vmlShapeAnchorRegexp.ReplaceAllStringFunc(shape.Val, func(anchor string) string {
+				return "<x:Anchor>" + adjustVMLShapeAnchor(vmlShapeAnchorRegexp.FindStringSubmatch(anchor)[1], dir, delta) + "</x:Anchor>"
+			})
+			if strings.Contains(shape.Style, "margin-") {
+				if margins == nil {
+					margins = newVMLShapeMargins(xlsx)
+				}
+				shape.Style = margins.adjust(shape.Style, shape.Val)
+			}
+		}
+		shapes = append(shapes, shape)
+	}
+	vml.Shape = shapes
+}
+
+// adjustCommentCell provides a function to get the new row or column number
+// of the cell of the comment and the offset it moved. The third return value
+// reports whether the cell is left after deletion.
+func adjustCommentCell(value int, m adjustMapping) (int, int, bool) {
+	newValue, _, ok := m.adjustRange(value, value)
+	return newValue, newValue - value, ok
+}
+
+// adjustVMLShapeAnchor provides a function to move the anchor of the shape of
+// the comment by given offset of the rows or columns, the anchor of the shape
+// can't be moved outside of the worksheet.
+func adjustVMLShapeAnchor(anchor string, dir AdjustDirection, delta int) string {
+	values := strings.Split(anchor, ",")
+	if len(values) != 8 {
+		return anchor
+	}
+	indexes := []int{2, 6}
+	if dir == columns {
+		indexes = []int{0, 4}
+	}
+	var anchors []int
+	for _, idx := range indexes {
+		value, err := strconv.Atoi(strings.TrimSpace(values[idx]))
+		if err != nil {
+			return anchor
+		}
+		anchors = append(anchors, value)
+	}
+	// Keep the size of the shape if it can't be moved by the whole offset.
+	if anchors[0]+delta < 0 {
+		delta = -anchors[0]
+	}
+	for i, idx := range indexes {
+		values[idx] = strconv.Itoa(anchors[i] + delta)
+	}
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return strings.Join(values, ", ")
+}
+
+// adjustTables provides a function to update the ranges of the tables and
+// keep the columns of the tables in sync with the data columns when inserting
+// or deleting rows or columns. New columns inserted in a table will be named
+// by unique default names, and the table will be removed if all of its rows
+// or columns are deleted.
+func (f *File) adjustTables(sheet string, xlsx *xlsxWorksheet, dir AdjustDirection, m adjustMapping) error {
+	if xlsx.TableParts == nil {
+		return nil
+	}
+	tableParts := xlsx.TableParts.TableParts[:0]
+	for _, tablePart := range xlsx.TableParts.TableParts {
+		tableXML := strings.Replace(f.getSheetRelationshipsTargetByID(sheet, tablePart.RID), "..", "xl", -1)
+		content, ok := f.XLSX[tableXML]
+		if !ok {
+			tableParts = append(tableParts, tablePart)
+			continue
+		}
+		var t xlsxTable
+		if err := xml.Unmarshal(namespaceStrictToTransitional(content), &t); err != nil {
+			return err
+		}
+		coordinates, err := areaRefToCoordinates(t.Ref)
+		if err != nil {
+			return err
+		}
+		origin := []int{coordinates[0], coordinates[1], coordinates[2], coordinates[3]}
+		if dir == rows {
+			coordinates[1], coordinates[3], ok = m.adjustRange(coordinates[1], coordinates[3])
+		} else {
+			coordinates[0], coordinates[2], ok = m.adjustRange(coordinates[0], coordinates[2])
+		}
+		if !ok {
+			f.deleteTable(sheet, tablePart.RID, tableXML)
+			continue
+		}
+		firstCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+		lastCell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+		t.Ref = firstCell + ":" + lastCell
+		if t.AutoFilter != nil {
+			if t.AutoFilter.Ref, err = adjustSqref(t.AutoFilter.Ref, nil, dir, m); err != nil {
+				return err
+			}
+			if dir == columns && !adjustFilterColumn(t.AutoFilter, origin[0], coordinates[0], m) {
+				f.unhideFilteredRows(sheet, xlsx, origin[1], origin[3], tableXML)
+			}
+		}
+		if dir == rows && m.offset > 0 && t.TotalsRowCount > 0 && m.num == origin[3] {
+			adjustTotalsRow(sheet, xlsx, &t, coordinates, m.num-1, m.offset)
+		}
+		if dir == columns && t.TableColumns != nil {
+			for col, name := range adjustTableColumns(t.TableColumns, origin[0], origin[2], m) {
+				cell, _ := CoordinatesToCellName(col, coordinates[1])
+				if err = f.SetCellStr(sheet, cell, name); err != nil {
+					return err
+				}
+			}
+		}
+		t.XMLNS = NameSpaceSpreadSheet
+		table, _ := xml.Marshal(t)
+		f.saveFileList(tableXML, table)
+		tableParts = append(tableParts, tablePart)
+	}
+	if len(tableParts) == 0 {
+		xlsx.TableParts = nil
+		return nil
+	}
+	xlsx.TableParts.TableParts = tableParts
+	xlsx.TableParts.Count = len(tableParts)
+	return nil
+}
+
+// adjustTotalsRow provides a function to extend the area references ending at
+// the last data row in the formulas of the totals row and the auto filter of
+// the table when inserting rows between the data rows and the totals row, so
+// that the aggregates of the totals row still cover all the data rows. The
+// coordinates are the range of the table after insertion, and last is the
+// last data row before insertion.
+func adjustTotalsRow(sheet string, xlsx *xlsxWorksheet, t *xlsxTable, coordinates []int, last, offset int) {
+	extend := func(formula string) string {
+		return replaceReferences(formula, sheet, true, func(ref string) string {
+			return extendAreaReference(ref, last, offset)
+		})
+	}
+	if t.AutoFilter != nil {
+		t.AutoFilter.Ref = extendAreaReference(t.AutoFilter.Ref, last, offset)
+	}
+	if t.TableColumns != nil {
+		for _, column := range t.TableColumns.TableColumn {
+			if column.TotalsRowFormula != nil {
+				column.TotalsRowFormula.Content = extend(column.TotalsRowFormula.Content)
+			}
+		}
+	}
+	if len(xlsx.SheetData.Row) < coordinates[3] {
+		return
+	}
+	row := &xlsx.SheetData.Row[coordinates[3]-1]
+	for colIdx := range row.C {
+		col, _, err := CellNameToCoordinates(row.C[colIdx].R)
+		if err != nil || col < coordinates[0] || col > coordinates[2] || row.C[colIdx].F == nil {
+			continue
+		}
+		row.C[colIdx].F.Content = extend(row.C[colIdx].F.Content)
+	}
+}
+
+// extendAreaReference provides a function to extend the area reference
+// ending at the given row by given offset rows. The other references are
+// left unchanged.
+func extendAreaReference(ref string, row, offset int) string {
+	parts := strings.Split(ref, ":")
+	if len(parts) != 2 {
+		return ref
+	}
+	end := cellReferenceRegexp.FindStringSubmatch(parts[1])
+	if end == nil || end[4] != strconv.Itoa(row) {
+		return ref
+	}
+	return parts[0] + ":" + end[1] + end[2] + end[3] + strconv.Itoa(row+offset)
+}
+
+// adjustTableColumns provides a function to insert or remove the columns of
+// the table located in the columns from first to last, and renumber the IDs
+// of the columns. It returns the names of the inserted columns keyed by the
+// column number of the worksheet after insertion.
+func adjustTableColumns(tableColumns *xlsxTableColumns, first, last int, m adjustMapping) map[int]string {
+	num, offset := m.num, m.offset
+	inserted := map[int]string{}
+	columns := tableColumns.TableColumn
+	if offset > 0 {
+		if num <= first || num > last {
+			return inserted
+		}
+		names := map[string]bool{}
+		for _, column := range columns {
+			names[strings.ToLower(column.Name)] = true
+		}
+		idx := num - first
+		newColumns := make([]*xlsxTableColumn, 0, len(columns)+offset)
+		newColumns = append(newColumns, columns[:idx]...)
+		for i, n := 0, idx+1; i < offset; i, n = i+1, n+1 {
+			name := "Column" + strconv.Itoa(n)
+			for names[strings.ToLower(name)] {
+				n++
+				name = "Column" + strconv.Itoa(n)
+			}
+			names[strings.ToLower(name)] = true
+			newColumns = append(newColumns, &xlsxTableColumn{Name: name})
+			inserted[num+i] = name
+		}
+		columns = append(newColumns, columns[idx:]...)
+	} else {
+		newColumns := columns[:0]
+		for i, column := range columns {
+			if m.isDeleted(first + i) {
+				continue
+			}
+			newColumns = append(newColumns, column)
+		}
+		columns = newColumns
+	}
+	for i, column := range columns {
+		column.ID = i + 1
+	}
+	tableColumns.TableColumn = columns
+	tableColumns.Count = len(columns)
+	return inserted
+}
+
+// vmlShapeMarginRegexp matches the absolute position of the shape of the
+// comment in the style of the shape.
+var vmlShapeMarginRegexp = regexp.MustCompile(`margin-(left|top):[^;]*`)
+
+// vmlShapeMargins records the sizes of the rows and columns of the worksheet
+// in pixels to calculate the absolute position of the shapes of the comments.
+type vmlShapeMargins struct {
+	rowHeights       map[int]float64
+	colWidths        map[int]float64
+	defaultRowHeight float64
+}
+
+// newVMLShapeMargins provides a function to get the sizes of the rows and
+// columns of the worksheet.
+func newVMLShapeMargins(xlsx *xlsxWorksheet) *vmlShapeMargins {
+	m := vmlShapeMargins{
+		rowHeights:       make(map[int]float64),
+		colWidths:        make(map[int]float64),
+		defaultRowHeight: defaultRowHeightPixels,
+	}
+	if xlsx.SheetFormatPr != nil && xlsx.SheetFormatPr.DefaultRowHeight != 0 {
+		m.defaultRowHeight = convertRowHeightToPixels(xlsx.SheetFormatPr.DefaultRowHeight)
+	}
+	for _, row := range xlsx.SheetData.Row {
+		if row.Ht != 0 || row.Hidden {
+			m.rowHeights[row.R] = convertRowHeightToPixels(row.Ht)
+		}
+		if row.Hidden {
+			m.rowHeights[row.R] = 0
+		}
+	}
+	if xlsx.Cols != nil {
+		for _, col := range xlsx.Cols.Col {
+			for c := col.Min; c <= col.Max && (col.Width != 0 || col.Hidden); c++ {
+				m.colWidths[c] = convertColWidthToPixels(col.Width)
+				if col.Hidden {
+					m.colWidths[c] = 0
+				}
+			}
+		}
+	}
+	return &m
+}
+
+// adjust provides a function to recalculate the absolute position of the
+// shape in the style by the anchor of the shape, which is the sum of the
+// sizes of the rows and columns before the top left corner of the shape and
+// the offsets in the corner cell.
+func (m *vmlShapeMargins) adjust(style, shape string) string {
+	match := vmlShapeAnchorRegexp.FindStringSubmatch(shape)
+	if match == nil {
+		return style
+	}
+	var anchors []int
+	for _, value := range strings.Split(match[1], ",") {
+		anchor, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return style
+		}
+		anchors = append(anchors, anchor)
+	}
+	if len(anchors) != 8 {
+		return style
+	}
+	left, top := float64(anchors[1]), float64(anchors[3])
+	for col := 1; col <= anchors[0]; col++ {
+		if width, ok := m.colWidths[col]; ok {
+			left += width
+			continue
+		}
+		left += defaultColWidthPixels
+	}
+	for row := 1; row <= anchors[2]; row++ {
+		if height, ok := m.rowHeights[row]; ok {
+			top += height
+			continue
+		}
+		top += m.defaultRowHeight
+	}
+	return vmlShapeMarginRegexp.ReplaceAllStringFunc(style, func(margin string) string {
+		value := top
+		if strings.HasPrefix(margin, "margin-left") {
+			value = left
+		}
+		return margin[:strings.Index(margin, ":")+1] + strconv.FormatFloat(value*0.75, 'f', -1, 64) + "pt"
+	})
+}
+
+// drawingAnchorRegexp matches the starting and ending anchors in the raw
+// content of the anchors of the existing drawing.
+var drawingAnchorRegexp = regexp.MustCompile(`<((?:\w+:)?)(from|to)>\s*<(?:\w+:)?col>(\d+)</(?:\w+:)?col>\s*<(?:\w+:)?colOff>(-?\d+)</(?:\w+:)?colOff>\s*<(?:\w+:)?row>(\d+)</(?:\w+:)?row>\s*<(?:\w+:)?rowOff>(-?\d+)</(?:\w+:)?rowOff>\s*</(?:\w+:)?(?:from|to)>`)
+
+// adjustDrawings provides a function to move the pictures, charts and shapes
+// in the drawing of the worksheet with the cells when inserting or deleting
+// rows or columns. The starting cell of both one cell and two cell anchors
+// will be moved, the extent of the one cell anchor is kept, and the ending
+// cell of the two cell anchor will be moved or shrunk. The object will be
+// removed if its starting cell is deleted, and the object which positioned
+// absolutely will not be moved.
+func (f *File) adjustDrawings(sheet string, xlsx *xlsxWorksheet, dir AdjustDirection, m adjustMapping) {
+	if xlsx.Drawing == nil {
+		return
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, xlsx.Drawing.RID)
+	if target == "" {
+		return
+	}
+	wsDr, _ := f.drawingParser(strings.Replace(target, "..", "xl", -1))
+	wsDr.OneCellAnchor = adjustDrawingAnchors(wsDr.OneCellAnchor, dir, m)
+	wsDr.TwoCellAnchor = adjustDrawingAnchors(wsDr.TwoCellAnchor, dir, m)
+}
+
+// ctrlPropFormulaRegexp matches the attributes of the form control properties
+// referencing the cells, such as the linked cell and the source range.
+var ctrlPropFormulaRegexp = regexp.MustCompile(`\b(fmlaGroup|fmlaLink|fmlaRange|fmlaTxbx)="([^"]*)"`)
+
+// vmlFormulaRegexp matches the elements of the legacy form controls in the
+// VML drawing referencing the cells, such as the linked cell and the source
+// range.
+var vmlFormulaRegexp = regexp.MustCompile(`<x:(Fmla[A-Za-z]+)>([^<]*)</x:Fmla[A-Za-z]+>`)
+
+// adjustFormControls provides a function to update the references to the
+// worksheet in the linked cells and the source ranges of the form controls,
+// such as the check boxes and the list boxes, in all worksheets when
+// inserting or deleting rows or columns. Both of the form control properties
+// and the legacy form controls in the VML drawings are updated.
+func (f *File) adjustFormControls(sheet string, dir AdjustDirection, m adjustMapping) {
+	for name, path := range f.sheetMap {
+		local := name == trimSheetName(sheet)
+		adjust := func(formula string) string {
+			return adjustReferences(formula, sheet, local, dir, m)
+		}
+		rels := f.workSheetRelsReader("xl/worksheets/_rels/" + strings.TrimPrefix(path, "xl/worksheets/") + ".rels")
+		if rels == nil {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			target := strings.Replace(rel.Target, "..", "xl", -1)
+			switch rel.Type {
+			case SourceRelationshipCtrlProp:
+				if content, ok := f.XLSX[target]; ok {
+					f.XLSX[target] = ctrlPropFormulaRegexp.ReplaceAllFunc(content, func(attr []byte) []byte {
+						match := ctrlPropFormulaRegexp.FindSubmatch(attr)
+						var value bytes.Buffer
+						_ = xml.EscapeText(&value, []byte(adjust(html.UnescapeString(string(match[2])))))
+						return []byte(string(match[1]) + `="` + value.String() + `"`)
+					})
+				}
+			case SourceRelationshipDrawingVML:
+				f.adjustVMLFormulas(target, adjust)
+			}
+		}
+	}
+}
+
+// adjustVMLFormulas provides a function to update the formulas of the legacy
+// form controls in the VML drawing by given path and adjust function. The
+// drawing already loaded for editing is updated in place, otherwise the raw
+// content of the drawing is updated without loading it, so that the shapes
+// not created by this library are kept as they are.
+func (f *File) adjustVMLFormulas(path string, adjust func(formula string) string) {
+	replace := func(element string) string {
+		match := vmlFormulaRegexp.FindStringSubmatch(element)
+		formula := html.UnescapeString(match[2])
+		adjusted := adjust(formula)
+		if adjusted == formula {
+			return element
+		}
+		var value bytes.Buffer
+		_ = xml.EscapeText(&value, []byte(adjusted))
+		return "<x:" + match[1] + ">" + value.String() + "</x:" + match[1] + ">"
+	}
+	if vml := f.VMLDrawing[path]; vml != nil {
+		for i := range vml.Shape {
+			vml.Shape[i].Val = vmlFormulaRegexp.ReplaceAllStringFunc(vml.Shape[i].Val, replace)
+		}
+		return
+	}
+	content, ok := f.XLSX[path]
+	if !ok {
+		return
+	}
+	adjusted := vmlFormulaRegexp.ReplaceAllFunc(content, func(element []byte) []byte {
+		return []byte(replace(string(element)))
+	})
+	if !bytes.Equal(adjusted, content) {
+		f.XLSX[path] = adjusted
+		delete(f.DecodeVMLDrawing, path)
+	}
+}
+
+// chartFormulaRegexp matches the references of the chart series, categories
+// and titles, and the cached values following the references.
+var chartFormulaRegexp = regexp.MustCompile(`(?s)<((?:\w+:)?)f>([^<]*)</(?:\w+:)?f>(\s*<(?:\w+:)?(?:numCache|strCache)>.*?</(?:\w+:)?(?:numCache|strCache)>)?`)
+
+// adjustCharts provides a function to update the references to the worksheet
+// in the series, categories and titles of the charts in the workbook when
+// inserting or deleting rows or columns. The references given by the defined
+// names, such as the axis titles bound to the named ranges, follow the
+// defined names updated by adjustDefinedNames. The cached values of the
+// changed references are removed if the ClearChartCaches option is set,
+// otherwise they are kept and may be stale until the charts are refreshed by
+// the spreadsheet application.
+func (f *File) adjustCharts(sheet string, dir AdjustDirection, m adjustMapping) {
+	for path, content := range f.XLSX {
+		if !strings.HasPrefix(path, "xl/charts/chart") {
+			continue
+		}
+		f.XLSX[path] = chartFormulaRegexp.ReplaceAllFunc(content, func(element []byte) []byte {
+			match := chartFormulaRegexp.FindSubmatch(element)
+			formula := html.UnescapeString(string(match[2]))
+			adjusted := adjustReferences(formula, sheet, false, dir, m)
+			if adjusted == formula {
+				return element
+			}
+			var value bytes.Buffer
+			_ = xml.EscapeText(&value, []byte(adjusted))
+			cache := match[3]
+			if f.adjustOptions.clearChartCaches {
+				cache = nil
+			}
+			return []byte("<" + string(match[1]) + "f>" + value.String() + "</" + string(match[1]) + "f>" + string(cache))
+		})
+	}
+}
+
+// pivotSourceRegexp matches the worksheet source element of the cache source
+// in the pivot cache definition.
+var pivotSourceRegexp = regexp.MustCompile(`<(?:\w+:)?worksheetSource\b[^>]*>`)
+
+// pivotSourceAttrRegexp matches the range and the worksheet name attributes
+// of the worksheet source of the pivot cache.
+var pivotSourceAttrRegexp = regexp.MustCompile(`\s(ref|sheet)="([^"]*)"`)
+
+// pivotDefinitionRegexp matches the start tag of the pivot cache definition.
+var pivotDefinitionRegexp = regexp.MustCompile(`<(?:\w+:)?pivotCacheDefinition\b[^>]*?(/?>)`)
+
+// pivotRefreshOnLoadRegexp matches the refresh on load attribute of the pivot
+// cache definition.
+var pivotRefreshOnLoadRegexp = regexp.MustCompile(`\srefreshOnLoad="[^"]*"`)
+
+// adjustPivotSource provides a function to update the source ranges on the
+// worksheet of the pivot cache definitions when inserting or deleting rows or
+// columns, so that the source range of the pivot table is grown by the rows
+// or columns inserted into it. The source ranges given by a defined name or
+// wholly deleted are left unchanged. The pivot caches whose source ranges are
+// changed are marked to be refreshed on load if the RefreshPivotCaches option
+// is set.
+func (f *File) adjustPivotSource(sheet string, dir AdjustDirection, m adjustMapping) {
+	for path, content := range f.XLSX {
+		if !strings.HasPrefix(path, "xl/pivotCache/pivotCacheDefinition") {
+			continue
+		}
+		var changed bool
+		content = pivotSourceRegexp.ReplaceAllFunc(content, func(element []byte) []byte {
+			attrs := map[string]string{}
+			for _, match := range pivotSourceAttrRegexp.FindAllSubmatch(element, -1) {
+				attrs[string(match[1])] = html.UnescapeString(string(match[2]))
+			}
+			if attrs["ref"] == "" || !strings.EqualFold(attrs["sheet"], trimSheetName(sheet)) {
+				return element
+			}
+			ref, ok := adjustCellReference(attrs["ref"], dir, m)
+			if !ok || ref == attrs["ref"] {
+				return element
+			}
+			changed = true
+			return pivotSourceAttrRegexp.ReplaceAllFunc(element, func(attr []byte) []byte {
+				if match := pivotSourceAttrRegexp.FindSubmatch(attr); string(match[1]) == "ref" {
+					return []byte(string(attr[0]) + `ref="` + ref + `"`)
+				}
+				return attr
+			})
+		})
+		if changed && f.adjustOptions.refreshPivotCaches {
+			content = setPivotRefreshOnLoad(content)
+		}
+		f.XLSX[path] = content
+	}
+}
+
+// setPivotRefreshOnLoad provides a function to set the refresh on load
+// attribute of the pivot cache definition, so that the spreadsheet
+// application rebuilds the pivot cache records when the workbook is opened.
+func setPivotRefreshOnLoad(content []byte) []byte {
+	loc := pivotDefinitionRegexp.FindSubmatchIndex(content)
+	if loc == nil {
+		return content
+	}
+	start := pivotRefreshOnLoadRegexp.ReplaceAllLiteral(content[loc[0]:loc[2]], nil)
+	adjusted := make([]byte, 0, len(content)+len(` refreshOnLoad="1"`))
+	adjusted = append(adjusted, content[:loc[0]]...)
+	adjusted = append(adjusted, start...)
+	adjusted = append(adjusted, ` refreshOnLoad="1"`...)
+	return append(adjusted, content[loc[2]:]...)
+}
+
+// adjustDrawingAnchors provides a function to update the anchors of the
+// drawing, and remove the anchors which starting cells are deleted.
+func adjustDrawingAnchors(anchors []*xdrCellAnchor, dir AdjustDirection, m adjustMapping) []*xdrCellAnchor {
+	adjusted := anchors[:0]
+	for _, anchor := range anchors {
+		if anchor.EditAs == "absolute" {
+			adjusted = append(adjusted, anchor)
+			continue
+		}
+		if anchor.From != nil {
+			col, colOff, row, rowOff, ok := adjustDrawingAnchor(anchor.From.Col, anchor.From.ColOff, anchor.From.Row, anchor.From.RowOff, dir, m)
+			if !ok {
+				continue
+			}
+			delta := col - anchor.From.Col + row - anchor.From.Row
+			anchor.From.Col, anchor.From.ColOff, anchor.From.Row, anchor.From.RowOff = col, colOff, row, rowOff
+			if anchor.To != nil {
+				if anchor.EditAs == "oneCell" {
+					anchor.To.Col, anchor.To.Row = moveDrawingAnchor(anchor.To.Col, anchor.To.Row, dir, delta)
+				} else {
+					anchor.To.Col, anchor.To.ColOff, anchor.To.Row, anchor.To.RowOff, _ = adjustDrawingAnchor(anchor.To.Col, anchor.To.ColOff, anchor.To.Row, anchor.To.RowOff, dir, m)
+				}
+			}
+		} else if anchor.GraphicFrame != "" && !adjustRawDrawingAnchor(anchor, dir, m) {
+			continue
+		}
+		adjusted = append(adjusted, anchor)
+	}
+	return adjusted
+}
+
+// adjustRawDrawingAnchor provides a function to update the starting and
+// ending anchors in the raw content of the anchor of the existing drawing. It
+// reports whether the starting cell of the anchor is left after deletion.
+func adjustRawDrawingAnchor(anchor *xdrCellAnchor, dir AdjustDirection, m adjustMapping) bool {
+	ok, delta := true, 0
+	anchor.GraphicFrame = drawingAnchorRegexp.ReplaceAllStringFunc(anchor.GraphicFrame, func(match string) string {
+		values := drawingAnchorRegexp.FindStringSubmatch(match)
+		prefix, name := values[1], values[2]
+		var cols, rows [2]int
+		for i, value := range values[3:7] {
+			n, _ := strconv.Atoi(value)
+			if i < 2 {
+				cols[i] = n
+			} else {
+				rows[i-2] = n
+			}
+		}
+		col, colOff, row, rowOff, left := adjustDrawingAnchor(cols[0], cols[1], rows[0], rows[1], dir, m)
+		if name == "from" {
+			ok, delta = left, col-cols[0]+row-rows[0]
+		} else if anchor.EditAs == "oneCell" {
+			col, row = moveDrawingAnchor(cols[0], rows[0], dir, delta)
+			colOff, rowOff = cols[1], rows[1]
+		}
+		return fmt.Sprintf("<%[1]s%[2]s><%[1]scol>%[3]d</%[1]scol><%[1]scolOff>%[4]d</%[1]scolOff><%[1]srow>%[5]d</%[1]srow><%[1]srowOff>%[6]d</%[1]srowOff></%[1]s%[2]s>",
+			prefix, name, col, colOff, row, rowOff)
+	})
+	return ok
+}
+
+// adjustDrawingAnchor provides a function to update the zero-based column and
+// row index and the offsets in the cell of the anchor. The anchor in the
+// deleted cells will be moved to the beginning of the cell after the deleted
+// cells, and the last return value reports whether the cell of the anchor is
+// left after deletion.
+func adjustDrawingAnchor(col, colOff, row, rowOff int, dir AdjustDirection, m adjustMapping) (int, int, int, int, bool) {
+	value, valueOff := row, rowOff
+	if dir == columns {
+		value, valueOff = col, colOff
+	}
+	ok := !m.isDeleted(value + 1)
+	if value = m.adjust(value+1) - 1; !ok {
+		valueOff = 0
+	}
+	if dir == columns {
+		return value, valueOff, row, rowOff, ok
+	}
+	return col, colOff, value, valueOff, ok
+}
+
+// moveDrawingAnchor provides a function to move the zero-based column or row
+// index of the anchor by given offset.
+func moveDrawingAnchor(col, row int, dir AdjustDirection, delta int) (int, int) {
+	if dir == columns {
+		return col + delta, row
+	}
+	return col, row + delta
 }
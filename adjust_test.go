@@ -1,11 +1,51 @@
 package excelize
 
 import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/mohae/deepcopy"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestAdjustMapping(t *testing.T) {
+	m := newRemoveMapping([]int{3, 5, 6})
+	for n, expected := range map[int]int{1: 1, 2: 2, 3: 3, 4: 3, 5: 4, 6: 4, 7: 4, 10: 7} {
+		assert.Equal(t, expected, m.adjust(n), n)
+	}
+	assert.True(t, m.isDeleted(5))
+	assert.False(t, m.isDeleted(4))
+	for _, c := range []struct {
+		first, last, newFirst, newLast int
+		ok                             bool
+	}{
+		{2, 7, 2, 4, true},
+		{4, 5, 3, 3, true},
+		{5, 6, 5, 6, false},
+		{5, 8, 4, 5, true},
+	} {
+		first, last, ok := m.adjustRange(c.first, c.last)
+		assert.Equal(t, []interface{}{c.newFirst, c.newLast, c.ok}, []interface{}{first, last, ok})
+	}
+	// Test the mapping of the consecutive rows is the same as removing them.
+	m1, m2 := newAdjustMapping(3, -2), newRemoveMapping([]int{3, 4})
+	for n := 1; n <= 6; n++ {
+		assert.Equal(t, m2.adjust(n), m1.adjust(n), n)
+		assert.Equal(t, m2.isDeleted(n), m1.isDeleted(n), n)
+	}
+	assert.Equal(t, 5, newAdjustMapping(3, 2).adjust(3))
+	// Test split the range at the deleted rows inside of it.
+	assert.Equal(t, [][2]int{{1, 2}, {3, 3}, {4, 4}}, m.splitRange(1, 7))
+	assert.Equal(t, [][2]int{{2, 2}, {3, 4}}, newAdjustMapping(3, -2).splitRange(2, 6))
+	assert.Equal(t, [][2]int{{2, 8}}, newAdjustMapping(3, 2).splitRange(2, 6))
+	assert.Empty(t, m.splitRange(5, 6))
+}
+
 func TestAdjustMergeCells(t *testing.T) {
 	f := NewFile()
 	// testing adjustAutoFilter with illegal cell coordinates.
@@ -17,7 +57,7 @@ func TestAdjustMergeCells(t *testing.T) {
 				},
 			},
 		},
-	}, rows, 0, 0), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	}, nil, rows, newAdjustMapping(0, 0)), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
 	assert.EqualError(t, f.adjustMergeCells(&xlsxWorksheet{
 		MergeCells: &xlsxMergeCells{
 			Cells: []*xlsxMergeCell{
@@ -26,22 +66,242 @@ func TestAdjustMergeCells(t *testing.T) {
 				},
 			},
 		},
-	}, rows, 0, 0), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	}, nil, rows, newAdjustMapping(0, 0)), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+}
+
+func TestAdjustMergeCellsCollapseAndShift(t *testing.T) {
+	f := NewFile()
+	for _, ref := range [][]string{{"A1", "A2"}, {"C5", "D6"}, {"E2", "F2"}, {"G7", "H8"}} {
+		assert.NoError(t, f.MergeCell("Sheet1", ref[0], ref[1]))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "A8", "A8"))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Test the merged cells between the collapsed and the deleted merged
+	// cells are shifted.
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 2) {
+		assert.Equal(t, "C4:D5", mergeCells[0][0])
+		assert.Equal(t, "G6:H7", mergeCells[1][0])
+	}
+	assert.Equal(t, 2, xlsx.MergeCells.Count)
+
+	// Test the container is removed when the last merged cells collapse.
+	assert.NoError(t, f.RemoveRow("Sheet1", 5))
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	if assert.Len(t, xlsx.MergeCells.Cells, 1) {
+		assert.Equal(t, "F5:G6", xlsx.MergeCells.Cells[0].Ref)
+	}
+	assert.Equal(t, 1, xlsx.MergeCells.Count)
+	assert.NoError(t, f.RemoveCol("Sheet1", "G"))
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	assert.Nil(t, xlsx.MergeCells)
+}
+
+func TestAdjustMergeCellsInsertInside(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C6"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	// Test insert row in the middle of the 5 rows merged cells.
+	assert.NoError(t, f.InsertRow("Sheet1", 4))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 1) {
+		assert.Equal(t, MergeCell{"B2:C7", "B2"}, mergeCells[0])
+	}
+	// Test the value is only kept in the top-left cell.
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	var values []string
+	for _, row := range rows {
+		for _, value := range row {
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	assert.Equal(t, []string{"B2"}, values)
+
+	// Test insert column in the middle of the merged cells, and insert rows
+	// at the first and after the last row of the merged cells.
+	assert.NoError(t, f.InsertCol("Sheet1", "C"))
+	assert.NoError(t, f.InsertRow("Sheet1", 8))
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 1) {
+		assert.Equal(t, MergeCell{"B3:D8", "B2"}, mergeCells[0])
+	}
+}
+
+func TestMergeAdjacentOnDelete(t *testing.T) {
+	for _, c := range []struct {
+		option     bool
+		dir        AdjustDirection
+		mergeCells []string
+		expected   []string
+	}{
+		{true, columns, []string{"A1:B2", "D1:E2"}, []string{"A1:D2"}},
+		{false, columns, []string{"A1:B2", "D1:E2"}, []string{"A1:B2", "C1:D2"}},
+		{true, columns, []string{"A1:B2", "D1:E3"}, []string{"A1:B2", "C1:D3"}},
+		{true, columns, []string{"D1:E2", "A1:C2", "A3:B3"}, []string{"C1:D2", "A1:B2", "A3:B3"}},
+		{true, columns, []string{"D3:E4", "E1:F2", "A1:B2", "A3:B4"}, []string{"D1:E2", "A1:B2", "A3:D4"}},
+		{false, columns, []string{"C1:C2", "B3:C3"}, nil},
+		{true, rows, []string{"A1:B2", "A4:B5"}, []string{"A1:B4"}},
+		{true, rows, []string{"A1:B2", "A4:C5"}, []string{"A1:B2", "A3:C4"}},
+	} {
+		f := NewFile()
+		f.SetAdjustOptions(MergeAdjacentOnDelete(c.option))
+		var option MergeAdjacentOnDelete
+		f.GetAdjustOptions(&option)
+		assert.Equal(t, MergeAdjacentOnDelete(c.option), option)
+		for _, ref := range c.mergeCells {
+			cells := strings.Split(ref, ":")
+			assert.NoError(t, f.MergeCell("Sheet1", cells[0], cells[1]))
+		}
+		assert.NoError(t, f.SetCellValue("Sheet1", "F6", "F6"))
+		if c.dir == columns {
+			assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+		} else {
+			assert.NoError(t, f.RemoveRow("Sheet1", 3))
+		}
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		var refs []string
+		for _, mergeCell := range mergeCells {
+			refs = append(refs, mergeCell.GetStartAxis()+":"+mergeCell.GetEndAxis())
+		}
+		assert.Equal(t, c.expected, refs, c.mergeCells)
+	}
+}
+
+func TestCollapsePolicy(t *testing.T) {
+	for policy, expected := range map[CollapsePolicy][]string{
+		CollapsePolicyRemove:     {"B1:C1"},
+		CollapsePolicyKeepSingle: {"A2:A2", "B1:C1"},
+	} {
+		f := NewFile()
+		f.SetAdjustOptions(policy)
+		var option CollapsePolicy
+		f.GetAdjustOptions(&option)
+		assert.Equal(t, policy, option)
+		assert.NoError(t, f.MergeCell("Sheet1", "A2", "A3"))
+		assert.NoError(t, f.MergeCell("Sheet1", "B1", "C1"))
+		assert.NoError(t, f.SetCellValue("Sheet1", "A4", "A4"))
+		// Test delete a row collapses the 2-row merged cells to one row.
+		assert.NoError(t, f.RemoveRow("Sheet1", 3))
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		var refs []string
+		for _, mergeCell := range mergeCells {
+			refs = append(refs, mergeCell.GetStartAxis()+":"+mergeCell.GetEndAxis())
+		}
+		assert.Equal(t, expected, refs)
+	}
+}
+
+func TestSplitMergesOnInsert(t *testing.T) {
+	for _, c := range []struct {
+		option     bool
+		dir        AdjustDirection
+		mergeCells []string
+		expected   []string
+	}{
+		{false, rows, []string{"A1:B4", "C3:C4", "D1:D2"}, []string{"A1:B5", "C4:C5", "D1:D2"}},
+		{true, rows, []string{"A1:B4", "C3:C4", "D1:D2"}, []string{"A1:B2", "A4:B5", "C4:C5", "D1:D2"}},
+		{true, rows, []string{"A2:A3", "B1:B4"}, []string{"B1:B2", "B4:B5"}},
+		{false, columns, []string{"A1:D2", "C3:D3"}, []string{"A1:E2", "D3:E3"}},
+		{true, columns, []string{"A1:D2", "C3:D3"}, []string{"A1:B2", "D1:E2", "D3:E3"}},
+	} {
+		f := NewFile()
+		f.SetAdjustOptions(SplitMergesOnInsert(c.option))
+		var option SplitMergesOnInsert
+		f.GetAdjustOptions(&option)
+		assert.Equal(t, SplitMergesOnInsert(c.option), option)
+		for _, ref := range c.mergeCells {
+			cells := strings.Split(ref, ":")
+			assert.NoError(t, f.MergeCell("Sheet1", cells[0], cells[1]))
+		}
+		if c.dir == columns {
+			assert.NoError(t, f.InsertCol("Sheet1", "C"))
+		} else {
+			assert.NoError(t, f.InsertRow("Sheet1", 3))
+		}
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		var refs []string
+		for _, mergeCell := range mergeCells {
+			refs = append(refs, mergeCell.GetStartAxis()+":"+mergeCell.GetEndAxis())
+		}
+		assert.Equal(t, c.expected, refs, c.mergeCells)
+	}
 }
 
 func TestAdjustAutoFilter(t *testing.T) {
 	f := NewFile()
 	// testing adjustAutoFilter with illegal cell coordinates.
-	assert.EqualError(t, f.adjustAutoFilter(&xlsxWorksheet{
+	assert.EqualError(t, f.adjustAutoFilter("Sheet1", &xlsxWorksheet{
 		AutoFilter: &xlsxAutoFilter{
 			Ref: "A:B1",
 		},
-	}, rows, 0, 0), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
-	assert.EqualError(t, f.adjustAutoFilter(&xlsxWorksheet{
+	}, rows, newAdjustMapping(0, 0)), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.adjustAutoFilter("Sheet1", &xlsxWorksheet{
 		AutoFilter: &xlsxAutoFilter{
 			Ref: "A1:B",
 		},
-	}, rows, 0, 0), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	}, rows, newAdjustMapping(0, 0)), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+}
+
+func TestAdjustAutoFilterColumn(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{1, 2, 3, 4, 5}))
+	}
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "E5", ""))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Filter the column C by the cell color.
+	xlsx.AutoFilter.FilterColumn = &xlsxFilterColumn{ColID: 2, ColorFilter: &xlsxColorFilter{CellColor: true, DxfID: 1}}
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustAutoFilterColumn.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAdjustAutoFilterColumn.xlsx"))
+	assert.NoError(t, err)
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Test insert a column before the auto filter.
+	assert.NoError(t, f.InsertCol("Sheet1", "A"))
+	assert.Equal(t, "B1:F5", xlsx.AutoFilter.Ref)
+	assert.Equal(t, &xlsxFilterColumn{ColID: 2, ColorFilter: &xlsxColorFilter{CellColor: true, DxfID: 1}}, xlsx.AutoFilter.FilterColumn)
+	// Test insert and remove the columns before the filter column.
+	assert.NoError(t, f.InsertCol("Sheet1", "C"))
+	assert.Equal(t, "B1:G5", xlsx.AutoFilter.Ref)
+	assert.Equal(t, 3, xlsx.AutoFilter.FilterColumn.ColID)
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.Equal(t, "B1:F5", xlsx.AutoFilter.Ref)
+	assert.Equal(t, 2, xlsx.AutoFilter.FilterColumn.ColID)
+	// Test insert a column after the filter column.
+	assert.NoError(t, f.InsertCol("Sheet1", "E"))
+	assert.Equal(t, 2, xlsx.AutoFilter.FilterColumn.ColID)
+	assert.NotNil(t, xlsx.AutoFilter.FilterColumn.ColorFilter)
+	visible, err := f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	// Test remove the filter column.
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	assert.Equal(t, "B1:F5", xlsx.AutoFilter.Ref)
+	assert.Nil(t, xlsx.AutoFilter.FilterColumn)
+	visible, err = f.GetRowVisible("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustAutoFilterColumn.xlsx")))
+
+	// Test renumber the filter column of the table.
+	autoFilter := &xlsxAutoFilter{FilterColumn: &xlsxFilterColumn{ColID: 1, IconFilter: &xlsxIconFilter{IconID: 2, IconSet: "3Arrows"}}}
+	assert.True(t, adjustFilterColumn(autoFilter, 2, 2, newAdjustMapping(2, 1)))
+	assert.Equal(t, &xlsxFilterColumn{ColID: 2, IconFilter: &xlsxIconFilter{IconID: 2, IconSet: "3Arrows"}}, autoFilter.FilterColumn)
 }
 
 func TestAdjustHelper(t *testing.T) {
@@ -67,3 +327,2148 @@ func TestAdjustHelper(t *testing.T) {
 	// testing adjustHelper on not exists worksheet.
 	assert.EqualError(t, f.adjustHelper("SheetN", rows, 0, 0), "sheet SheetN is not exist")
 }
+
+func TestAdjustColDimensionsCollision(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A", "B", "C"}))
+	// Force the cells shifted onto the occupied references by deleting the
+	// columns without removing the cells of them.
+	assert.NoError(t, f.adjustHelper("Sheet1", columns, 2, -1))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, xlsx.SheetData.Row[0].C, 2)
+	assert.Equal(t, "A1", xlsx.SheetData.Row[0].C[0].R)
+	assert.Equal(t, "B1", xlsx.SheetData.Row[0].C[1].R)
+	for cell, expected := range map[string]string{"A1": "B", "B1": "C"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+
+	// Test the cells shifted onto the references which are not adjacent.
+	cells := uniqueCells([]xlsxC{{R: "A1", V: "1"}, {R: "C1", V: "2"}, {R: "A1", V: "3"}, {R: "B1", V: "4"}, {R: "D1", V: "5"}})
+	assert.Equal(t, []xlsxC{{R: "A1", V: "3"}, {R: "B1", V: "4"}, {R: "C1", V: "2"}, {R: "D1", V: "5"}}, cells)
+}
+
+func TestAdjustDimensions(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A1", "B1", "C1"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A2", "B2", "C2"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "SUM(A1:A2)"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B2", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	cellValues := func(cells map[string]string) {
+		for cell, expected := range cells {
+			value, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, value, cell)
+		}
+	}
+
+	// Test shift the rows down and up.
+	assert.NoError(t, f.AdjustRowDimensions("Sheet1", 2, 2))
+	cellValues(map[string]string{"A1": "A1", "A2": "", "A3": "", "A4": "A2", "C4": "C2"})
+	formula, err := f.GetCellFormula("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A1:A2)", formula)
+	assert.NoError(t, f.AdjustRowDimensions("Sheet1", 4, -2))
+	cellValues(map[string]string{"A1": "A1", "A2": "A2", "C2": "C2"})
+
+	// Test shift the columns right and left.
+	assert.NoError(t, f.AdjustColumnDimensions("Sheet1", "B", 1))
+	cellValues(map[string]string{"A1": "A1", "B1": "", "C1": "B1", "D1": "C1", "D2": "C2"})
+	assert.NoError(t, f.AdjustColumnDimensions("Sheet1", "C", -1))
+	cellValues(map[string]string{"A1": "A1", "B1": "B1", "C1": "C1", "C2": "C2"})
+
+	// Test the metadata of the worksheet are left unchanged.
+	formula, err = f.GetCellFormula("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A1:A2)", formula)
+	link, target, err := f.GetCellHyperLink("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/360EntSecGroup-Skylar/excelize", target)
+
+	// Test adjust dimensions with invalid arguments.
+	assert.EqualError(t, f.AdjustRowDimensions("Sheet1", 0, 1), "invalid row number 0")
+	assert.EqualError(t, f.AdjustRowDimensions("SheetN", 1, 1), "sheet SheetN is not exist")
+	assert.EqualError(t, f.AdjustColumnDimensions("Sheet1", "*", 1), `invalid column name "*"`)
+	assert.EqualError(t, f.AdjustColumnDimensions("SheetN", "A", 1), "sheet SheetN is not exist")
+
+	// Test shift the cells above the row 1 or to the left of the column A.
+	assert.EqualError(t, f.AdjustRowDimensions("Sheet1", 2, -2), "invalid row number 0")
+	assert.EqualError(t, f.AdjustColumnDimensions("Sheet1", "B", -3), "incorrect column number -1")
+	cellValues(map[string]string{"A1": "A1", "B1": "B1", "C1": "C1", "C2": "C2"})
+
+	// Test shift the cells beyond the last row or column of the worksheet.
+	assert.EqualError(t, f.AdjustRowDimensions("Sheet1", 2, TotalRows-2), "invalid row number 1048577")
+	assert.EqualError(t, f.AdjustColumnDimensions("Sheet1", "B", TotalColumns-2), "incorrect column number 16385")
+	cellValues(map[string]string{"A1": "A1", "B1": "B1", "C1": "C1", "C2": "C2"})
+}
+
+func TestAdjustConditionalFormats(t *testing.T) {
+	f := NewFile()
+	xlsx := &xlsxWorksheet{
+		ConditionalFormatting: []*xlsxConditionalFormatting{
+			{SQRef: "A1:B1"},
+			{SQRef: "A2 B2:C3"},
+		},
+	}
+	assert.NoError(t, f.adjustConditionalFormats("Sheet1", xlsx, nil, rows, newAdjustMapping(1, -1)))
+	assert.Len(t, xlsx.ConditionalFormatting, 1)
+	assert.Equal(t, "A1 B1:C2", xlsx.ConditionalFormatting[0].SQRef)
+	assert.NoError(t, f.adjustConditionalFormats("Sheet1", xlsx, nil, columns, newAdjustMapping(1, -1)))
+	assert.Equal(t, "A1:B2", xlsx.ConditionalFormatting[0].SQRef)
+	assert.NoError(t, f.adjustConditionalFormats("Sheet1", xlsx, nil, columns, newAdjustMapping(1, -2)))
+	assert.Nil(t, xlsx.ConditionalFormatting)
+	// testing adjustConditionalFormats with illegal cell coordinates.
+	assert.EqualError(t, f.adjustConditionalFormats("Sheet1", &xlsxWorksheet{
+		ConditionalFormatting: []*xlsxConditionalFormatting{{SQRef: "A1:B"}},
+	}, nil, rows, newAdjustMapping(1, 1)), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+}
+
+func TestAdjustConditionalFormatPriorities(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", 1))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.ConditionalFormatting = []*xlsxConditionalFormatting{
+		{SQRef: "A1:A5", CfRule: []*xlsxCfRule{{Type: "expression", Priority: 3, Formula: []string{"A1>1"}}}},
+		{SQRef: "B3", CfRule: []*xlsxCfRule{{Type: "expression", Priority: 2, Formula: []string{"B3>1"}}}},
+		{SQRef: "C1:C5", CfRule: []*xlsxCfRule{{Type: "expression", Priority: 1, Formula: []string{"C1>1"}}}},
+	}
+	// Test the priorities are kept without rules removed.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.Equal(t, 3, xlsx.ConditionalFormatting[0].CfRule[0].Priority)
+	// Test the priorities are renumbered after the middle rule is removed.
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	if assert.Len(t, xlsx.ConditionalFormatting, 2) {
+		assert.Equal(t, "A1:A4", xlsx.ConditionalFormatting[0].SQRef)
+		assert.Equal(t, 2, xlsx.ConditionalFormatting[0].CfRule[0].Priority)
+		assert.Equal(t, "C1:C4", xlsx.ConditionalFormatting[1].SQRef)
+		assert.Equal(t, 1, xlsx.ConditionalFormatting[1].CfRule[0].Priority)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustConditionalFormatPriorities.xlsx")))
+}
+
+func TestAdjustProtectedCells(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(row), row))
+	}
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.SheetProtection = &xlsxSheetProtection{Sheet: true}
+	xlsx.ProtectedRanges = &xlsxProtectedRanges{ProtectedRange: []*xlsxProtectedRange{
+		{Name: "Range1", Sqref: "A2:B4"},
+		{Name: "Range2", Sqref: "D5 E1"},
+	}}
+	// Test the edit-allowed range grows when inserting a row inside it.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.Equal(t, "A2:B5", xlsx.ProtectedRanges.ProtectedRange[0].Sqref)
+	assert.Equal(t, "D6 E1", xlsx.ProtectedRanges.ProtectedRange[1].Sqref)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustProtectedCells.xlsx")))
+
+	// Test the protected range will be removed if all of its ranges are deleted.
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.Len(t, xlsx.ProtectedRanges.ProtectedRange, 1)
+	assert.Equal(t, "Range2", xlsx.ProtectedRanges.ProtectedRange[0].Name)
+	assert.Equal(t, "B6 C1", xlsx.ProtectedRanges.ProtectedRange[0].Sqref)
+	assert.NoError(t, f.adjustProtectedCells(xlsx, nil, columns, newAdjustMapping(2, -2)))
+	assert.Nil(t, xlsx.ProtectedRanges)
+	assert.NoError(t, f.adjustProtectedCells(xlsx, nil, columns, newAdjustMapping(2, -2)))
+
+	// Test adjust protected ranges with illegal cell coordinates.
+	assert.EqualError(t, f.adjustProtectedCells(&xlsxWorksheet{
+		ProtectedRanges: &xlsxProtectedRanges{ProtectedRange: []*xlsxProtectedRange{{Sqref: "A1:B"}}},
+	}, nil, rows, newAdjustMapping(1, 1)), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+}
+
+func TestAdjustScenarios(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 6; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{row, row}))
+	}
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.Scenarios = &xlsxScenarios{Current: 1, Show: 1, Sqref: "A6", Scenario: []*xlsxScenario{
+		{Name: "Low", Count: 1, InputCells: []*xlsxInputCells{{R: "A2", Val: "1"}}},
+		{Name: "High", Count: 2, InputCells: []*xlsxInputCells{{R: "B5", Val: "100"}, {R: "B3", Val: "200"}}},
+	}}
+	// Test the input cells and the result cells follow the inserted row.
+	assert.NoError(t, f.InsertRow("Sheet1", 4))
+	assert.Equal(t, "A7", xlsx.Scenarios.Sqref)
+	assert.Equal(t, "A2", xlsx.Scenarios.Scenario[0].InputCells[0].R)
+	assert.Equal(t, "B6", xlsx.Scenarios.Scenario[1].InputCells[0].R)
+	assert.Equal(t, "B3", xlsx.Scenarios.Scenario[1].InputCells[1].R)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustScenarios.xlsx")))
+
+	// Test the scenario is removed when its input cell is deleted, and the
+	// current and the shown scenario follow the scenario left.
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	if assert.Len(t, xlsx.Scenarios.Scenario, 1) {
+		assert.Equal(t, "High", xlsx.Scenarios.Scenario[0].Name)
+		assert.Equal(t, "B5", xlsx.Scenarios.Scenario[0].InputCells[0].R)
+		assert.Equal(t, "B2", xlsx.Scenarios.Scenario[0].InputCells[1].R)
+	}
+	assert.Equal(t, 0, xlsx.Scenarios.Current)
+	assert.Equal(t, 0, xlsx.Scenarios.Show)
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.Nil(t, xlsx.Scenarios)
+
+	// Test adjust the scenarios with illegal cell coordinates.
+	assert.EqualError(t, adjustScenarios(&xlsxWorksheet{Scenarios: &xlsxScenarios{Sqref: "A"}}, nil, rows, newAdjustMapping(1, 1)), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, adjustScenarios(&xlsxWorksheet{Scenarios: &xlsxScenarios{Scenario: []*xlsxScenario{
+		{InputCells: []*xlsxInputCells{{R: "A"}}},
+	}}}, nil, rows, newAdjustMapping(1, 1)), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestAdjustConditionalFormatsInsertCol(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "D10", 1))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:D10", `[{"type":"formula","criteria":"$D1>$A1","format":0}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10 C1:D10", `[{"type":"cell","criteria":">","format":0,"value":"1"}]`))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// Test the ranges spanning the columns grow in the same way as the rows.
+	assert.NoError(t, f.InsertCol("Sheet1", "B"))
+	assert.Equal(t, "A1:E10", xlsx.ConditionalFormatting[0].SQRef)
+	assert.Equal(t, []string{"$E1>$A1"}, xlsx.ConditionalFormatting[0].CfRule[0].Formula)
+	assert.Equal(t, "A1:A10 D1:E10", xlsx.ConditionalFormatting[1].SQRef)
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	assert.Equal(t, "A1:E11", xlsx.ConditionalFormatting[0].SQRef)
+
+	// Test the ranges shrink when deleting the columns.
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.Equal(t, "A1:D11", xlsx.ConditionalFormatting[0].SQRef)
+	assert.Equal(t, "A1:A11 C1:D11", xlsx.ConditionalFormatting[1].SQRef)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustConditionalFormatsInsertCol.xlsx")))
+}
+
+func TestAdjustConditionalFormatsDataBar(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(row), row))
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"data_bar", "criteria":"=", "min_type":"num","max_type":"percent","bar_color":"#638EC6"}]`))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	if !assert.Len(t, xlsx.ConditionalFormatting, 1) || !assert.Len(t, xlsx.ConditionalFormatting[0].CfRule, 1) {
+		t.FailNow()
+	}
+	dataBar := xlsx.ConditionalFormatting[0].CfRule[0].DataBar
+	dataBar.MinLength, dataBar.MaxLength, dataBar.ShowValue = 10, 90, true
+	dataBar.Cfvo[0].Val, dataBar.Cfvo[1].Val = "2", "80"
+	expected := deepcopy.Copy(*xlsx.ConditionalFormatting[0].CfRule[0]).(xlsxCfRule)
+
+	// Test the settings of the data bar are intact after inserting a row.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.Equal(t, "A2:A11", xlsx.ConditionalFormatting[0].SQRef)
+	assert.Equal(t, expected, *xlsx.ConditionalFormatting[0].CfRule[0])
+	assert.NoError(t, f.RemoveRow("Sheet1", 5))
+	assert.Equal(t, "A2:A10", xlsx.ConditionalFormatting[0].SQRef)
+	assert.Equal(t, expected, *xlsx.ConditionalFormatting[0].CfRule[0])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustConditionalFormatsDataBar.xlsx")))
+}
+
+func TestAdjustConditionalFormatsExt(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(row), row-5))
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"data_bar", "criteria":"=", "min_type":"min","max_type":"max","bar_color":"#638EC6"}]`))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	if !assert.Len(t, xlsx.ConditionalFormatting, 1) || !assert.Len(t, xlsx.ConditionalFormatting[0].CfRule, 1) {
+		t.FailNow()
+	}
+	// Link the data bar to the extended settings with the negative fill and
+	// the axis color.
+	id := "{DA7ABA51-AAAA-BBBB-0001-000000000001}"
+	xlsx.ConditionalFormatting[0].CfRule[0].ExtLst = &xlsxExtLst{Ext: `<ext uri="{B025F937-C7B1-47D3-B67F-A62EFF666E3E}" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:id>` + id + `</x14:id></ext>`}
+	extRule := `<x14:cfRule type="dataBar" id="` + id + `"><x14:dataBar minLength="0" maxLength="100" negativeBarColorSameAsPositive="0" axisPosition="middle"><x14:cfvo type="autoMin"></x14:cfvo><x14:cfvo type="autoMax"></x14:cfvo><x14:negativeFillColor rgb="FFFF0000"></x14:negativeFillColor><x14:axisColor rgb="FF000000"></x14:axisColor></x14:dataBar></x14:cfRule>`
+	ext := `<ext uri="{78C0D931-6437-407d-A8EE-F0AAD7539E65}" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="http://schemas.microsoft.com/office/excel/2006/main">` + extRule + `<xm:sqref>%s</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`
+	xlsx.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(ext, "A1:A10")}
+
+	// Test the extended data bar follows the range after inserting a row.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.Equal(t, "A2:A11", xlsx.ConditionalFormatting[0].SQRef)
+	assert.Equal(t, fmt.Sprintf(ext, "A2:A11"), xlsx.ExtLst.Ext)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustConditionalFormatsExt.xlsx")))
+
+	// Test the extended data bar is removed with the rule.
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	}
+	assert.Nil(t, xlsx.ConditionalFormatting)
+	assert.Nil(t, xlsx.ExtLst)
+
+	// Test the extended rule of the removed rule is removed while the other
+	// extensions of the worksheet are kept.
+	other := `<ext uri="{05C60535-1F16-4fd2-B633-F4F36F0B64E0}"></ext>`
+	xlsx.ConditionalFormatting = []*xlsxConditionalFormatting{{SQRef: "A1:A2", CfRule: []*xlsxCfRule{{Type: "dataBar", ExtLst: &xlsxExtLst{Ext: `<ext><x14:id>` + id + `</x14:id></ext>`}}}}}
+	xlsx.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(ext, "B1:B5") + other}
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{2}))
+	assert.NoError(t, f.RemoveRowsByIndex("Sheet1", []int{1, 2}))
+	assert.Nil(t, xlsx.ConditionalFormatting)
+	assert.Equal(t, other, xlsx.ExtLst.Ext)
+
+	// Test adjust the extended conditional formatting with invalid range.
+	xlsx.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(ext, "A")}
+	assert.EqualError(t, f.InsertRow("Sheet1", 1), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestAdjustSheetAndTableAutoFilters(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 6; row++ {
+		cell, _ := CoordinatesToCellName(1, row)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &[]interface{}{row, row, nil, nil, row, row}))
+	}
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "B6", `{"column":"B","expression":"x == 1"}`))
+	assert.NoError(t, f.AddTable("Sheet1", "E1", "F6", `{"table_name":"table"}`))
+	// Add the filter criteria on the second column of the table.
+	var table xlsxTable
+	assert.NoError(t, xml.Unmarshal(f.XLSX["xl/tables/table1.xml"], &table))
+	table.AutoFilter.FilterColumn = &xlsxFilterColumn{ColID: 1, Filters: &xlsxFilters{Filter: []*xlsxFilter{{Val: "4"}}}}
+	content, err := xml.Marshal(table)
+	assert.NoError(t, err)
+	f.XLSX["xl/tables/table1.xml"] = content
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 5, false))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	getTable := func() *xlsxTable {
+		var t xlsxTable
+		_ = xml.Unmarshal(f.XLSX["xl/tables/table1.xml"], &t)
+		return &t
+	}
+
+	// Test delete a column between the auto filters.
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	assert.Equal(t, "A1:B6", xlsx.AutoFilter.Ref)
+	assert.Equal(t, 1, xlsx.AutoFilter.FilterColumn.ColID)
+	assert.Equal(t, "D1:E6", getTable().AutoFilter.Ref)
+	assert.Equal(t, 1, getTable().AutoFilter.FilterColumn.ColID)
+
+	// Test delete the filtered column of the worksheet, the rows in the
+	// range of the filtered table are kept hidden.
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.Equal(t, "A1:A6", xlsx.AutoFilter.Ref)
+	assert.Nil(t, xlsx.AutoFilter.FilterColumn)
+	assert.Equal(t, "C1:D6", getTable().AutoFilter.Ref)
+	assert.Equal(t, 1, getTable().AutoFilter.FilterColumn.ColID)
+	for _, row := range []int{3, 5} {
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.False(t, visible, row)
+	}
+
+	// Test delete the filtered column of the table, the auto filter of the
+	// worksheet is left unchanged.
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	assert.Equal(t, "A1:A6", xlsx.AutoFilter.Ref)
+	assert.Equal(t, "C1:C6", getTable().AutoFilter.Ref)
+	assert.Nil(t, getTable().AutoFilter.FilterColumn)
+	for _, row := range []int{3, 5} {
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.True(t, visible, row)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustSheetAndTableAutoFilters.xlsx")))
+}
+
+func TestAdjustFilteredBlockDeleted(t *testing.T) {
+	for _, c := range []struct {
+		removed []int
+		hidden  int
+	}{
+		// Delete the auto filter.
+		{removed: []int{2, 3, 4, 5}, hidden: 4},
+		// Delete the auto filter with the row above it.
+		{removed: []int{1, 2, 3, 4, 5}, hidden: 3},
+		// Delete the auto filter with the row below it.
+		{removed: []int{2, 3, 4, 5, 6}, hidden: 3},
+		// Delete the header and the first data rows of the auto filter.
+		{removed: []int{2, 3, 4}, hidden: 5},
+	} {
+		f := NewFile()
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Title"}))
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Name", "Link"}))
+		for row := 3; row <= 8; row++ {
+			cell, _ := CoordinatesToCellName(1, row)
+			assert.NoError(t, f.SetSheetRow("Sheet1", cell, &[]interface{}{row, row}))
+		}
+		assert.NoError(t, f.AutoFilter("Sheet1", "A2", "B5", `{"column":"B","expression":"x == 3"}`))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "B4", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "B5", "Sheet1!A1", "Location"))
+		// Hide the filtered row 4 and the row 8 below the auto filter.
+		assert.NoError(t, f.SetRowVisible("Sheet1", 4, false))
+		assert.NoError(t, f.SetRowVisible("Sheet1", 8, false))
+
+		// Test the auto filter, the hyperlinks and their relationships are
+		// removed, and the rows out of the auto filter are kept hidden.
+		assert.NoError(t, f.RemoveRowsByIndex("Sheet1", c.removed))
+		xlsx, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		assert.Nil(t, xlsx.AutoFilter, c.removed)
+		if len(c.removed) == 3 {
+			if assert.NotNil(t, xlsx.Hyperlinks) && assert.Len(t, xlsx.Hyperlinks.Hyperlink, 1) {
+				assert.Equal(t, "B2", xlsx.Hyperlinks.Hyperlink[0].Ref)
+				assert.Equal(t, "Sheet1!A1", xlsx.Hyperlinks.Hyperlink[0].Location)
+			}
+		} else {
+			assert.Nil(t, xlsx.Hyperlinks, c.removed)
+		}
+		rels := f.workSheetRelsReader("xl/worksheets/_rels/sheet1.xml.rels")
+		if rels != nil {
+			for _, rel := range rels.Relationships {
+				assert.NotEqual(t, SourceRelationshipHyperLink, rel.Type, c.removed)
+			}
+		}
+		for row := 1; row <= len(xlsx.SheetData.Row); row++ {
+			visible, err := f.GetRowVisible("Sheet1", row)
+			assert.NoError(t, err)
+			assert.Equal(t, row != c.hidden, visible, c.removed, row)
+		}
+		assert.Empty(t, f.Validate(), c.removed)
+	}
+}
+
+func TestAdjustSortState(t *testing.T) {
+	f := NewFile()
+	xlsx := &xlsxWorksheet{
+		AutoFilter: &xlsxAutoFilter{
+			Ref: "A1:C10",
+			SortState: &xlsxSortState{
+				Ref: "A2:C10",
+				SortCondition: []*xlsxSortCondition{
+					{Ref: "B2:B10"},
+					{Ref: "C2:C10", Descending: true},
+				},
+			},
+		},
+	}
+	// delete the first sorted column.
+	assert.NoError(t, f.adjustAutoFilter("Sheet1", xlsx, columns, newAdjustMapping(2, -1)))
+	assert.Equal(t, "A1:B10", xlsx.AutoFilter.Ref)
+	assert.Equal(t, &xlsxSortState{
+		Ref:           "A2:B10",
+		SortCondition: []*xlsxSortCondition{{Ref: "B2:B10", Descending: true}},
+	}, xlsx.AutoFilter.SortState)
+	// insert a row in the middle of the sorted range.
+	assert.NoError(t, f.adjustAutoFilter("Sheet1", xlsx, rows, newAdjustMapping(5, 1)))
+	assert.Equal(t, "A1:B11", xlsx.AutoFilter.Ref)
+	assert.Equal(t, "A2:B11", xlsx.AutoFilter.SortState.Ref)
+	assert.Equal(t, "B2:B11", xlsx.AutoFilter.SortState.SortCondition[0].Ref)
+	// delete the last sorted column.
+	assert.NoError(t, f.adjustAutoFilter("Sheet1", xlsx, columns, newAdjustMapping(2, -1)))
+	assert.Equal(t, "A1:A11", xlsx.AutoFilter.Ref)
+	assert.Nil(t, xlsx.AutoFilter.SortState)
+
+	// testing adjustSortState with illegal cell coordinates.
+	assert.EqualError(t, f.adjustSortState(&xlsxAutoFilter{
+		SortState: &xlsxSortState{Ref: "A1:B"},
+	}, rows, newAdjustMapping(1, 1)), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	assert.EqualError(t, f.adjustSortState(&xlsxAutoFilter{
+		SortState: &xlsxSortState{Ref: "A1:B2", SortCondition: []*xlsxSortCondition{{Ref: "A1:B"}}},
+	}, rows, newAdjustMapping(1, 1)), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	// the sort state range collapses.
+	autoFilter := &xlsxAutoFilter{SortState: &xlsxSortState{Ref: "A2:A2"}}
+	assert.NoError(t, f.adjustSortState(autoFilter, rows, newAdjustMapping(2, -1)))
+	assert.Nil(t, autoFilter.SortState)
+}
+
+func TestAdjustPanes(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "D10", 1))
+	assert.NoError(t, f.SetPanes("Sheet1", `{"freeze":true,"split":false,"x_split":0,"y_split":3,"top_left_cell":"A4","active_pane":"bottomLeft","panes":[{"sqref":"A5","active_cell":"A5","pane":"bottomLeft"}]}`))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	view := &xlsx.SheetViews.SheetView[len(xlsx.SheetViews.SheetView)-1]
+
+	// delete the exact split row.
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	assert.Equal(t, &xlsxPane{ActivePane: "bottomLeft", State: "frozen", TopLeftCell: "A3", YSplit: 2}, view.Pane)
+	// insert row in the frozen rows.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.Equal(t, &xlsxPane{ActivePane: "bottomLeft", State: "frozen", TopLeftCell: "A4", YSplit: 3}, view.Pane)
+	// insert row below the frozen rows.
+	assert.NoError(t, f.InsertRow("Sheet1", 4))
+	assert.Equal(t, &xlsxPane{ActivePane: "bottomLeft", State: "frozen", TopLeftCell: "A5", YSplit: 3}, view.Pane)
+	// delete row of the top left cell.
+	assert.NoError(t, f.RemoveRow("Sheet1", 5))
+	assert.Equal(t, &xlsxPane{ActivePane: "bottomLeft", State: "frozen", TopLeftCell: "A5", YSplit: 3}, view.Pane)
+	// delete all of the frozen rows, the pane is removed.
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	}
+	assert.Nil(t, view.Pane)
+	assert.Equal(t, []*xlsxSelection{{ActiveCell: "A5", SQRef: "A5"}}, view.Selection)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustPanes.xlsx")))
+
+	// delete the frozen row of the panes with both vertical and horizontal
+	// splits.
+	assert.NoError(t, f.SetPanes("Sheet1", `{"freeze":true,"split":false,"x_split":1,"y_split":1,"top_left_cell":"B2","active_pane":"bottomRight","panes":[{"sqref":"B1","active_cell":"B1","pane":"topRight"},{"sqref":"A2","active_cell":"A2","pane":"bottomLeft"},{"sqref":"C3","active_cell":"C3","pane":"bottomRight"}]}`))
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.Equal(t, &xlsxPane{ActivePane: "topRight", State: "frozen", TopLeftCell: "B1", XSplit: 1}, view.Pane)
+	assert.Equal(t, []*xlsxSelection{
+		{ActiveCell: "C3", Pane: "topRight", SQRef: "C3"},
+		{ActiveCell: "A2", Pane: "topLeft", SQRef: "A2"},
+	}, view.Selection)
+	// delete the frozen column.
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.Nil(t, view.Pane)
+	assert.Equal(t, []*xlsxSelection{{ActiveCell: "C3", SQRef: "C3"}}, view.Selection)
+
+	// split panes are not adjusted.
+	assert.NoError(t, f.SetPanes("Sheet1", `{"freeze":false,"split":true,"x_split":3270,"y_split":1800,"top_left_cell":"N57","active_pane":"bottomLeft"}`))
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.Equal(t, &xlsxPane{ActivePane: "bottomLeft", TopLeftCell: "N57", XSplit: 3270, YSplit: 1800}, view.Pane)
+}
+
+func TestAdjustPanesTopLeftCell(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "E10", 1))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	view := &xlsx.SheetViews.SheetView[len(xlsx.SheetViews.SheetView)-1]
+	view.Pane = &xlsxPane{State: "frozen", TopLeftCell: "B3"}
+
+	// Test the top left cell of the frozen panes without splits is shifted.
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	assert.Equal(t, &xlsxPane{State: "frozen", TopLeftCell: "B4"}, view.Pane)
+	assert.NoError(t, f.InsertCol("Sheet1", "A"))
+	assert.Equal(t, &xlsxPane{State: "frozen", TopLeftCell: "C4"}, view.Pane)
+	assert.NoError(t, f.InsertRow("Sheet1", 5))
+	assert.Equal(t, &xlsxPane{State: "frozen", TopLeftCell: "C4"}, view.Pane)
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.Equal(t, &xlsxPane{State: "frozen", TopLeftCell: "C3"}, view.Pane)
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	assert.Equal(t, &xlsxPane{State: "frozen", TopLeftCell: "C3"}, view.Pane)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustPanesTopLeftCell.xlsx")))
+}
+
+func TestAdjustCellImages(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "image"))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// an image in cell is bound by the value metadata index of the cell.
+	vm := uint(1)
+	prepareSheetXML(xlsx, 2, 2)
+	xlsx.SheetData.Row[1].C[1] = xlsxC{R: "B2", T: "e", V: "#VALUE!", Vm: &vm}
+
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	assert.Empty(t, xlsx.SheetData.Row[1].C)
+	assert.Equal(t, xlsxC{R: "B3", T: "e", V: "#VALUE!", Vm: &vm}, xlsx.SheetData.Row[2].C[1])
+	assert.NoError(t, f.InsertCol("Sheet1", "A"))
+	assert.Equal(t, xlsxC{R: "C3", T: "e", V: "#VALUE!", Vm: &vm}, xlsx.SheetData.Row[2].C[2])
+
+	f.workSheetWriter()
+	assert.Contains(t, string(f.XLSX["xl/worksheets/sheet1.xml"]), `<c r="C3" t="e" vm="1"><v>#VALUE!</v></c>`)
+	// the binding is dropped with the deleted cell.
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	f.workSheetWriter()
+	assert.NotContains(t, string(f.XLSX["xl/worksheets/sheet1.xml"]), `vm="1"`)
+}
+
+func TestAdjustCols(t *testing.T) {
+	cases := []struct {
+		label    string
+		col      int
+		offset   int
+		cols     []xlsxCol
+		expected []xlsxCol
+	}{{
+		label:    "delete column below min",
+		col:      2,
+		offset:   -1,
+		cols:     []xlsxCol{{Min: 3, Max: 5, Width: 20}},
+		expected: []xlsxCol{{Min: 2, Max: 4, Width: 20}},
+	}, {
+		label:    "delete column inside min and max",
+		col:      4,
+		offset:   -1,
+		cols:     []xlsxCol{{Min: 3, Max: 5, Width: 20}},
+		expected: []xlsxCol{{Min: 3, Max: 4, Width: 20}},
+	}, {
+		label:    "delete column equal to min",
+		col:      3,
+		offset:   -1,
+		cols:     []xlsxCol{{Min: 3, Max: 5, Width: 20}},
+		expected: []xlsxCol{{Min: 3, Max: 4, Width: 20}},
+	}, {
+		label:    "delete column equal to max",
+		col:      5,
+		offset:   -1,
+		cols:     []xlsxCol{{Min: 3, Max: 5, Width: 20}},
+		expected: []xlsxCol{{Min: 3, Max: 4, Width: 20}},
+	}, {
+		label:    "delete column after max",
+		col:      6,
+		offset:   -1,
+		cols:     []xlsxCol{{Min: 3, Max: 5, Width: 20}},
+		expected: []xlsxCol{{Min: 3, Max: 5, Width: 20}},
+	}, {
+		label:    "delete the only column",
+		col:      3,
+		offset:   -1,
+		cols:     []xlsxCol{{Min: 1, Max: 2, Width: 10}, {Min: 3, Max: 3, Width: 20}},
+		expected: []xlsxCol{{Min: 1, Max: 2, Width: 10}},
+	}, {
+		label:    "insert column inside min and max",
+		col:      4,
+		offset:   1,
+		cols:     []xlsxCol{{Min: 3, Max: 5, Width: 20}},
+		expected: []xlsxCol{{Min: 3, Max: 6, Width: 20}},
+	}, {
+		label:    "insert column before min",
+		col:      3,
+		offset:   1,
+		cols:     []xlsxCol{{Min: 3, Max: 5, Width: 20}},
+		expected: []xlsxCol{{Min: 4, Max: 6, Width: 20}},
+	}}
+	for _, c := range cases {
+		f := NewFile()
+		xlsx := &xlsxWorksheet{Cols: &xlsxCols{Col: c.cols}}
+		f.adjustCols(xlsx, c.col, c.offset)
+		assert.Equal(t, c.expected, xlsx.Cols.Col, c.label)
+	}
+
+	f := NewFile()
+	xlsx := &xlsxWorksheet{Cols: &xlsxCols{Col: []xlsxCol{{Min: 3, Max: 3, Width: 20}}}}
+	f.adjustCols(xlsx, 3, -1)
+	assert.Nil(t, xlsx.Cols)
+
+	// the width is kept with the column after deleting a column before it.
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "E", 20))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	width, err = f.GetColWidth("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidthPixels, width)
+}
+
+func TestAdjustColOutlines(t *testing.T) {
+	f := NewFile()
+	// Group the columns C:E.
+	for _, col := range []string{"C", "D", "E"} {
+		assert.NoError(t, f.SetColOutlineLevel("Sheet1", col, 1))
+	}
+	// Test insert column before the group.
+	assert.NoError(t, f.InsertCol("Sheet1", "B"))
+	for col, expected := range map[string]uint8{"B": 0, "C": 0, "D": 1, "E": 1, "F": 1, "G": 0} {
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level, col)
+	}
+
+	// Test insert column inside of the collapsed group with summary column G.
+	for _, col := range []string{"D", "E", "F"} {
+		assert.NoError(t, f.SetColVisible("Sheet1", col, false))
+	}
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.Cols.Col = append(xlsx.Cols.Col, xlsxCol{Min: 7, Max: 7, Collapsed: true})
+	assert.NoError(t, f.InsertCol("Sheet1", "E"))
+	level, err := f.GetColOutlineLevel("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+	visible, err := f.GetColVisible("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+
+	// Test remove all columns of the group.
+	for col := 0; col < 4; col++ {
+		assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	}
+	for _, c := range xlsx.Cols.Col {
+		assert.False(t, c.Collapsed)
+		assert.Equal(t, uint8(0), c.OutlineLevel)
+	}
+
+	// Test insert column without the outline.
+	xlsx = &xlsxWorksheet{Cols: &xlsxCols{Col: []xlsxCol{{Min: 1, Max: 1, Width: 20}, {Min: 3, Max: 3, Width: 20}}}}
+	adjustColOutlines(xlsx, 2, 1)
+	assert.Len(t, xlsx.Cols.Col, 2)
+}
+
+func TestAdjustHyperlinkFormulas(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", `HYPERLINK("https://github.com/360EntSecGroup-Skylar/excelize?cell=A2","Sheet1!A2")`))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", `HYPERLINK("#Sheet1!A2",A2)`))
+	// Test the cells of the HYPERLINK formulas move without altering the URL.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	for cell, expected := range map[string]string{
+		"B3": `HYPERLINK("https://github.com/360EntSecGroup-Skylar/excelize?cell=A2","Sheet1!A2")`,
+		"B4": `HYPERLINK("#Sheet1!A2",A3)`,
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+}
+
+func TestAdjustReferences(t *testing.T) {
+	for _, c := range []struct {
+		formula, expected string
+	}{
+		{`SUM(A1:A3)+LOG10(B2)`, `SUM(A1:A4)+LOG10(B3)`},
+		{`Sheet1!$A$2+'Sheet1'!B1+Sheet2!A2`, `Sheet1!$A$3+'Sheet1'!B1+Sheet2!A2`},
+		{`"A2"&A2&[1]Sheet1!A2`, `"A2"&A3&[1]Sheet1!A2`},
+		{`'Bob''s Sheet'!A2`, `'Bob''s Sheet'!A2`},
+		{`'[Book2.xlsx]Sheet1'!A2+'C:\data\[Book2.xlsx]Sheet1'!A2+A2`, `'[Book2.xlsx]Sheet1'!A2+'C:\data\[Book2.xlsx]Sheet1'!A2+A3`},
+		{`SUM(Sheet2:Sheet1!A2)+SUM(Sheet1:Sheet3!A2:B2)`, `SUM(Sheet2:Sheet1!A2)+SUM(Sheet1:Sheet3!A2:B2)`},
+		{`SUM($2:$3)+SUM(Sheet1!1:1)+SUM(A:$B)+TIMEVALUE("1:30")`, `SUM($3:$4)+SUM(Sheet1!1:1)+SUM(A:$B)+TIMEVALUE("1:30")`},
+	} {
+		assert.Equal(t, c.expected, adjustReferences(c.formula, "Sheet1", true, rows, newAdjustMapping(2, 1)), c.formula)
+	}
+	assert.Equal(t, `SUM(1:2)+SUM(A:$C)+SUM(#REF!)`, adjustReferences(`SUM(1:2)+SUM(A:$D)+SUM(B:B)`, "Sheet1", true, columns, newAdjustMapping(2, -1)))
+	assert.Equal(t, `'Bob''s Sheet'!A3`, adjustReferences(`'Bob''s Sheet'!A2`, "Bob's Sheet", false, rows, newAdjustMapping(2, 1)))
+	assert.Equal(t, `A2+Sheet1!#REF!`, adjustReferences(`A2+Sheet1!B2`, "Sheet1", false, columns, newAdjustMapping(2, -1)))
+
+	// Test only the references to the worksheet are shifted after inserting.
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C3", "Sheet2!A1+A1"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "C3", "Sheet2!A1+A1+Sheet1!A1"))
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	formula, err := f.GetCellFormula("Sheet1", "C4")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet2!A1+A2", formula)
+	formula, err = f.GetCellFormula("Sheet2", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet2!A1+A1+Sheet1!A2", formula)
+}
+
+func TestAdjustPrintTitles(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetDefinedName(&DefinedName{
+		Name:     "_xlnm.Print_Titles",
+		RefersTo: "Sheet1!$A:$B,Sheet1!$1:$2",
+		Scope:    "Sheet1",
+	}))
+	printTitles := func() string {
+		for _, definedName := range f.GetDefinedName() {
+			if definedName.Name == "_xlnm.Print_Titles" {
+				return definedName.RefersTo
+			}
+		}
+		return ""
+	}
+
+	// Test insert a row above the print titles.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.Equal(t, "Sheet1!$A:$B,Sheet1!$2:$3", printTitles())
+	// Test insert a row and a column inside of the print titles.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.NoError(t, f.InsertCol("Sheet1", "B"))
+	assert.Equal(t, "Sheet1!$A:$C,Sheet1!$2:$4", printTitles())
+	// Test remove the rows of the print titles.
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A5", "A5"))
+		assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	}
+	assert.Equal(t, "Sheet1!$A:$C,Sheet1!#REF!", printTitles())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustPrintTitles.xlsx")))
+}
+
+func TestAdjustCommentsWithConditionalFormats(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{row, row * 2}))
+	}
+	assert.NoError(t, f.AddComment("Sheet1", "B5", `{"author":"Excelize: ","text":"Threshold"}`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B5", `[{"type":"formula","criteria":"$B$5>5","format":0}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", `[{"type":"formula","criteria":"A1>$B$5","format":0}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C10", `[{"type":"data_bar","criteria":"=","min_type":"num","max_type":"max","bar_color":"#638EC6"}]`))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.ConditionalFormatting[2].CfRule[0].DataBar.Cfvo[0].Val = "$B$5"
+
+	// Test the comment and the conditional formats referencing the commented
+	// cell stay aligned after inserting a row.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "B6", comments[0].Ref)
+	}
+	for i, expected := range [][]string{{"B6", "$B$6>5"}, {"A1:A11", "A1>$B$6"}, {"C1:C11", "$B$6"}} {
+		cf := xlsx.ConditionalFormatting[i]
+		assert.Equal(t, expected[0], cf.SQRef)
+		if cf.CfRule[0].DataBar != nil {
+			assert.Equal(t, expected[1], cf.CfRule[0].DataBar.Cfvo[0].Val)
+			continue
+		}
+		assert.Equal(t, []string{expected[1]}, cf.CfRule[0].Formula)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustCommentsWithConditionalFormats.xlsx")))
+
+	// Test the references to the deleted commented cell are replaced by #REF!.
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	assert.Empty(t, f.GetComments()["Sheet1"])
+	if assert.Len(t, xlsx.ConditionalFormatting, 2) {
+		assert.Equal(t, []string{"A1>#REF!"}, xlsx.ConditionalFormatting[0].CfRule[0].Formula)
+	}
+}
+
+func TestAdjustComments(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "B3", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "C5", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustComments.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAdjustComments.xlsx"))
+	assert.NoError(t, err)
+	// Test insert row above the comments.
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, "B4", comments[0].Ref)
+		assert.Equal(t, "C6", comments[1].Ref)
+	}
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 2) {
+		assert.Contains(t, vml.Shape[0].Val, "<x:Anchor>2, 23, 4, 0, 4, 29, 6, 5</x:Anchor>")
+		assert.Contains(t, vml.Shape[0].Val, "<x:Row>3</x:Row>")
+		assert.Contains(t, vml.Shape[0].Val, "<x:Column>1</x:Column>")
+	}
+
+	// Test remove column of the comment and insert column before the comment.
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.NoError(t, f.InsertCol("Sheet1", "A"))
+	comments = f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "C6", comments[0].Ref)
+	}
+	if assert.Len(t, vml.Shape, 1) {
+		assert.Contains(t, vml.Shape[0].Val, "<x:Anchor>3, 23, 6, 0, 5, 30, 8, 5</x:Anchor>")
+		assert.Contains(t, vml.Shape[0].Val, "<x:Row>5</x:Row>")
+		assert.Contains(t, vml.Shape[0].Val, "<x:Column>2</x:Column>")
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustComments.xlsx")))
+}
+
+func TestAdjustThreadedComments(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "B3", `{"author":"tc={5F3A0A12-1C2B-4C8E-9B3E-6A6B8E2C6B01}","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "C5", `{"author":"tc={5F3A0A12-1C2B-4C8E-9B3E-6A6B8E2C6B03}","text":"This is a comment."}`))
+	f.addSheetRelationships("Sheet1", SourceRelationshipThreadedComment, "../threadedComments/threadedComment1.xml", "")
+	f.XLSX["xl/threadedComments/threadedComment1.xml"] = []byte(`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"><threadedComment ref="B3" dT="2019-10-01T08:00:00.00" personId="{0D3C2D6B-6E0A-4E2C-8F24-3B5D1E2A9C11}" id="{5F3A0A12-1C2B-4C8E-9B3E-6A6B8E2C6B01}"><text>This is a comment.</text></threadedComment><threadedComment ref="B3" dT="2019-10-01T09:00:00.00" personId="{0D3C2D6B-6E0A-4E2C-8F24-3B5D1E2A9C11}" id="{5F3A0A12-1C2B-4C8E-9B3E-6A6B8E2C6B02}" parentId="{5F3A0A12-1C2B-4C8E-9B3E-6A6B8E2C6B01}"><text>This is a reply.</text></threadedComment><threadedComment ref="C5" dT="2019-10-01T10:00:00.00" personId="{0D3C2D6B-6E0A-4E2C-8F24-3B5D1E2A9C11}" id="{5F3A0A12-1C2B-4C8E-9B3E-6A6B8E2C6B03}"><text>This is a comment.</text><mentions><mention mentionpersonId="{0D3C2D6B-6E0A-4E2C-8F24-3B5D1E2A9C11}" mentionId="{5F3A0A12-1C2B-4C8E-9B3E-6A6B8E2C6B04}" startIndex="0" length="4"/></mentions></threadedComment></ThreadedComments>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustThreadedComments.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAdjustThreadedComments.xlsx"))
+	assert.NoError(t, err)
+	// Test the legacy and threaded comments are shifted together.
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	comments := f.GetComments()["Sheet1"]
+	threadedComments := f.ThreadedComments["xl/threadedComments/threadedComment1.xml"]
+	if assert.Len(t, comments, 2) && assert.Len(t, threadedComments.ThreadedComment, 3) {
+		assert.Equal(t, "B4", comments[0].Ref)
+		assert.Equal(t, "C6", comments[1].Ref)
+		for idx, ref := range []string{"B4", "B4", "C6"} {
+			assert.Equal(t, ref, threadedComments.ThreadedComment[idx].Ref)
+		}
+	}
+
+	// Test remove the column of the thread with the reply.
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	comments = f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) && assert.Len(t, threadedComments.ThreadedComment, 1) {
+		assert.Equal(t, "B6", comments[0].Ref)
+		assert.Equal(t, "B6", threadedComments.ThreadedComment[0].Ref)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustThreadedComments.xlsx")))
+	assert.Contains(t, string(f.XLSX["xl/threadedComments/threadedComment1.xml"]), `<mentions><mention mentionpersonId=`)
+
+	// Test delete the comment of the cell with the thread.
+	assert.NoError(t, f.DeleteComment("Sheet1", "B6"))
+	assert.Empty(t, threadedComments.ThreadedComment)
+}
+
+func TestAdjustCommentsShapePosition(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "B3", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if !assert.Len(t, vml.Shape, 1) {
+		t.FailNow()
+	}
+	vml.Shape[0].Style = "position:absolute;margin-left:0pt;margin-top:0pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
+
+	// Test the absolute position of the shape follows the cell after
+	// inserting a row above the cell of the comment.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.Contains(t, vml.Shape[0].Val, "<x:Row>3</x:Row>")
+	assert.Equal(t, "position:absolute;margin-left:113.25pt;margin-top:75pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden", vml.Shape[0].Style)
+	// Test the hidden rows and columns are excluded from the position.
+	assert.NoError(t, f.SetRowVisible("Sheet1", 1, false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "A", false))
+	assert.NoError(t, f.InsertCol("Sheet1", "B"))
+	assert.Contains(t, vml.Shape[0].Val, "<x:Column>2</x:Column>")
+	assert.Equal(t, "position:absolute;margin-left:113.25pt;margin-top:60pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden", vml.Shape[0].Style)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustCommentsShapePosition.xlsx")))
+
+	// Test keep the size of the shape which can't be moved by the offset.
+	assert.Equal(t, "1, 15, 0, 2, 3, 15, 3, 16", adjustVMLShapeAnchor("1, 15, 0, 2, 3, 15, 3, 16", rows, -1))
+	assert.Equal(t, "0, 15, 0, 2, 2, 15, 3, 16", adjustVMLShapeAnchor("1, 15, 0, 2, 3, 15, 3, 16", columns, -2))
+	// Test recalculate the position with invalid anchor.
+	margins := newVMLShapeMargins(&xlsxWorksheet{SheetFormatPr: &xlsxSheetFormatPr{DefaultRowHeight: 30}})
+	assert.Equal(t, "margin-top:0pt", margins.adjust("margin-top:0pt", ""))
+	assert.Equal(t, "margin-top:0pt", margins.adjust("margin-top:0pt", "<x:Anchor>1, 0, 1, 0</x:Anchor>"))
+	assert.Equal(t, "margin-top:0pt", margins.adjust("margin-top:0pt", "<x:Anchor>1, 0, A, 0, 2, 0, 2, 0</x:Anchor>"))
+	assert.Equal(t, "margin-top:30pt", margins.adjust("margin-top:0pt", "<x:Anchor>1, 0, 1, 0, 2, 0, 2, 0</x:Anchor>"))
+}
+
+// formControlsVML is the VML drawing of a legacy check box saved by Excel.
+const formControlsVML = `<xml xmlns:v="urn:schemas-microsoft-com:vml"
+ xmlns:o="urn:schemas-microsoft-com:office:office"
+ xmlns:x="urn:schemas-microsoft-com:office:excel">
+ <o:shapelayout v:ext="edit">
+  <o:idmap v:ext="edit" data="2"/>
+ </o:shapelayout><v:shapetype id="_x0000_t201" coordsize="21600,21600" o:spt="201"
+  path="m,l,21600r21600,l21600,xe">
+  <v:stroke joinstyle="miter"/>
+  <v:path shadowok="f" o:extrusionok="f" strokeok="f" fillok="f" o:connecttype="rect"/>
+  <o:lock v:ext="edit" shapetype="t"/>
+ </v:shapetype><v:shape id="_x0000_s2049" type="#_x0000_t201" style='position:absolute;
+  margin-left:48pt;margin-top:15pt;width:72pt;height:18pt;z-index:1;
+  mso-wrap-style:tight' filled="f" fillcolor="window [65]" stroked="f"
+  strokecolor="windowText [64]" o:insetmode="auto" o:button="t">
+  <v:path shadowok="t" strokeok="t" fillok="t"/>
+  <o:lock v:ext="edit" rotation="t"/>
+  <v:textbox style='mso-direction-alt:auto' o:singleclick="f">
+   <div style='text-align:left'><font face="Segoe UI" size="160" color="auto">Check Box 1</font></div>
+  </v:textbox>
+  <x:ClientData ObjectType="Checkbox">
+   <x:Anchor>
+    1, 0, 1, 0, 2, 56, 2, 0</x:Anchor>
+   <x:AutoFill>False</x:AutoFill>
+   <x:AutoLine>False</x:AutoLine>
+   <x:TextVAlign>Center</x:TextVAlign>
+   <x:FmlaLink>%s</x:FmlaLink>
+   <x:NoThreeD/>
+  </x:ClientData>
+ </v:shape></xml>`
+
+func TestAdjustFormControls(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	f.NewSheet("Sheet3")
+	// Add a legacy check box in the VML drawing of the comments.
+	assert.NoError(t, f.AddComment("Sheet1", "E10", `{"author":"Excelize: ","text":"This is a comment."}`))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:   "_x0000_s1027",
+		Type: "#_x0000_t202",
+		Val:  `<x:ClientData ObjectType="Checkbox"><x:Anchor>1, 0, 1, 0, 2, 0, 2, 0</x:Anchor><x:FmlaLink>$B$2</x:FmlaLink></x:ClientData>`,
+	})
+	// Add the VML drawing of a check box saved by Excel linked to the cell of
+	// the other worksheet.
+	f.XLSX["xl/drawings/vmlDrawing2.vml"] = []byte(fmt.Sprintf(formControlsVML, "Sheet1!$B$2"))
+	xlsx, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	xlsx.LegacyDrawing = &xlsxLegacyDrawing{RID: "rId" + strconv.Itoa(f.addSheetRelationships("Sheet2", SourceRelationshipDrawingVML, "../drawings/vmlDrawing2.vml", ""))}
+	// Add the form control properties of a check box and a list box.
+	f.XLSX["xl/ctrlProps/ctrlProp1.xml"] = []byte(`<formControlPr xmlns="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" objectType="CheckBox" fmlaLink="$B$2" lx="0"/>`)
+	f.XLSX["xl/ctrlProps/ctrlProp2.xml"] = []byte(`<formControlPr xmlns="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" objectType="List" fmlaLink="&apos;Sheet1&apos;!$C$1" fmlaRange="Sheet1!$D$1:$D$5"><itemLst><item val="A2"/></itemLst></formControlPr>`)
+	f.addSheetRelationships("Sheet1", SourceRelationshipCtrlProp, "../ctrlProps/ctrlProp1.xml", "")
+	f.addSheetRelationships("Sheet2", SourceRelationshipCtrlProp, "../ctrlProps/ctrlProp2.xml", "")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustFormControls.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAdjustFormControls.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	assert.Equal(t, `<formControlPr xmlns="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" objectType="CheckBox" fmlaLink="$B$3" lx="0"/>`, string(f.XLSX["xl/ctrlProps/ctrlProp1.xml"]))
+	assert.Equal(t, `<formControlPr xmlns="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main" objectType="List" fmlaLink="&#39;Sheet1&#39;!$C$1" fmlaRange="Sheet1!$D$1:$D$6"><itemLst><item val="A2"/></itemLst></formControlPr>`, string(f.XLSX["xl/ctrlProps/ctrlProp2.xml"]))
+	vml = f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 2) {
+		assert.Contains(t, vml.Shape[1].Val, "<x:FmlaLink>$B$3</x:FmlaLink>")
+	}
+	// Test the VML drawing saved by Excel is updated without loading it, only
+	// the linked cell is changed.
+	assert.Nil(t, f.VMLDrawing["xl/drawings/vmlDrawing2.vml"])
+	assert.Equal(t, fmt.Sprintf(formControlsVML, "Sheet1!$B$3"), string(f.XLSX["xl/drawings/vmlDrawing2.vml"]))
+
+	// Test the references to the other worksheets are left unchanged.
+	assert.NoError(t, f.InsertCol("Sheet3", "A"))
+	assert.Contains(t, string(f.XLSX["xl/ctrlProps/ctrlProp2.xml"]), `fmlaRange="Sheet1!$D$1:$D$6"`)
+	assert.Nil(t, f.VMLDrawing["xl/drawings/vmlDrawing2.vml"])
+	assert.Equal(t, fmt.Sprintf(formControlsVML, "Sheet1!$B$3"), string(f.XLSX["xl/drawings/vmlDrawing2.vml"]))
+
+	// Test delete the linked cell.
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.Contains(t, string(f.XLSX["xl/ctrlProps/ctrlProp1.xml"]), `fmlaLink="#REF!"`)
+	assert.Contains(t, vml.Shape[1].Val, "<x:FmlaLink>#REF!</x:FmlaLink>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustFormControls.xlsx")))
+
+	// Test the VML drawing saved by Excel is kept after saving.
+	f, err = OpenFile(filepath.Join("test", "TestAdjustFormControls.xlsx"))
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(formControlsVML, "Sheet1!#REF!"), string(f.XLSX["xl/drawings/vmlDrawing2.vml"]))
+}
+
+func TestAdjustCharts(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Small", 2}, {"Normal", 3}, {"Large", 5}} {
+		cell, _ := CoordinatesToCellName(1, idx+2)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E4", `{"type":"col","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$4","values":"Sheet1!$B$2:$B$4"}],"title":{"name":"Column Chart"}}`))
+	// Add the cached values of the series values.
+	chart := string(f.XLSX["xl/charts/chart1.xml"])
+	cache := `<c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="3"/><c:pt idx="0"><c:v>2</c:v></c:pt><c:pt idx="1"><c:v>3</c:v></c:pt><c:pt idx="2"><c:v>5</c:v></c:pt></c:numCache>`
+	if !assert.Contains(t, chart, "<c:f>Sheet1!$B$2:$B$4</c:f>") {
+		t.FailNow()
+	}
+	f.XLSX["xl/charts/chart1.xml"] = []byte(strings.Replace(chart, "<c:f>Sheet1!$B$2:$B$4</c:f>", "<c:f>Sheet1!$B$2:$B$4</c:f>"+cache, 1))
+
+	// Test insert a data row keeps the cached values by default.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	chart = string(f.XLSX["xl/charts/chart1.xml"])
+	assert.Contains(t, chart, "<c:f>Sheet1!$A$2:$A$5</c:f>")
+	assert.Contains(t, chart, "<c:f>Sheet1!$B$2:$B$5</c:f>"+cache)
+	assert.Contains(t, chart, "<c:f>Sheet1!$B$1</c:f>")
+
+	// Test insert a data row clears the cached values of the changed
+	// references.
+	f.SetAdjustOptions(ClearChartCaches(true))
+	var option ClearChartCaches
+	f.GetAdjustOptions(&option)
+	assert.Equal(t, ClearChartCaches(true), option)
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	chart = string(f.XLSX["xl/charts/chart1.xml"])
+	assert.Contains(t, chart, "<c:f>Sheet1!$B$2:$B$6</c:f></c:numRef>")
+	assert.NotContains(t, chart, "<c:numCache>")
+
+	// Test the references to the other worksheets are left unchanged.
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.InsertRow("Sheet2", 1))
+	assert.Contains(t, string(f.XLSX["xl/charts/chart1.xml"]), "<c:f>Sheet1!$B$2:$B$6</c:f>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustCharts.xlsx")))
+}
+
+func TestAdjustChartTitles(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Sales", "Amount"}))
+	for idx, row := range [][]interface{}{{"Small", 2}, {"Normal", 3}, {"Large", 5}} {
+		cell, _ := CoordinatesToCellName(1, idx+2)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "AxisLabel", RefersTo: "Sheet1!$B$1"}))
+	assert.NoError(t, f.AddChart("Sheet1", "E4", `{"type":"col","series":[{"name":"Sheet1!$B$1","categories":"Sheet1!$A$2:$A$4","values":"Sheet1!$B$2:$B$4"}],"title":{"name":"Column Chart"}}`))
+	// Bind the chart title to the cell A1, and the title of the value axis to
+	// the defined name.
+	chart := regexp.MustCompile(`<c:title><c:tx>.*?</c:tx>`).ReplaceAllLiteralString(string(f.XLSX["xl/charts/chart1.xml"]),
+		`<c:title><c:tx><c:strRef><c:f>Sheet1!$A$1</c:f><c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>Sales</c:v></c:pt></c:strCache></c:strRef></c:tx>`)
+	if !assert.Contains(t, chart, `<c:axPos val="l"></c:axPos>`) {
+		t.FailNow()
+	}
+	f.XLSX["xl/charts/chart1.xml"] = []byte(strings.Replace(chart, `<c:axPos val="l"></c:axPos>`,
+		`<c:axPos val="l"></c:axPos><c:title><c:tx><c:strRef><c:f>Sheet1!AxisLabel</c:f></c:strRef></c:tx><c:overlay val="0"/></c:title>`, 1))
+
+	// Test the chart title and the defined name of the axis title follow the
+	// inserted row.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	chart = string(f.XLSX["xl/charts/chart1.xml"])
+	assert.Contains(t, chart, "<c:title><c:tx><c:strRef><c:f>Sheet1!$A$2</c:f><c:strCache>")
+	assert.Contains(t, chart, "<c:f>Sheet1!AxisLabel</c:f>")
+	definedNames := f.GetDefinedName()
+	if assert.Len(t, definedNames, 1) {
+		assert.Equal(t, "Sheet1!$B$2", definedNames[0].RefersTo)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustChartTitles.xlsx")))
+
+	// Test the chart title bound to the deleted cell.
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	assert.Contains(t, string(f.XLSX["xl/charts/chart1.xml"]), "<c:f>Sheet1!#REF!</c:f>")
+	assert.Equal(t, "Sheet1!#REF!", f.GetDefinedName()[0].RefersTo)
+}
+
+func TestAdjustPivotSource(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	definition := `<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId1" recordCount="4"><cacheSource type="worksheet"><worksheetSource %s/></cacheSource></pivotCacheDefinition>`
+	f.XLSX["xl/pivotCache/pivotCacheDefinition1.xml"] = []byte(fmt.Sprintf(definition, `ref="A1:C5" sheet="Sheet1"`))
+	f.XLSX["xl/pivotCache/pivotCacheDefinition2.xml"] = []byte(fmt.Sprintf(definition, `ref="A1:C5" sheet="Sheet2"`))
+	f.XLSX["xl/pivotCache/pivotCacheDefinition3.xml"] = []byte(fmt.Sprintf(definition, `name="Amount"`))
+
+	// Test insert rows into the source range.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.Equal(t, fmt.Sprintf(definition, `ref="A1:C7" sheet="Sheet1"`), string(f.XLSX["xl/pivotCache/pivotCacheDefinition1.xml"]))
+	assert.Equal(t, fmt.Sprintf(definition, `ref="A1:C5" sheet="Sheet2"`), string(f.XLSX["xl/pivotCache/pivotCacheDefinition2.xml"]))
+	assert.Equal(t, fmt.Sprintf(definition, `name="Amount"`), string(f.XLSX["xl/pivotCache/pivotCacheDefinition3.xml"]))
+
+	// Test insert a row above and delete a column of the source range.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.Equal(t, fmt.Sprintf(definition, `ref="A2:B8" sheet="Sheet1"`), string(f.XLSX["xl/pivotCache/pivotCacheDefinition1.xml"]))
+
+	// Test the source range is left unchanged when it is wholly deleted.
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, f.RemoveCol("Sheet2", "A"))
+	}
+	assert.Equal(t, fmt.Sprintf(definition, `ref="A1:A5" sheet="Sheet2"`), string(f.XLSX["xl/pivotCache/pivotCacheDefinition2.xml"]))
+}
+
+func TestAdjustPivotSourceRefreshOnLoad(t *testing.T) {
+	f := NewFile()
+	definition := `<pivotCacheDefinition xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId1"%s><cacheSource type="worksheet"><worksheetSource ref="%s" sheet="Sheet1"/></cacheSource></pivotCacheDefinition>`
+	f.XLSX["xl/pivotCache/pivotCacheDefinition1.xml"] = []byte(fmt.Sprintf(definition, ` recordCount="4"`, "A1:C5"))
+	f.XLSX["xl/pivotCache/pivotCacheDefinition2.xml"] = []byte(fmt.Sprintf(definition, ` refreshOnLoad="0" recordCount="4"`, "A1:C5"))
+	f.XLSX["xl/pivotCache/pivotCacheDefinition3.xml"] = []byte(fmt.Sprintf(definition, ` recordCount="1"`, "E1:F2"))
+
+	// Test insert the source rows leaves the pivot caches by default.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.Equal(t, fmt.Sprintf(definition, ` recordCount="4"`, "A1:C6"), string(f.XLSX["xl/pivotCache/pivotCacheDefinition1.xml"]))
+
+	// Test insert the source rows marks the changed pivot caches to be
+	// refreshed on load.
+	f.SetAdjustOptions(RefreshPivotCaches(true))
+	var option RefreshPivotCaches
+	f.GetAdjustOptions(&option)
+	assert.Equal(t, RefreshPivotCaches(true), option)
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.Equal(t, fmt.Sprintf(definition, ` recordCount="4" refreshOnLoad="1"`, "A1:C7"), string(f.XLSX["xl/pivotCache/pivotCacheDefinition1.xml"]))
+	assert.Equal(t, fmt.Sprintf(definition, ` recordCount="4" refreshOnLoad="1"`, "A1:C7"), string(f.XLSX["xl/pivotCache/pivotCacheDefinition2.xml"]))
+
+	// Test the pivot caches whose source ranges are unchanged are left.
+	assert.NoError(t, f.InsertCol("Sheet1", "G"))
+	assert.Equal(t, fmt.Sprintf(definition, ` recordCount="1"`, "E1:F2"), string(f.XLSX["xl/pivotCache/pivotCacheDefinition3.xml"]))
+
+	// Test delete the source rows marks the pivot cache only once.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A7", "Total"))
+	assert.NoError(t, f.RemoveRow("Sheet1", 7))
+	assert.Equal(t, fmt.Sprintf(definition, ` recordCount="4" refreshOnLoad="1"`, "A1:C6"), string(f.XLSX["xl/pivotCache/pivotCacheDefinition1.xml"]))
+}
+
+func TestAdjustCommentsVisible(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", "B3", `{"author":"Excelize: ","text":"This is a comment."}`))
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if !assert.Len(t, vml.Shape, 1) {
+		t.FailNow()
+	}
+	// Mark the comment always visible.
+	vml.Shape[0].ID = "_x0000_s1026"
+	vml.Shape[0].Style = strings.Replace(vml.Shape[0].Style, "visibility:hidden", "visibility:visible", 1)
+	vml.Shape[0].Val = strings.Replace(vml.Shape[0].Val, "</x:ClientData>", "<x:Visible></x:Visible></x:ClientData>", 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustCommentsVisible.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAdjustCommentsVisible.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "B4", comments[0].Ref)
+	}
+	vml = f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 1) {
+		assert.Equal(t, "_x0000_s1026", vml.Shape[0].ID)
+		assert.Contains(t, vml.Shape[0].Style, "visibility:visible")
+		assert.Contains(t, vml.Shape[0].Val, "<x:AutoFill>True</x:AutoFill>")
+		assert.Contains(t, vml.Shape[0].Val, "<x:Row>3</x:Row>")
+		assert.Contains(t, vml.Shape[0].Val, "<x:Visible></x:Visible>")
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustCommentsVisible.xlsx")))
+}
+
+func TestAdjustDataValidations(t *testing.T) {
+	f := NewFile()
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A2:B3"
+	dvRange.Formula1 = "<formula1>$E$1:$E$3</formula1>"
+	dvRange.Type = convDataValidationType(typeList)
+	dvRange.SetError(DataValidationErrorStyleWarning, "error title", "error body")
+	dvRange.SetInput("input title", "input body")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "D5"
+	assert.NoError(t, dvRange.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustDataValidations.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestAdjustDataValidations.xlsx"))
+	assert.NoError(t, err)
+	// Test insert a row above the data validations.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, xlsx.DataValidations.DataValidation, 2) {
+		dv := xlsx.DataValidations.DataValidation[0]
+		assert.Equal(t, "A3:B4", dv.Sqref)
+		assert.Equal(t, "<formula1>$E$2:$E$4</formula1>", dv.Formula1)
+		assert.Equal(t, "list", dv.Type)
+		assert.True(t, dv.AllowBlank)
+		assert.True(t, dv.ShowErrorMessage)
+		assert.True(t, dv.ShowInputMessage)
+		assert.Equal(t, "warning", *dv.ErrorStyle)
+		assert.Equal(t, "error title", *dv.ErrorTitle)
+		assert.Equal(t, "error body", *dv.Error)
+		assert.Equal(t, "input title", *dv.PromptTitle)
+		assert.Equal(t, "input body", *dv.Prompt)
+		assert.Equal(t, "D6", xlsx.DataValidations.DataValidation[1].Sqref)
+		assert.Equal(t, "between", xlsx.DataValidations.DataValidation[1].Operator)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustDataValidations.xlsx")))
+
+	// Test remove the data validations with the deleted ranges.
+	assert.NoError(t, f.SetCellValue("Sheet1", "D6", 15))
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	if assert.Len(t, xlsx.DataValidations.DataValidation, 1) {
+		assert.Equal(t, 1, xlsx.DataValidations.Count)
+	}
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.Nil(t, xlsx.DataValidations)
+
+	// Test adjust the data validations with invalid range.
+	xlsx.DataValidations = &xlsxDataValidations{DataValidation: []*DataValidation{{Sqref: "A"}}}
+	assert.EqualError(t, f.InsertRow("Sheet1", 1), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestAdjustDataValidationsDeleteMiddle(t *testing.T) {
+	f := NewFile()
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A1:A10"
+	assert.NoError(t, dvRange.SetDropList([]string{"1", "2", "3"}))
+	dvRange.SetError(DataValidationErrorStyleStop, "error title", "error body")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "C1:C4 C5 C6:C10"
+	assert.NoError(t, dvRange.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+
+	// Test delete a middle row of the validated ranges, the data validation
+	// is split into two data validations covering the cells left before and
+	// after the deleted row, and both of them keep the settings.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", 1))
+	assert.NoError(t, f.RemoveRow("Sheet1", 5))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, xlsx.DataValidations.DataValidation, 3) {
+		for i, sqref := range []string{"A1:A4", "A5:A9"} {
+			dv := xlsx.DataValidations.DataValidation[i]
+			assert.Equal(t, sqref, dv.Sqref)
+			assert.Equal(t, "list", dv.Type)
+			assert.Equal(t, `<formula1>"1,2,3"</formula1>`, dv.Formula1)
+			assert.Equal(t, "error title", *dv.ErrorTitle)
+			assert.Equal(t, "error body", *dv.Error)
+		}
+		dv := xlsx.DataValidations.DataValidation[2]
+		assert.Equal(t, "C1:C4 C5:C9", dv.Sqref)
+		assert.Equal(t, "between", dv.Operator)
+		assert.Equal(t, 3, xlsx.DataValidations.Count)
+	}
+
+	// Test remove the rows inside of multiple validated ranges, the nth parts
+	// of the split ranges of a data validation are kept together.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A9", 1))
+	assert.NoError(t, f.RemoveRowsByIndex("Sheet1", []int{2, 7}))
+	var sqrefs []string
+	for _, dv := range xlsx.DataValidations.DataValidation {
+		sqrefs = append(sqrefs, dv.Sqref)
+	}
+	assert.Equal(t, []string{"A1", "A2:A3", "A4:A5", "A6:A7", "C1 C4:C5", "C2:C3 C6:C7"}, sqrefs)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustDataValidationsDeleteMiddle.xlsx")))
+}
+
+func TestAdjustIgnoredErrors(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "D5", "1"))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.IgnoredErrors = &xlsxIgnoredErrors{IgnoredError: []*xlsxIgnoredError{
+		{Sqref: "A2:B4 D5", NumberStoredAsText: true},
+		{Sqref: "C1", EvalError: true},
+	}}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustIgnoredErrors.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAdjustIgnoredErrors.xlsx"))
+	assert.NoError(t, err)
+	// Test insert a row inside the range of the ignored errors.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, xlsx.IgnoredErrors.IgnoredError, 2) {
+		assert.Equal(t, &xlsxIgnoredError{Sqref: "A2:B5 D6", NumberStoredAsText: true}, xlsx.IgnoredErrors.IgnoredError[0])
+		assert.Equal(t, &xlsxIgnoredError{Sqref: "C1", EvalError: true}, xlsx.IgnoredErrors.IgnoredError[1])
+	}
+
+	// Test remove the ignored errors with the deleted ranges.
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	if assert.Len(t, xlsx.IgnoredErrors.IgnoredError, 1) {
+		assert.Equal(t, "A2:B5", xlsx.IgnoredErrors.IgnoredError[0].Sqref)
+	}
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.Nil(t, xlsx.IgnoredErrors)
+
+	// Test adjust the ignored errors with invalid range.
+	xlsx.IgnoredErrors = &xlsxIgnoredErrors{IgnoredError: []*xlsxIgnoredError{{Sqref: "A"}}}
+	assert.EqualError(t, f.InsertRow("Sheet1", 1), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestAdjustFormulas(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A5", "SUM(A1:A4)"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "B1", "Sheet1!A3*2+A3"))
+
+	// Test insert row without formulas changed.
+	f.SetAdjustOptions(FullCalcOnLoad(true))
+	assert.NoError(t, f.InsertRow("Sheet1", 6))
+	assert.False(t, f.workbookReader().CalcPr.FullCalcOnLoad)
+
+	// Test insert row without the FullCalcOnLoad option.
+	f.SetAdjustOptions(FullCalcOnLoad(false))
+	assert.NoError(t, f.InsertRow("Sheet1", 5))
+	assert.False(t, f.workbookReader().CalcPr.FullCalcOnLoad)
+
+	// Test insert row into the formula range.
+	f.SetAdjustOptions(FullCalcOnLoad(true))
+	f.workbookReader().CalcPr = nil
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	for cell, expected := range map[string]string{"Sheet1!A7": "SUM(A1:A5)", "Sheet2!B1": "Sheet1!A4*2+A3"} {
+		ref := strings.Split(cell, "!")
+		formula, err := f.GetCellFormula(ref[0], ref[1])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	assert.True(t, f.workbookReader().CalcPr.FullCalcOnLoad)
+	var fullCalcOnLoad FullCalcOnLoad
+	f.GetAdjustOptions(&fullCalcOnLoad)
+	assert.True(t, bool(fullCalcOnLoad))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustFormulas.xlsx")))
+
+	// Test the worksheets without the references to the worksheet are not
+	// loaded, and the worksheets with the references are loaded and updated.
+	f = NewFile()
+	f.NewSheet("Sheet2")
+	f.NewSheet("Sheet3")
+	f.NewSheet("Sheet4")
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "SUM(A1:A4)+Sheet3!A5"))
+	assert.NoError(t, f.SetCellFormula("Sheet3", "A1", "SUM(Sheet1!A1:A4)&\"&\""))
+	assert.NoError(t, f.SetCellHyperLink("Sheet4", "A1", "Sheet1!A10", "Location"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustFormulas.xlsx")))
+	f, err := OpenFile(filepath.Join("test", "TestAdjustFormulas.xlsx"))
+	assert.NoError(t, err)
+	sheet2 := f.XLSX["xl/worksheets/sheet2.xml"]
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	assert.Nil(t, f.Sheet["xl/worksheets/sheet2.xml"])
+	assert.Equal(t, sheet2, f.XLSX["xl/worksheets/sheet2.xml"])
+	formula, err := f.GetCellFormula("Sheet3", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, `SUM(Sheet1!A1:A5)&"&"`, formula)
+	_, target, err := f.GetCellHyperLink("Sheet4", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!A11", target)
+}
+
+func TestAdjustSharedFormulas(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1*2+$A$1+Sheet2!A1", FormulaOpts{Type: STCellFormulaTypeShared, Ref: "B1:B5"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "$A1+F1", FormulaOpts{Type: STCellFormulaTypeShared, Ref: "D1:F1"}))
+	formula := func(cell string) *xlsxF {
+		xlsx, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		col, row, err := CellNameToCoordinates(cell)
+		assert.NoError(t, err)
+		return xlsx.SheetData.Row[row-1].C[col-1].F
+	}
+
+	// Test delete the master cell of the shared formula.
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.Equal(t, &xlsxF{Content: "A1*2+#REF!+Sheet2!A2", T: STCellFormulaTypeShared, Ref: "B1:B4", Si: "0"}, formula("B1"))
+	assert.Equal(t, &xlsxF{T: STCellFormulaTypeShared, Si: "0"}, formula("B2"))
+	value, err := f.GetCellFormula("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1*2+#REF!+Sheet2!A2", value)
+
+	// Test delete the master cell and the next slave cell in several runs.
+	assert.NoError(t, f.RemoveRowsByIndex("Sheet1", []int{1, 2, 4}))
+	assert.Equal(t, &xlsxF{Content: "A1*2+#REF!+Sheet2!A4", T: STCellFormulaTypeShared, Ref: "B1:B1", Si: "0"}, formula("B1"))
+
+	// Test delete the master cell in the columns.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "$A1+F1", FormulaOpts{Type: STCellFormulaTypeShared, Ref: "D1:F1"}))
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	assert.Equal(t, &xlsxF{Content: "$A1+F1", T: STCellFormulaTypeShared, Ref: "D1:E1", Si: "1"}, formula("D1"))
+	assert.Equal(t, &xlsxF{T: STCellFormulaTypeShared, Si: "1"}, formula("E1"))
+
+	// Test delete all the cells of the shared formula.
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, xlsx.SheetData.Row[0].C, 3)
+
+	// Test delete the master cell of the shared formula with invalid range.
+	formula("B1").Ref = "B"
+	assert.EqualError(t, f.RemoveRow("Sheet1", 1), `invalid area "B"`)
+	assert.EqualError(t, f.RemoveRowsByIndex("Sheet1", []int{1}), `invalid area "B"`)
+	assert.EqualError(t, f.RemoveCol("Sheet1", "B"), `invalid area "B"`)
+}
+
+func TestOffsetCellReference(t *testing.T) {
+	for _, c := range []struct{ ref, expected string }{
+		{"A1", "C2"},
+		{"$A1:B$1", "$A2:D$1"},
+		{"1:$2", "2:$2"},
+		{"A:$B", "C:$B"},
+		{"A1048576", "#REF!"},
+		{"XFD1", "#REF!"},
+		{"2:3", "3:4"},
+		{"XFD:XFD", "#REF!"},
+		{"1048576:1048576", "#REF!"},
+	} {
+		assert.Equal(t, c.expected, offsetCellReference(c.ref, 2, 1), c.ref)
+	}
+}
+
+func TestAdjustStats(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{1, 2, 3}))
+	}
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "B3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C4", "C5"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B5", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "C5", ""))
+
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	stats, err := f.GetAdjustStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, AdjustStats{CellsShifted: 12, MergeCellsAdjusted: 2, HyperlinksAdjusted: 2}, stats)
+	// Test remove the row of the hyperlink and the last row of merged cells.
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	// Test remove the header row of the auto filter.
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	stats, err = f.GetAdjustStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, AdjustStats{
+		CellsShifted:       21,
+		MergeCellsAdjusted: 3,
+		MergeCellsDropped:  1,
+		HyperlinksAdjusted: 3,
+		HyperlinksDropped:  1,
+		AutoFiltersCleared: 1,
+	}, stats)
+
+	assert.NoError(t, f.ResetAdjustStats("Sheet1"))
+	stats, err = f.GetAdjustStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, AdjustStats{}, stats)
+
+	// Test get and reset the counters on not exists worksheet.
+	_, err = f.GetAdjustStats("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	assert.EqualError(t, f.ResetAdjustStats("SheetN"), "sheet SheetN is not exist")
+}
+
+func TestAdjustTables(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Name", "Price", "Amount"}, {"a", 1, 2}, {"b", 3, 4}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(idx+1), &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "C3", `{"table_name":"table"}`))
+	tableReader := func() *xlsxTable {
+		var table xlsxTable
+		assert.NoError(t, xml.Unmarshal(f.XLSX["xl/tables/table1.xml"], &table))
+		return &table
+	}
+	tableColumnNames := func(table *xlsxTable) (names []string) {
+		for idx, column := range table.TableColumns.TableColumn {
+			assert.Equal(t, idx+1, column.ID)
+			names = append(names, column.Name)
+		}
+		assert.Equal(t, len(names), table.TableColumns.Count)
+		return
+	}
+
+	// Test insert a column in the middle of the table.
+	assert.NoError(t, f.InsertCol("Sheet1", "B"))
+	table := tableReader()
+	assert.Equal(t, "A1:D3", table.Ref)
+	assert.Equal(t, "A1:D3", table.AutoFilter.Ref)
+	assert.Equal(t, []string{"Name", "Column2", "Price", "Amount"}, tableColumnNames(table))
+	header, err := f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Column2", header)
+
+	// Test insert columns with the names which are already exists.
+	assert.NoError(t, f.InsertCol("Sheet1", "B"))
+	assert.Equal(t, []string{"Name", "Column3", "Column2", "Price", "Amount"}, tableColumnNames(tableReader()))
+
+	// Test insert a column before the table and rows in the table.
+	assert.NoError(t, f.InsertCol("Sheet1", "A"))
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	table = tableReader()
+	assert.Equal(t, "B1:F4", table.Ref)
+	assert.Len(t, table.TableColumns.TableColumn, 5)
+
+	// Test remove columns in the table.
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "C"))
+	table = tableReader()
+	assert.Equal(t, "B1:D4", table.Ref)
+	assert.Equal(t, []string{"Name", "Price", "Amount"}, tableColumnNames(table))
+
+	// Test remove all columns of the table.
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	}
+	_, ok := f.XLSX["xl/tables/table1.xml"]
+	assert.False(t, ok)
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, xlsx.TableParts)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustTables.xlsx")))
+
+	// Test adjust the table with invalid table XML and range.
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B2", ""))
+	f.XLSX["xl/tables/table1.xml"] = []byte(`<table ref="A:B"></table>`)
+	assert.EqualError(t, f.InsertCol("Sheet1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	f.XLSX["xl/tables/table1.xml"] = []byte(`<table`)
+	assert.EqualError(t, f.InsertCol("Sheet1", "A"), "XML syntax error on line 1: unexpected EOF")
+}
+
+func TestAdjustTablesTotalsRow(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Name", "Amount"}, {"a", 1}, {"b", 2}, {"c", 3}, {"Total"}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(idx+1), &row))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B5", "SUBTOTAL(109,B2:B4)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C5", "SUM(B2:B4)"))
+	assert.NoError(t, f.AddTable("Sheet1", "A1", "B5", `{"table_name":"table"}`))
+	var table xlsxTable
+	assert.NoError(t, xml.Unmarshal(f.XLSX["xl/tables/table1.xml"], &table))
+	table.TotalsRowCount = 1
+	table.AutoFilter.Ref = "A1:B4"
+	table.TableColumns.TableColumn[0].TotalsRowLabel = "Total"
+	table.TableColumns.TableColumn[1].TotalsRowFunction = "custom"
+	table.TableColumns.TableColumn[1].TotalsRowFormula = &xlsxTableFormula{Content: "SUBTOTAL(109,Sheet1!$B$2:$B$4)"}
+	content, err := xml.Marshal(table)
+	assert.NoError(t, err)
+	f.XLSX["xl/tables/table1.xml"] = content
+
+	// Test insert a data row between the data rows and the totals row.
+	assert.NoError(t, f.InsertRow("Sheet1", 5))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{"d", 4}))
+	table = xlsxTable{}
+	assert.NoError(t, xml.Unmarshal(f.XLSX["xl/tables/table1.xml"], &table))
+	assert.Equal(t, "A1:B6", table.Ref)
+	assert.Equal(t, "A1:B5", table.AutoFilter.Ref)
+	assert.Equal(t, "SUBTOTAL(109,Sheet1!$B$2:$B$5)", table.TableColumns.TableColumn[1].TotalsRowFormula.Content)
+	// The formulas out of the table are left unchanged.
+	for cell, expected := range map[string]string{"B6": "SUBTOTAL(109,B2:B5)", "C6": "SUM(B2:B4)"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+
+	// Test insert a data row in the middle of the data rows.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	formula, err := f.GetCellFormula("Sheet1", "B7")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(109,B2:B6)", formula)
+
+	// Test insert a row after the totals row.
+	assert.NoError(t, f.InsertRow("Sheet1", 8))
+	formula, err = f.GetCellFormula("Sheet1", "B7")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(109,B2:B6)", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustTablesTotalsRow.xlsx")))
+}
+
+func TestCellCoordinatesCache(t *testing.T) {
+	var cache cellCoordinatesCache
+	// Test parse the cell names without memoizing by nil cache.
+	coordinates, err := cache.areaRefToCoordinates("C3:A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 3, 3}, coordinates)
+
+	cache = cellCoordinatesCache{}
+	col, row, err := cache.cellNameToCoordinates("B2")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 2}, []int{col, row})
+	assert.Equal(t, [2]int{2, 2}, cache["B2"])
+	_, _, err = cache.cellNameToCoordinates("B")
+	assert.EqualError(t, err, `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	assert.Len(t, cache, 1)
+
+	_, err = cache.areaRefToCoordinates("A1")
+	assert.EqualError(t, err, `invalid area "A1"`)
+	_, err = cache.areaRefToCoordinates("A:B1")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	_, err = cache.areaRefToCoordinates("A1:B")
+	assert.EqualError(t, err, `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+}
+
+func BenchmarkAdjustHelper(b *testing.B) {
+	f := NewFile()
+	row := make([]interface{}, 100)
+	for idx := range row {
+		row[idx] = idx
+	}
+	for r := 2; r <= 1001; r++ {
+		cell := strconv.Itoa(r)
+		if err := f.SetSheetRow("Sheet1", "A"+cell, &row); err != nil {
+			b.Error(err)
+		}
+		if err := f.SetCellHyperLink("Sheet1", "C"+cell, "Sheet1!A1", "Location"); err != nil {
+			b.Error(err)
+		}
+		if r%2 == 0 {
+			if err := f.MergeCell("Sheet1", "A"+cell, "B"+cell); err != nil {
+				b.Error(err)
+			}
+		}
+	}
+	xlsx, err := f.workSheetReader("Sheet1")
+	if err != nil {
+		b.Error(err)
+	}
+	for _, mergeCell := range xlsx.MergeCells.Cells {
+		xlsx.ConditionalFormatting = append(xlsx.ConditionalFormatting, &xlsxConditionalFormatting{SQRef: mergeCell.Ref})
+	}
+	// Compare the adjust passes of the references with and without cache, the
+	// rows are inserted and removed in turn to keep the worksheet unchanged.
+	for _, c := range []struct {
+		name  string
+		cache func() cellCoordinatesCache
+	}{
+		{"WithCache", func() cellCoordinatesCache { return cellCoordinatesCache{} }},
+		{"WithoutCache", func() cellCoordinatesCache { return nil }},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, offset := range []int{1, -1} {
+					cache := c.cache()
+					newAdjustStatsCollector(xlsx, cache, rows, 1)
+					f.adjustHyperlinks(xlsx, cache, "Sheet1", rows, newAdjustMapping(1, offset))
+					if err := f.adjustMergeCells(xlsx, cache, rows, newAdjustMapping(1, offset)); err != nil {
+						b.Error(err)
+					}
+					if err := f.adjustConditionalFormats("Sheet1", xlsx, cache, rows, newAdjustMapping(1, offset)); err != nil {
+						b.Error(err)
+					}
+				}
+			}
+		})
+	}
+	b.Run("InsertRemoveRow", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := f.InsertRow("Sheet1", 1); err != nil {
+				b.Error(err)
+			}
+			if err := f.RemoveRow("Sheet1", 1); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func TestAdjustFilterDatabase(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Bob's Sheet")
+	setFilterDatabase := func(sheetID int, ref string) {
+		wb := f.workbookReader()
+		wb.DefinedNames = &xlsxDefinedNames{DefinedName: []xlsxDefinedName{
+			{Name: "_xlnm._FilterDatabase", LocalSheetID: &sheetID, Hidden: true, Data: ref},
+		}}
+	}
+	getFilterDatabase := func() string {
+		wb := f.workbookReader()
+		if wb.DefinedNames == nil {
+			return ""
+		}
+		return wb.DefinedNames.DefinedName[0].Data
+	}
+
+	// Test adjust the auto filter defined by the defined name only.
+	setFilterDatabase(0, "Sheet1!$A$1:$C$5")
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.Equal(t, "Sheet1!$A$1:$C$6", getFilterDatabase())
+	assert.NoError(t, f.InsertRow("Bob's Sheet", 1))
+	assert.Equal(t, "Sheet1!$A$1:$C$6", getFilterDatabase())
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.Equal(t, "Sheet1!$A$1:$B$6", getFilterDatabase())
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", i))
+		assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	}
+	assert.Equal(t, "", getFilterDatabase())
+
+	// Test keep the defined name in sync with the auto filter.
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Bob's Sheet", "A"+strconv.Itoa(row), &[]interface{}{1, 2, 3}))
+	}
+	assert.NoError(t, f.AutoFilter("Bob's Sheet", "A1", "C5", ""))
+	setFilterDatabase(1, "'Bob''s Sheet'!$A$1:$C$5")
+	assert.NoError(t, f.InsertRow("Bob's Sheet", 1))
+	assert.Equal(t, "'Bob''s Sheet'!$A$2:$C$6", getFilterDatabase())
+	assert.NoError(t, f.RemoveRow("Bob's Sheet", 4))
+	assert.Equal(t, "'Bob''s Sheet'!$A$2:$C$5", getFilterDatabase())
+	// Test remove the defined name by clearing the auto filter.
+	assert.NoError(t, f.RemoveRow("Bob's Sheet", 2))
+	assert.Equal(t, "", getFilterDatabase())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustFilterDatabase.xlsx")))
+
+	assert.Equal(t, "'2019'!$A$1", absoluteAreaReference("2019", "A1"))
+	assert.False(t, f.adjustFilterDatabase("SheetN", &xlsxWorksheet{}, false))
+}
+
+func TestAdjustDrawings(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A30", "A30"))
+	assert.NoError(t, f.AddPicture("Sheet1", "B3", filepath.Join("test", "images", "excel.jpg"), ""))
+	wsDr, _ := f.drawingParser("xl/drawings/drawing1.xml")
+	if !assert.Len(t, wsDr.TwoCellAnchor, 1) {
+		t.FailNow()
+	}
+	anchor := wsDr.TwoCellAnchor[0]
+	from, to := *anchor.From, *anchor.To
+
+	// Test move the two cell anchor.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.Equal(t, xlsxFrom{Col: from.Col, Row: from.Row + 1}, *anchor.From)
+	assert.Equal(t, xlsxTo{Col: to.Col, ColOff: to.ColOff, Row: to.Row + 1, RowOff: to.RowOff}, *anchor.To)
+	// Test shrink the two cell anchor by deleting the ending cell.
+	assert.NoError(t, f.RemoveRow("Sheet1", to.Row+2))
+	assert.Equal(t, xlsxTo{Col: to.Col, ColOff: to.ColOff, Row: to.Row + 1, RowOff: 0}, *anchor.To)
+
+	// Test move the one cell anchor and keep the extent.
+	anchor.To, anchor.Ext = nil, &xlsxExt{Cx: 1000, Cy: 2000}
+	wsDr.OneCellAnchor, wsDr.TwoCellAnchor = []*xdrCellAnchor{anchor}, nil
+	assert.NoError(t, f.InsertCol("Sheet1", "A"))
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.Equal(t, xlsxFrom{Col: from.Col + 1, Row: from.Row + 2}, *anchor.From)
+	assert.Nil(t, anchor.To)
+	assert.Equal(t, xlsxExt{Cx: 1000, Cy: 2000}, *anchor.Ext)
+	// Test remove the one cell anchor by deleting the starting cell.
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.Len(t, wsDr.OneCellAnchor, 1)
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.Len(t, wsDr.OneCellAnchor, 0)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustDrawings.xlsx")))
+
+	// Test adjust the anchors of the existing drawing.
+	f, err := OpenFile(filepath.Join("test", "TestAdjustDrawings.xlsx"))
+	assert.NoError(t, err)
+	f.XLSX["xl/drawings/drawing1.xml"] = []byte(`<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">` +
+		`<xdr:oneCellAnchor><xdr:from><xdr:col>1</xdr:col><xdr:colOff>10</xdr:colOff><xdr:row>2</xdr:row><xdr:rowOff>20</xdr:rowOff></xdr:from><xdr:ext cx="1000" cy="2000"/><xdr:sp/><xdr:clientData/></xdr:oneCellAnchor>` +
+		`<xdr:twoCellAnchor editAs="oneCell"><xdr:from><xdr:col>1</xdr:col><xdr:colOff>10</xdr:colOff><xdr:row>2</xdr:row><xdr:rowOff>20</xdr:rowOff></xdr:from><xdr:to><xdr:col>3</xdr:col><xdr:colOff>30</xdr:colOff><xdr:row>5</xdr:row><xdr:rowOff>40</xdr:rowOff></xdr:to><xdr:sp/><xdr:clientData/></xdr:twoCellAnchor>` +
+		`<xdr:twoCellAnchor editAs="absolute"><xdr:from><xdr:col>1</xdr:col><xdr:colOff>10</xdr:colOff><xdr:row>2</xdr:row><xdr:rowOff>20</xdr:rowOff></xdr:from><xdr:to><xdr:col>3</xdr:col><xdr:colOff>30</xdr:colOff><xdr:row>5</xdr:row><xdr:rowOff>40</xdr:rowOff></xdr:to><xdr:sp/><xdr:clientData/></xdr:twoCellAnchor>` +
+		`</xdr:wsDr>`)
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	wsDr, _ = f.drawingParser("xl/drawings/drawing1.xml")
+	if assert.Len(t, wsDr.OneCellAnchor, 1) && assert.Len(t, wsDr.TwoCellAnchor, 2) {
+		assert.Contains(t, wsDr.OneCellAnchor[0].GraphicFrame, `<xdr:from><xdr:col>1</xdr:col><xdr:colOff>10</xdr:colOff><xdr:row>3</xdr:row><xdr:rowOff>20</xdr:rowOff></xdr:from><xdr:ext cx="1000" cy="2000"/>`)
+		assert.Contains(t, wsDr.TwoCellAnchor[0].GraphicFrame, `<xdr:to><xdr:col>3</xdr:col><xdr:colOff>30</xdr:colOff><xdr:row>6</xdr:row><xdr:rowOff>40</xdr:rowOff></xdr:to>`)
+		assert.Contains(t, wsDr.TwoCellAnchor[1].GraphicFrame, `<xdr:from><xdr:col>1</xdr:col><xdr:colOff>10</xdr:colOff><xdr:row>2</xdr:row><xdr:rowOff>20</xdr:rowOff></xdr:from>`)
+	}
+	// Test move the ending anchor of the two cell anchor which edited as one
+	// cell by deleting the rows inside of the anchor.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A8", "A8"))
+	assert.NoError(t, f.RemoveRow("Sheet1", 6))
+	assert.Contains(t, wsDr.TwoCellAnchor[0].GraphicFrame, `<xdr:to><xdr:col>3</xdr:col><xdr:colOff>30</xdr:colOff><xdr:row>6</xdr:row><xdr:rowOff>40</xdr:rowOff></xdr:to>`)
+	assert.NoError(t, f.RemoveRow("Sheet1", 4))
+	assert.Len(t, wsDr.OneCellAnchor, 0)
+	assert.Len(t, wsDr.TwoCellAnchor, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustDrawings.xlsx")))
+}
+
+func TestAdjustSafeMode(t *testing.T) {
+	prepare := func() (*File, *xlsxWorksheet) {
+		f := NewFile()
+		for cell, value := range map[string]string{"A1": "A1", "A3": "A3", "C3": "C3"} {
+			assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+		}
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "SUM(A3:A4)"))
+		assert.NoError(t, f.MergeCell("Sheet1", "C3", "D4"))
+		xlsx, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		// Make the worksheet broken to trigger a panic on adjusting the
+		// merged cells.
+		xlsx.MergeCells.Cells = append(xlsx.MergeCells.Cells, nil)
+		return f, xlsx
+	}
+
+	// Test panic without the SafeMode option.
+	f, _ := prepare()
+	assert.Panics(t, func() { _ = f.InsertRow("Sheet1", 2) })
+
+	f, xlsx := prepare()
+	f.SetAdjustOptions(SafeMode(true))
+	var safeMode SafeMode
+	f.GetAdjustOptions(&safeMode)
+	assert.True(t, bool(safeMode))
+	expected, err := xml.Marshal(xlsx)
+	assert.NoError(t, err)
+	for _, fn := range []func() error{
+		func() error { return f.InsertRow("Sheet1", 2) },
+		func() error { return f.RemoveRow("Sheet1", 2) },
+		func() error { return f.RemoveRowsByIndex("Sheet1", []int{1, 2}) },
+		func() error { return f.DuplicateRowTo("Sheet1", 1, 2) },
+		func() error { return f.InsertCol("Sheet1", "B") },
+		func() error { return f.RemoveCol("Sheet1", "B") },
+	} {
+		err := fn()
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "recovered from panic")
+		}
+		xlsx, err = f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		actual, err := xml.Marshal(xlsx)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual))
+	}
+
+	// Test insert row with the SafeMode option after repairing the worksheet.
+	xlsx.MergeCells.Cells = xlsx.MergeCells.Cells[:1]
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(A4:A5)", formula)
+	value, err := f.GetCellValue("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "A3", value)
+
+	// Test roll back the relationships of the worksheet on failure.
+	f = NewFile()
+	f.SetAdjustOptions(SafeMode(true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "A3"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.ConditionalFormatting = []*xlsxConditionalFormatting{{SQRef: "A"}}
+	assert.Error(t, f.RemoveRow("Sheet1", 2))
+	link, target, err := f.GetCellHyperLink("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/360EntSecGroup-Skylar/excelize", target)
+	rels := f.workSheetRelsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	if assert.Len(t, rels.Relationships, 1) {
+		assert.Equal(t, "rId1", rels.Relationships[0].ID)
+	}
+}
+
+func TestAdjustHyperlinkLocations(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellValue("Sheet1", "A10", "target"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "Sheet1!A10", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "A10", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet2", "A1", "'Sheet1'!A10:B10", "Location"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet2", "B1", "A10", "Location"))
+
+	assert.NoError(t, f.InsertRow("Sheet1", 5))
+	for _, c := range []struct{ sheet, cell, expected string }{
+		{"Sheet1", "A1", "Sheet1!A11"},
+		{"Sheet1", "B1", "A11"},
+		{"Sheet2", "A1", "'Sheet1'!A11:B11"},
+		{"Sheet2", "B1", "A10"},
+	} {
+		link, target, err := f.GetCellHyperLink(c.sheet, c.cell)
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, c.expected, target, c.sheet+"!"+c.cell)
+	}
+
+	// Test the location to the deleted cell.
+	assert.NoError(t, f.RemoveRow("Sheet1", 11))
+	_, target, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!#REF!", target)
+}
+
+func TestShiftHyperlinks(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		assert.NoError(t, f.SetCellValue("Sheet1", "E5", "E5"))
+		for _, cell := range []string{"A1", "B2", "B3", "C4", "D5"} {
+			assert.NoError(t, f.SetCellHyperLink("Sheet1", cell, "https://github.com/"+cell, "External"))
+		}
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3", "Sheet1!A1", "Location"))
+		return f
+	}
+	hyperlinks := func(f *File) ([]xlsxHyperlink, []xlsxWorkbookRelation) {
+		xlsx, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		rels := f.workSheetRelsReader("xl/worksheets/_rels/sheet1.xml.rels")
+		if xlsx.Hyperlinks == nil {
+			return nil, rels.Relationships
+		}
+		return xlsx.Hyperlinks.Hyperlink, rels.Relationships
+	}
+
+	for _, c := range []struct {
+		shift       ShiftDirection
+		num, offset int
+		adjust      func(f *File) error
+	}{
+		{ShiftCellsDown, 2, 2, func(f *File) error {
+			if err := f.InsertRow("Sheet1", 2); err != nil {
+				return err
+			}
+			return f.InsertRow("Sheet1", 2)
+		}},
+		{ShiftCellsDown, 3, -2, func(f *File) error { return f.RemoveRowsByIndex("Sheet1", []int{3, 4}) }},
+		{ShiftCellsRight, 3, 1, func(f *File) error { return f.InsertCol("Sheet1", "C") }},
+		{ShiftCellsRight, 2, -1, func(f *File) error { return f.RemoveCol("Sheet1", "B") }},
+	} {
+		expected := prepare()
+		assert.NoError(t, c.adjust(expected))
+		expectedLinks, expectedRels := hyperlinks(expected)
+
+		f := prepare()
+		assert.NoError(t, f.ShiftHyperlinks("Sheet1", c.shift, c.num, c.offset))
+		links, rels := hyperlinks(f)
+		assert.Equal(t, expectedLinks, links)
+		assert.Equal(t, expectedRels, rels)
+
+		// Test the cells are left unchanged.
+		value, err := f.GetCellValue("Sheet1", "E5")
+		assert.NoError(t, err)
+		assert.Equal(t, "E5", value)
+	}
+
+	// Test remove all hyperlinks with relationships.
+	f := prepare()
+	assert.NoError(t, f.ShiftHyperlinks("Sheet1", ShiftCellsDown, 1, -5))
+	links, rels := hyperlinks(f)
+	assert.Empty(t, links)
+	assert.Empty(t, rels)
+
+	// Test shift hyperlinks with invalid arguments.
+	assert.EqualError(t, f.ShiftHyperlinks("Sheet1", ShiftDirection(2), 1, 1), "invalid shift direction 2")
+	assert.EqualError(t, f.ShiftHyperlinks("Sheet1", ShiftCellsDown, 0, 1), "invalid row number 0")
+	assert.EqualError(t, f.ShiftHyperlinks("Sheet1", ShiftCellsRight, 0, 1), "incorrect column number 0")
+	assert.EqualError(t, f.ShiftHyperlinks("SheetN", ShiftCellsDown, 1, 1), "sheet SheetN is not exist")
+}
+
+func TestAdjustCachedErrorValue(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B2", "A1/0"))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.SheetData.Row[1].C[1].T, xlsx.SheetData.Row[1].C[1].V = "e", "#DIV/0!"
+
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustCachedErrorValue.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestAdjustCachedErrorValue.xlsx"))
+	assert.NoError(t, err)
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	cell := xlsx.SheetData.Row[2].C[1]
+	assert.Equal(t, "B3", cell.R)
+	assert.Equal(t, "e", cell.T)
+	assert.Equal(t, "#DIV/0!", cell.V)
+	assert.Equal(t, "A2/0", cell.F.Content)
+	value, err := f.GetCellValue("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "#DIV/0!", value)
+}
+
+func TestAdjustPhoneticRuns(t *testing.T) {
+	f := NewFile()
+	f.XLSX["xl/sharedStrings.xml"] = []byte(`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="1" uniqueCount="1"><si><t>東京</t><rPh sb="0" eb="1"><t>トウ</t></rPh><rPh sb="1" eb="2"><t>キョウ</t></rPh><phoneticPr fontId="1"/></si></sst>`)
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	prepareSheetXML(xlsx, 1, 1)
+	prepareSheetXML(xlsx, 1, 2)
+	xlsx.SheetData.Row[0].C[0] = xlsxC{R: "A1", T: "s", V: "0", Ph: true}
+	xlsx.SheetData.Row[1].C[0] = xlsxC{R: "A2", T: "inlineStr", Ph: true, IS: &xlsxIS{
+		T:          "大阪",
+		RPh:        []*xlsxPhoneticRun{{Sb: 0, Eb: 2, T: "オオサカ"}},
+		PhoneticPr: &xlsxPhoneticPr{Type: "Hiragana"},
+	}}
+
+	// Test the phonetic runs are kept at the new cells after inserting a row.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustPhoneticRuns.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestAdjustPhoneticRuns.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A2": "東京", "A3": "大阪"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, xlsxC{R: "A2", T: "s", V: "0", Ph: true}, xlsx.SheetData.Row[1].C[0])
+	cell := xlsx.SheetData.Row[2].C[0]
+	assert.Equal(t, "A3", cell.R)
+	assert.True(t, cell.Ph)
+	if assert.NotNil(t, cell.IS) && assert.Len(t, cell.IS.RPh, 1) {
+		assert.Equal(t, xlsxPhoneticRun{Sb: 0, Eb: 2, T: "オオサカ"}, *cell.IS.RPh[0])
+		assert.Equal(t, "Hiragana", cell.IS.PhoneticPr.Type)
+	}
+	si := f.sharedStringsReader().SI[0]
+	if assert.Len(t, si.RPh, 2) {
+		assert.Equal(t, xlsxPhoneticRun{Sb: 1, Eb: 2, T: "キョウ"}, *si.RPh[1])
+		assert.Equal(t, 1, *si.PhoneticPr.FontID)
+	}
+}
+
+func TestWillAdjust(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{row, row, row}))
+	}
+	// Test inserting or deleting beyond the used range.
+	assert.False(t, f.WillAdjust("Sheet1", AdjustRows, 6, 1))
+	assert.False(t, f.WillAdjust("Sheet1", AdjustRows, 10, -2))
+	assert.False(t, f.WillAdjust("Sheet1", AdjustColumns, 4, 1))
+	assert.True(t, f.WillAdjust("Sheet1", AdjustRows, 5, 1))
+	assert.True(t, f.WillAdjust("Sheet1", AdjustRows, 1, -1))
+	assert.True(t, f.WillAdjust("Sheet1", AdjustColumns, 3, 1))
+	assert.False(t, f.WillAdjust("Sheet1", AdjustRows, 1, 0))
+	assert.False(t, f.WillAdjust("Sheet1", AdjustRows, 0, 1))
+	assert.False(t, f.WillAdjust("SheetN", AdjustRows, 1, 1))
+
+	// Test the inserting beyond the used range leaves the worksheet unchanged.
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	before, err := xml.Marshal(xlsx)
+	assert.NoError(t, err)
+	assert.NoError(t, f.InsertRow("Sheet1", 6))
+	after, err := xml.Marshal(xlsx)
+	assert.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+
+	// Test the structures of the worksheet beyond the cells are affected.
+	assert.NoError(t, f.MergeCell("Sheet1", "A8", "B9"))
+	assert.True(t, f.WillAdjust("Sheet1", rows, 6, 1))
+	assert.False(t, f.WillAdjust("Sheet1", rows, 10, 1))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "E1:E2", `[{"type":"cell","criteria":">","format":0,"value":"1"}]`))
+	assert.True(t, f.WillAdjust("Sheet1", columns, 4, 1))
+	assert.False(t, f.WillAdjust("Sheet1", columns, 6, 1))
+
+	// Test the references to the worksheet in the other worksheets and the
+	// defined names are affected.
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "SUM(Sheet1!G1:G20)"))
+	assert.True(t, f.WillAdjust("Sheet1", columns, 6, 1))
+	assert.True(t, f.WillAdjust("Sheet1", rows, 15, -1))
+	assert.False(t, f.WillAdjust("Sheet1", rows, 21, 1))
+	assert.False(t, f.WillAdjust("Sheet2", columns, 2, 1))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "total", RefersTo: "Sheet1!$A$30"}))
+	assert.True(t, f.WillAdjust("Sheet1", rows, 21, 1))
+	assert.False(t, f.WillAdjust("Sheet1", rows, 31, 1))
+}
+
+func TestWillAdjustUnchanged(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	f.NewSheet("Sheet3")
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet2", "A"+strconv.Itoa(row), &[]interface{}{row, row}))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet3", "A1", "SUM(Sheet1!A1:A5)"))
+	assert.NoError(t, f.AddComment("Sheet2", "C3", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddChart("Sheet2", "E1", `{"type":"col","series":[{"name":"Sheet2!$A$1","categories":"Sheet2!$A$2:$A$5","values":"Sheet2!$B$2:$B$5"}]}`))
+	f.XLSX["xl/drawings/vmlDrawing3.vml"] = []byte(fmt.Sprintf(formControlsVML, "Sheet1!$B$2"))
+	xlsx, err := f.workSheetReader("Sheet3")
+	assert.NoError(t, err)
+	xlsx.LegacyDrawing = &xlsxLegacyDrawing{RID: "rId" + strconv.Itoa(f.addSheetRelationships("Sheet3", SourceRelationshipDrawingVML, "../drawings/vmlDrawing3.vml", ""))}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWillAdjustUnchanged.xlsx")))
+
+	write := func(check func(f *File)) map[string][]byte {
+		f, err := OpenFile(filepath.Join("test", "TestWillAdjustUnchanged.xlsx"))
+		assert.NoError(t, err)
+		check(f)
+		_, err = f.WriteToBuffer()
+		assert.NoError(t, err)
+		return f.XLSX
+	}
+	expected := write(func(f *File) {})
+	// Test checking the adjusting leaves all parts of the workbook unchanged.
+	actual := write(func(f *File) {
+		assert.False(t, f.WillAdjust("Sheet1", rows, 10, 1))
+		assert.True(t, f.WillAdjust("Sheet1", rows, 1, 1))
+		assert.True(t, f.WillAdjust("Sheet1", columns, 2, -1))
+		assert.True(t, f.WillAdjust("Sheet2", rows, 4, 1))
+		assert.True(t, f.WillAdjust("Sheet2", columns, 3, 1))
+		assert.False(t, f.WillAdjust("Sheet2", columns, 40, 1))
+		assert.False(t, f.WillAdjust("Sheet3", rows, 2, 1))
+		assert.Nil(t, f.VMLDrawing["xl/drawings/vmlDrawing3.vml"])
+		assert.Empty(t, f.Drawings)
+		assert.Empty(t, f.Comments)
+	})
+	if assert.Equal(t, len(expected), len(actual)) {
+		for path, content := range expected {
+			assert.Equal(t, string(content), string(actual[path]), path)
+		}
+	}
+}
+
+func TestApplyAcrossSheets(t *testing.T) {
+	f := NewFile()
+	sheets := []string{"Sheet1", "Sheet2", "Sheet3"}
+	for i, sheet := range sheets {
+		f.NewSheet(sheet)
+		for row := 1; row <= i+3; row++ {
+			assert.NoError(t, f.SetCellValue(sheet, "A"+strconv.Itoa(row), sheet+strconv.Itoa(row)))
+		}
+	}
+	assert.NoError(t, f.MergeCell("Sheet2", "B4", "C5"))
+	rows := func(sheet string) []string {
+		rows, err := f.GetRows(sheet)
+		assert.NoError(t, err)
+		var values []string
+		for _, row := range rows {
+			values = append(values, row[0])
+		}
+		return values
+	}
+
+	// Test delete the row in each worksheet independently.
+	assert.NoError(t, f.ApplyAcrossSheets(sheets, RemoveRowOp(3)))
+	assert.Equal(t, []string{"Sheet11", "Sheet12"}, rows("Sheet1"))
+	assert.Equal(t, []string{"Sheet21", "Sheet22", "Sheet24"}, rows("Sheet2"))
+	assert.Equal(t, []string{"Sheet31", "Sheet32", "Sheet34", "Sheet35"}, rows("Sheet3"))
+	mergeCells, err := f.GetMergeCells("Sheet2")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 1) {
+		assert.Equal(t, "B3:C4", mergeCells[0][0])
+	}
+
+	// Test insert and delete the rows and the columns.
+	assert.NoError(t, f.ApplyAcrossSheets(sheets, InsertRowOp(1)))
+	assert.NoError(t, f.ApplyAcrossSheets(sheets, InsertColOp("A")))
+	for _, sheet := range sheets {
+		value, err := f.GetCellValue(sheet, "B2")
+		assert.NoError(t, err)
+		assert.Equal(t, sheet+"1", value)
+	}
+	assert.NoError(t, f.ApplyAcrossSheets(sheets[1:], RemoveColOp("A")))
+	value, err := f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet11", value)
+	value, err = f.GetCellValue("Sheet2", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet21", value)
+
+	// Test stop at the failed worksheet.
+	assert.EqualError(t, f.ApplyAcrossSheets([]string{"Sheet1", "SheetN", "Sheet3"}, RemoveRowOp(1)), "sheet SheetN: sheet SheetN is not exist")
+	value, err = f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet11", value)
+	value, err = f.GetCellValue("Sheet3", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet31", value)
+}
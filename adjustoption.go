@@ -0,0 +1,291 @@
+// Copyright 2016 - 2019 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX files. Support reads and writes XLSX file generated by
+// Microsoft Excel™ 2007 and later. Support save file without losing original
+// charts of XLSX. This library needs Go version 1.8 or later.
+
+package excelize
+
+import (
+	"fmt"
+
+	"github.com/mohae/deepcopy"
+)
+
+// adjustOptions directly maps the settings of adjusting the worksheets when
+// inserting or deleting rows or columns.
+type adjustOptions struct {
+	mergeAdjacentOnDelete bool
+	fullCalcOnLoad        bool
+	collapsePolicy        CollapsePolicy
+	safeMode              bool
+	splitMergesOnInsert   bool
+	clearChartCaches      bool
+	refreshPivotCaches    bool
+}
+
+// AdjustOption is an option of adjusting the worksheets when inserting or
+// deleting rows or columns. See SetAdjustOptions().
+type AdjustOption interface {
+	setAdjustOption(opts *adjustOptions)
+}
+
+// AdjustOptionPtr is a writable AdjustOption. See GetAdjustOptions().
+type AdjustOptionPtr interface {
+	AdjustOption
+	getAdjustOption(opts *adjustOptions)
+}
+
+type (
+	// MergeAdjacentOnDelete is an AdjustOption, specifies whether to union
+	// two merged cells with the same span into a single merged cell when
+	// deleting rows or columns between them makes them adjacent.
+	MergeAdjacentOnDelete bool
+	// FullCalcOnLoad is an AdjustOption, specifies whether to make the
+	// spreadsheet application recalculate the workbook when it is opened
+	// after the formulas are changed by inserting or deleting rows or
+	// columns, because the cached values of the formulas may be stale.
+	FullCalcOnLoad bool
+	// CollapsePolicy is an AdjustOption, specifies how to handle the merged
+	// cells which collapse to a single cell by deleting rows or columns.
+	CollapsePolicy int
+	// SafeMode is an AdjustOption, specifies whether to recover from the
+	// panics when inserting or deleting rows or columns. The panic is
+	// returned as an error, and the workbook is rolled back to the state
+	// before the operation.
+	SafeMode bool
+	// SplitMergesOnInsert is an AdjustOption, specifies whether to split the
+	// merged cells straddling the inserted rows or columns into two merged
+	// cells before and after them, instead of growing the merged cells.
+	SplitMergesOnInsert bool
+	// ClearChartCaches is an AdjustOption, specifies whether to remove the
+	// cached values of the chart series whose references are changed by
+	// inserting or deleting rows or columns, so that the spreadsheet
+	// application reads the values from the cells instead of showing the
+	// stale cached values.
+	ClearChartCaches bool
+	// RefreshPivotCaches is an AdjustOption, specifies whether to mark the
+	// pivot caches to be refreshed when the workbook is opened if their
+	// source ranges are changed by inserting or deleting rows or columns,
+	// because the cached records of the pivot tables may be stale.
+	RefreshPivotCaches bool
+)
+
+// Collapse policies of the merged cells.
+const (
+	// CollapsePolicyRemove removes the merged cells collapsed to a single
+	// cell.
+	CollapsePolicyRemove CollapsePolicy = iota
+	// CollapsePolicyKeepSingle keeps the merged cells collapsed to a single
+	// cell as a single cell merged cells.
+	CollapsePolicyKeepSingle
+)
+
+// setAdjustOption implements the AdjustOption interface.
+func (o MergeAdjacentOnDelete) setAdjustOption(opts *adjustOptions) {
+	opts.mergeAdjacentOnDelete = bool(o)
+}
+
+// getAdjustOption implements the AdjustOptionPtr interface.
+func (o *MergeAdjacentOnDelete) getAdjustOption(opts *adjustOptions) {
+	// Default: false
+	*o = MergeAdjacentOnDelete(opts.mergeAdjacentOnDelete)
+}
+
+// setAdjustOption implements the AdjustOption interface.
+func (o FullCalcOnLoad) setAdjustOption(opts *adjustOptions) {
+	opts.fullCalcOnLoad = bool(o)
+}
+
+// getAdjustOption implements the AdjustOptionPtr interface.
+func (o *FullCalcOnLoad) getAdjustOption(opts *adjustOptions) {
+	// Default: false
+	*o = FullCalcOnLoad(opts.fullCalcOnLoad)
+}
+
+// setAdjustOption implements the AdjustOption interface.
+func (o CollapsePolicy) setAdjustOption(opts *adjustOptions) {
+	opts.collapsePolicy = o
+}
+
+// getAdjustOption implements the AdjustOptionPtr interface.
+func (o *CollapsePolicy) getAdjustOption(opts *adjustOptions) {
+	// Default: CollapsePolicyRemove
+	*o = opts.collapsePolicy
+}
+
+// setAdjustOption implements the AdjustOption interface.
+func (o SafeMode) setAdjustOption(opts *adjustOptions) {
+	opts.safeMode = bool(o)
+}
+
+// getAdjustOption implements the AdjustOptionPtr interface.
+func (o *SafeMode) getAdjustOption(opts *adjustOptions) {
+	// Default: false
+	*o = SafeMode(opts.safeMode)
+}
+
+// setAdjustOption implements the AdjustOption interface.
+func (o SplitMergesOnInsert) setAdjustOption(opts *adjustOptions) {
+	opts.splitMergesOnInsert = bool(o)
+}
+
+// getAdjustOption implements the AdjustOptionPtr interface.
+func (o *SplitMergesOnInsert) getAdjustOption(opts *adjustOptions) {
+	// Default: false
+	*o = SplitMergesOnInsert(opts.splitMergesOnInsert)
+}
+
+// setAdjustOption implements the AdjustOption interface.
+func (o ClearChartCaches) setAdjustOption(opts *adjustOptions) {
+	opts.clearChartCaches = bool(o)
+}
+
+// getAdjustOption implements the AdjustOptionPtr interface.
+func (o *ClearChartCaches) getAdjustOption(opts *adjustOptions) {
+	// Default: false
+	*o = ClearChartCaches(opts.clearChartCaches)
+}
+
+// setAdjustOption implements the AdjustOption interface.
+func (o RefreshPivotCaches) setAdjustOption(opts *adjustOptions) {
+	opts.refreshPivotCaches = bool(o)
+}
+
+// getAdjustOption implements the AdjustOptionPtr interface.
+func (o *RefreshPivotCaches) getAdjustOption(opts *adjustOptions) {
+	// Default: false
+	*o = RefreshPivotCaches(opts.refreshPivotCaches)
+}
+
+// adjustSnapshot directly maps the parts of the workbook which may be changed
+// by inserting or deleting rows or columns.
+type adjustSnapshot struct {
+	adjustStats      map[string]*AdjustStats
+	calcChain        *xlsxCalcChain
+	comments         map[string]*xlsxComments
+	contentTypes     *xlsxTypes
+	decodeVMLDrawing map[string]*decodeVmlDrawing
+	drawingRels      map[string]*xlsxWorkbookRels
+	drawings         map[string]*xlsxWsDr
+	sheet            map[string]*xlsxWorksheet
+	threadedComments map[string]*xlsxThreadedComments
+	vmlDrawing       map[string]*vmlDrawing
+	workBook         *xlsxWorkbook
+	workBookRels     *xlsxWorkbookRels
+	workSheetRels    map[string]*xlsxWorkbookRels
+	xlsx             map[string][]byte
+}
+
+// takeAdjustSnapshot provides a function to copy the parts of the workbook
+// which may be changed by inserting or deleting rows or columns if the
+// SafeMode option is set, otherwise it returns nil.
+func (f *File) takeAdjustSnapshot() *adjustSnapshot {
+	if !f.adjustOptions.safeMode {
+		return nil
+	}
+	xlsx := make(map[string][]byte, len(f.XLSX))
+	for k, v := range f.XLSX {
+		xlsx[k] = v
+	}
+	return &adjustSnapshot{
+		adjustStats:      deepcopy.Copy(f.adjustStats).(map[string]*AdjustStats),
+		calcChain:        deepcopy.Copy(f.CalcChain).(*xlsxCalcChain),
+		comments:         deepcopy.Copy(f.Comments).(map[string]*xlsxComments),
+		contentTypes:     deepcopy.Copy(f.ContentTypes).(*xlsxTypes),
+		decodeVMLDrawing: deepcopy.Copy(f.DecodeVMLDrawing).(map[string]*decodeVmlDrawing),
+		drawingRels:      deepcopy.Copy(f.DrawingRels).(map[string]*xlsxWorkbookRels),
+		drawings:         deepcopy.Copy(f.Drawings).(map[string]*xlsxWsDr),
+		sheet:            deepcopy.Copy(f.Sheet).(map[string]*xlsxWorksheet),
+		threadedComments: deepcopy.Copy(f.ThreadedComments).(map[string]*xlsxThreadedComments),
+		vmlDrawing:       deepcopy.Copy(f.VMLDrawing).(map[string]*vmlDrawing),
+		workBook:         deepcopy.Copy(f.WorkBook).(*xlsxWorkbook),
+		workBookRels:     deepcopy.Copy(f.WorkBookRels).(*xlsxWorkbookRels),
+		workSheetRels:    deepcopy.Copy(f.WorkSheetRels).(map[string]*xlsxWorkbookRels),
+		xlsx:             xlsx,
+	}
+}
+
+// recoverAdjust provides a function to recover from the panic of inserting or
+// deleting rows or columns, and roll back the workbook to the given snapshot
+// when the operation panics or fails. It must be deferred directly, and does
+// nothing if the snapshot is nil.
+func (f *File) recoverAdjust(snapshot *adjustSnapshot, err *error) {
+	if snapshot == nil {
+		return
+	}
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("recovered from panic: %v", r)
+	}
+	if *err == nil {
+		return
+	}
+	f.adjustStats = snapshot.adjustStats
+	f.CalcChain = snapshot.calcChain
+	f.Comments = snapshot.comments
+	f.ContentTypes = snapshot.contentTypes
+	f.DecodeVMLDrawing = snapshot.decodeVMLDrawing
+	f.DrawingRels = snapshot.drawingRels
+	f.Drawings = snapshot.drawings
+	f.Sheet = snapshot.sheet
+	f.ThreadedComments = snapshot.threadedComments
+	f.VMLDrawing = snapshot.vmlDrawing
+	f.WorkBook = snapshot.workBook
+	f.WorkBookRels = snapshot.workBookRels
+	f.WorkSheetRels = snapshot.workSheetRels
+	f.XLSX = snapshot.xlsx
+}
+
+// setFullCalcOnLoad provides a function to mark the workbook to be fully
+// calculated when it is opened if the FullCalcOnLoad option is set.
+func (f *File) setFullCalcOnLoad() {
+	if !f.adjustOptions.fullCalcOnLoad {
+		return
+	}
+	wb := f.workbookReader()
+	if wb.CalcPr == nil {
+		wb.CalcPr = &xlsxCalcPr{}
+	}
+	wb.CalcPr.FullCalcOnLoad = true
+}
+
+// SetAdjustOptions provides a function to set the options of adjusting the
+// worksheets when inserting or deleting rows or columns. For example, union
+// the merged cells A1:B2 and D1:E2 into A1:D2 when deleting the column C:
+//
+//    f.SetAdjustOptions(excelize.MergeAdjacentOnDelete(true))
+//    err := f.RemoveCol("Sheet1", "C")
+//
+// Available options:
+//   MergeAdjacentOnDelete(bool)
+//   FullCalcOnLoad(bool)
+//   CollapsePolicy(int)
+//   SafeMode(bool)
+//   SplitMergesOnInsert(bool)
+//   ClearChartCaches(bool)
+//   RefreshPivotCaches(bool)
+func (f *File) SetAdjustOptions(opts ...AdjustOption) {
+	for _, opt := range opts {
+		opt.setAdjustOption(&f.adjustOptions)
+	}
+}
+
+// GetAdjustOptions provides a function to get the options of adjusting the
+// worksheets when inserting or deleting rows or columns.
+//
+// Available options:
+//   MergeAdjacentOnDelete(bool)
+//   FullCalcOnLoad(bool)
+//   CollapsePolicy(int)
+//   SafeMode(bool)
+//   SplitMergesOnInsert(bool)
+//   ClearChartCaches(bool)
+//   RefreshPivotCaches(bool)
+func (f *File) GetAdjustOptions(opts ...AdjustOptionPtr) {
+	for _, opt := range opts {
+		opt.getAdjustOption(&f.adjustOptions)
+	}
+}
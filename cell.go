@@ -13,6 +13,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -44,6 +45,161 @@ func (f *File) GetCellValue(sheet, axis string) (string, error) {
 	})
 }
 
+// GetCellValues provides a function to get the formatted values of the given
+// cells by given worksheet name, the values are returned in the same order as
+// the cells. It reads the worksheet in a single pass and is faster than
+// calling GetCellValue for each cell. For example, get the values of the
+// cells A1, C3 and B10 on Sheet1:
+//
+//    values, err := f.GetCellValues("Sheet1", []string{"A1", "C3", "B10"})
+//
+func (f *File) GetCellValues(sheet string, cells []string) ([]string, error) {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	rows := make(map[int]*xlsxRow, len(xlsx.SheetData.Row))
+	for rowIdx := range xlsx.SheetData.Row {
+		rows[xlsx.SheetData.Row[rowIdx].R] = &xlsx.SheetData.Row[rowIdx]
+	}
+	sst := f.sharedStringsReader()
+	values := make([]string, len(cells))
+	for i, cell := range cells {
+		axis, err := f.mergeCellsParser(xlsx, cell)
+		if err != nil {
+			return nil, err
+		}
+		col, row, err := CellNameToCoordinates(axis)
+		if err != nil {
+			return nil, err
+		}
+		rowData, ok := rows[row]
+		if !ok {
+			continue
+		}
+		var c *xlsxC
+		if col <= len(rowData.C) && rowData.C[col-1].R == axis {
+			c = &rowData.C[col-1]
+		} else {
+			for colIdx := range rowData.C {
+				if rowData.C[colIdx].R == axis {
+					c = &rowData.C[colIdx]
+				}
+			}
+		}
+		if c == nil {
+			continue
+		}
+		if values[i], err = c.getValueFrom(f, sst); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// CellType is the type of the cell value.
+type CellType byte
+
+// Cell value types enumeration.
+const (
+	CellTypeBlank CellType = iota
+	CellTypeBool
+	CellTypeDate
+	CellTypeError
+	CellTypeNumber
+	CellTypeString
+)
+
+// GetCellType provides a function to get the type of the cell value by given
+// worksheet name and axis in XLSX file. The numeric values with a date or
+// time number format are reported as CellTypeDate. For example, get the type
+// of the value of cell A1 on Sheet1:
+//
+//    cellType, err := f.GetCellType("Sheet1", "A1")
+//
+func (f *File) GetCellType(sheet, axis string) (CellType, error) {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return CellTypeBlank, err
+	}
+	axis, err = f.mergeCellsParser(xlsx, axis)
+	if err != nil {
+		return CellTypeBlank, err
+	}
+	if _, _, err = CellNameToCoordinates(axis); err != nil {
+		return CellTypeBlank, err
+	}
+	for rowIdx := range xlsx.SheetData.Row {
+		for _, c := range xlsx.SheetData.Row[rowIdx].C {
+			if c.R == axis {
+				return f.cellType(&c), err
+			}
+		}
+	}
+	return CellTypeBlank, err
+}
+
+// cellType provides a function to get the type of the cell value by the type
+// attribute and the number format of the cell.
+func (f *File) cellType(c *xlsxC) CellType {
+	switch c.T {
+	case "b":
+		return CellTypeBool
+	case "d":
+		return CellTypeDate
+	case "e":
+		return CellTypeError
+	case "s", "str", "inlineStr":
+		return CellTypeString
+	}
+	if c.V == "" && c.F == nil {
+		return CellTypeBlank
+	}
+	if f.isDateNumFmt(c.S) {
+		return CellTypeDate
+	}
+	return CellTypeNumber
+}
+
+// isDateNumFmt provides a function to check if the number format of the
+// given style index is a date or time format, the literal strings, the
+// escaped and the bracketed parts of the format code are skipped.
+func (f *File) isDateNumFmt(s int) bool {
+	styleSheet := f.stylesReader()
+	if styleSheet.CellXfs == nil || s <= 0 || s >= len(styleSheet.CellXfs.Xf) {
+		return false
+	}
+	numFmtID := styleSheet.CellXfs.Xf[s].NumFmtID
+	code, ok := builtInNumFmt[numFmtID]
+	if !ok && styleSheet.NumFmts != nil {
+		for _, numFmt := range styleSheet.NumFmts.NumFmt {
+			if numFmt.NumFmtID == numFmtID {
+				code = numFmt.FormatCode
+			}
+		}
+	}
+	var quoted, bracketed, skip bool
+	for _, r := range strings.ToLower(code) {
+		switch {
+		case skip:
+			skip = false
+		case quoted:
+			quoted = r != '"'
+		case bracketed:
+			bracketed = r != ']'
+		case r == '"':
+			quoted = true
+		case r == '[':
+			bracketed = true
+		case r == '\\' || r == '_' || r == '*':
+			skip = true
+		case strings.ContainsRune("ymdhs", r):
+			return true
+		}
+	}
+	return false
+}
+
 // SetCellValue provides a function to set value of a cell. The following
 // shows the supported data types:
 //
@@ -273,14 +429,30 @@ func (f *File) GetCellFormula(sheet, axis string) (string, error) {
 	})
 }
 
+// FormulaOpts can be passed to SetCellFormula to use other formula types.
+// The Type is either STCellFormulaTypeArray or STCellFormulaTypeShared, and
+// the Ref is the range of cells which the formula applies to, it must start
+// with the cell of the formula.
+type FormulaOpts struct {
+	Type string
+	Ref  string
+}
+
 // SetCellFormula provides a function to set cell formula by given string and
-// worksheet name.
-func (f *File) SetCellFormula(sheet, axis, formula string) error {
+// worksheet name. For example, set an array formula and a shared formula on
+// the range A1:A3 and B1:B3 of Sheet1:
+//
+//    err := f.SetCellFormula("Sheet1", "A1", "ROW(C1:C3)",
+//        excelize.FormulaOpts{Type: excelize.STCellFormulaTypeArray, Ref: "A1:A3"})
+//    err = f.SetCellFormula("Sheet1", "B1", "C1*2",
+//        excelize.FormulaOpts{Type: excelize.STCellFormulaTypeShared, Ref: "B1:B3"})
+//
+func (f *File) SetCellFormula(sheet, axis, formula string, opts ...FormulaOpts) error {
 	xlsx, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	cellData, _, _, err := f.prepareCell(xlsx, sheet, axis)
+	cellData, col, row, err := f.prepareCell(xlsx, sheet, axis)
 	if err != nil {
 		return err
 	}
@@ -295,9 +467,61 @@ func (f *File) SetCellFormula(sheet, axis, formula string) error {
 	} else {
 		cellData.F = &xlsxF{Content: formula}
 	}
+	for _, o := range opts {
+		if o.Type == "" || o.Type == STCellFormulaTypeNormal {
+			continue
+		}
+		if o.Type != STCellFormulaTypeArray && o.Type != STCellFormulaTypeShared {
+			return fmt.Errorf("unsupported formula type %q", o.Type)
+		}
+		area, err := cellRefToCoordinates(o.Ref)
+		if err != nil {
+			return err
+		}
+		if area[0] != col || area[1] != row {
+			return fmt.Errorf("formula range %s must start with the cell %s", o.Ref, axis)
+		}
+		cellData.F.T, cellData.F.Ref = o.Type, o.Ref
+		if o.Type == STCellFormulaTypeShared {
+			return f.setSharedFormula(xlsx, sheet, cellData, area)
+		}
+	}
 	return err
 }
 
+// setSharedFormula provides a function to set the shared formula index of
+// the master cell and the other cells in the range of the shared formula.
+func (f *File) setSharedFormula(xlsx *xlsxWorksheet, sheet string, master *xlsxC, area []int) error {
+	si := 0
+	for _, r := range xlsx.SheetData.Row {
+		for _, c := range r.C {
+			if c.F != nil && c.F != master.F && c.F.T == STCellFormulaTypeShared {
+				if idx, err := strconv.Atoi(c.F.Si); err == nil && idx >= si {
+					si = idx + 1
+				}
+			}
+		}
+	}
+	master.F.Si = strconv.Itoa(si)
+	for row := area[1]; row <= area[3]; row++ {
+		for col := area[0]; col <= area[2]; col++ {
+			if col == area[0] && row == area[1] {
+				continue
+			}
+			cell, err := CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			cellData, _, _, err := f.prepareCell(xlsx, sheet, cell)
+			if err != nil {
+				return err
+			}
+			cellData.F = &xlsxF{T: STCellFormulaTypeShared, Si: master.F.Si}
+		}
+	}
+	return nil
+}
+
 // GetCellHyperLink provides a function to get cell hyperlink by given
 // worksheet name and axis. Boolean type value link will be ture if the cell
 // has a hyperlink and the target is the address of the hyperlink. Otherwise,
@@ -393,6 +617,53 @@ func (f *File) SetCellHyperLink(sheet, axis, link, linkType string) error {
 	return nil
 }
 
+// HyperLink directly maps the settings of a hyperlink in the worksheet. Type
+// is "External" for the link to a web site or file, and "Location" for the
+// link to a cell in the workbook.
+type HyperLink struct {
+	Ref     string
+	Type    string
+	Target  string
+	Tooltip string
+	Display string
+}
+
+// GetHyperLinks provides a function to get all hyperlinks of the worksheet by
+// given worksheet name. The cell references of the hyperlinks reflect the
+// current state of the worksheet, for example after rows or columns have
+// been inserted or deleted. For example, get hyperlinks of Sheet1:
+//
+//    links, err := f.GetHyperLinks("Sheet1")
+//    for _, link := range links {
+//        fmt.Println(link.Ref, link.Target)
+//    }
+//
+func (f *File) GetHyperLinks(sheet string) ([]HyperLink, error) {
+	var links []HyperLink
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return links, err
+	}
+	if xlsx.Hyperlinks == nil {
+		return links, err
+	}
+	for _, link := range xlsx.Hyperlinks.Hyperlink {
+		hyperLink := HyperLink{
+			Ref:     link.Ref,
+			Type:    "Location",
+			Target:  link.Location,
+			Tooltip: link.Tooltip,
+			Display: link.Display,
+		}
+		if link.RID != "" {
+			hyperLink.Type = "External"
+			hyperLink.Target = f.getSheetRelationshipsTargetByID(sheet, link.RID)
+		}
+		links = append(links, hyperLink)
+	}
+	return links, err
+}
+
 // MergeCell provides a function to merge cells by given coordinate area and
 // sheet name. For example create a merged cell of D3:E9 on Sheet1:
 //
@@ -485,6 +756,808 @@ func (f *File) SetSheetRow(sheet, axis string, slice interface{}) error {
 	return err
 }
 
+// SetRange writes a two-dimensional array to the rectangular block by given
+// worksheet name and the top-left cell of the block. Each element of the
+// array is written to a row of the block, and the cells outside of the block
+// are left untouched. For example, writes a 2x3 block start with the cell B2
+// on Sheet1:
+//
+//     err := f.SetRange("Sheet1", "B2", [][]interface{}{{"a", 1, 2}, {"b", 3, nil}})
+//
+func (f *File) SetRange(sheet, topLeft string, data [][]interface{}) error {
+	col, row, err := CellNameToCoordinates(topLeft)
+	if err != nil {
+		return err
+	}
+	for rowIdx, values := range data {
+		for colIdx, value := range values {
+			cell, err := CoordinatesToCellName(col+colIdx, row+rowIdx)
+			if err != nil {
+				return err
+			}
+			if err = f.SetCellValue(sheet, cell, value); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// FillDown provides a function to fill the cells of the destination range
+// downward from the cells of the source range by given worksheet name, like
+// the fill handle of the spreadsheet application. The destination range must
+// have the same columns as the source range and be below it. For each column,
+// the numeric values or the dates with a constant step are extended as a
+// linear series, otherwise the values, formulas and styles of the source
+// cells are copied repeatedly, and the relative references in the copied
+// formulas are moved. For example, extend the series in A1:A2 to A3:A10 on
+// Sheet1:
+//
+//    err := f.FillDown("Sheet1", "A1:A2", "A3:A10")
+//
+func (f *File) FillDown(sheet, srcRange, destRange string) error {
+	coordinates := func(ref string) ([]int, error) {
+		if !strings.Contains(ref, ":") {
+			ref = ref + ":" + ref
+		}
+		return areaRefToCoordinates(ref)
+	}
+	src, err := coordinates(srcRange)
+	if err != nil {
+		return err
+	}
+	dest, err := coordinates(destRange)
+	if err != nil {
+		return err
+	}
+	if src[0] != dest[0] || src[2] != dest[2] {
+		return fmt.Errorf("the columns of the destination range %s are not the same as the source range %s", destRange, srcRange)
+	}
+	if dest[1] <= src[3] {
+		return fmt.Errorf("the destination range %s is not below the source range %s", destRange, srcRange)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	for col := src[0]; col <= src[2]; col++ {
+		cells := make([]xlsxC, 0, src[3]-src[1]+1)
+		for row := src[1]; row <= src[3]; row++ {
+			var c xlsxC
+			if row <= len(xlsx.SheetData.Row) && col <= len(xlsx.SheetData.Row[row-1].C) {
+				c = xlsx.SheetData.Row[row-1].C[col-1]
+			}
+			if c.F != nil {
+				c.F = &xlsxF{Content: f.cellFormula(xlsx, &c, col, row)}
+			}
+			cells = append(cells, c)
+		}
+		first, step, series := fillSeries(cells)
+		for row := dest[1]; row <= dest[3]; row++ {
+			idx := row - src[1]
+			c := cells[idx%len(cells)]
+			if series {
+				c.V = strconv.FormatFloat(first+step*float64(idx), 'f', -1, 64)
+			} else if c.F != nil {
+				c.F, c.V = &xlsxF{Content: f.offsetReferences(c.F.Content, 0, idx-idx%len(cells))}, ""
+			}
+			if c.IS != nil {
+				is := *c.IS
+				c.IS = &is
+			}
+			c.R, _ = CoordinatesToCellName(col, row)
+			prepareSheetXML(xlsx, col, row)
+			xlsx.SheetData.Row[row-1].C[col-1] = c
+		}
+	}
+	return err
+}
+
+// fillSeries provides a function to detect the linear series in the source
+// cells of filling, it returns the first value and the step of the series,
+// and reports whether the cells are at least two numeric values with a
+// constant step.
+func fillSeries(cells []xlsxC) (float64, float64, bool) {
+	if len(cells) < 2 {
+		return 0, 0, false
+	}
+	values := make([]float64, len(cells))
+	for i, c := range cells {
+		if c.F != nil || (c.T != "" && c.T != "n") {
+			return 0, 0, false
+		}
+		value, err := strconv.ParseFloat(c.V, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		values[i] = value
+	}
+	step := values[1] - values[0]
+	for i := 2; i < len(values); i++ {
+		if math.Abs(values[i]-values[i-1]-step) > 1e-9 {
+			return 0, 0, false
+		}
+	}
+	return values[0], step, true
+}
+
+// cellFormula provides a function to get the formula of the cell by given
+// coordinates, the formula of the cell of the shared formula is derived from
+// the formula of the master cell.
+func (f *File) cellFormula(xlsx *xlsxWorksheet, c *xlsxC, col, row int) string {
+	if c.F.T != STCellFormulaTypeShared || c.F.Ref != "" {
+		return c.F.Content
+	}
+	for _, r := range xlsx.SheetData.Row {
+		for _, cell := range r.C {
+			if cell.F != nil && cell.F.Ref != "" && cell.F.T == STCellFormulaTypeShared && cell.F.Si == c.F.Si {
+				masterCol, masterRow, _ := CellNameToCoordinates(cell.R)
+				return f.offsetReferences(cell.F.Content, col-masterCol, row-masterRow)
+			}
+		}
+	}
+	return ""
+}
+
+// ShiftDirection defined the direction in which cells are moved when inserting
+// cells into a range or deleting the cells of a range.
+type ShiftDirection int
+
+// Shift directions.
+const (
+	// ShiftCellsRight moves the cells of the affected rows to the right.
+	ShiftCellsRight ShiftDirection = iota
+	// ShiftCellsDown moves the cells of the affected columns down.
+	ShiftCellsDown
+	// ShiftCellsLeft moves the cells of the affected rows to the left.
+	ShiftCellsLeft
+	// ShiftCellsUp moves the cells of the affected columns up.
+	ShiftCellsUp
+)
+
+// InsertCells provides a function to insert blank cells into the range by
+// given worksheet name, area reference and shift direction. Unlike InsertRow
+// and InsertCol, only the cells of the rows (for ShiftCellsRight) or columns
+// (for ShiftCellsDown) covered by the range are moved, the rest of the
+// worksheet is left unchanged. For example, insert cells into Sheet1!B2:C3
+// and move the existing cells of rows 2 and 3 two columns to the right:
+//
+//    err := f.InsertCells("Sheet1", "B2:C3", excelize.ShiftCellsRight)
+//
+// The hyperlinks, merged cells, comments, conditional formats, data
+// validations and the references in the formulas to the moved cells are
+// updated. An error is returned when the moved cells would go beyond the last
+// row or column of the worksheet, or a merged cell is only partially inside
+// the range of cells to be moved, since it could not be moved without
+// splitting it.
+func (f *File) InsertCells(sheet, rangeRef string, shift ShiftDirection) (err error) {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef = rangeRef + ":" + rangeRef
+	}
+	coordinates, err := areaRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	if shift != ShiftCellsRight && shift != ShiftCellsDown {
+		return fmt.Errorf("invalid shift direction %d", shift)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if err = insertCellsBound(xlsx, coordinates, shift); err != nil {
+		return err
+	}
+	if err = insertCellsMergeCells(xlsx, coordinates, shift); err != nil {
+		return err
+	}
+	defer f.recoverAdjust(f.takeAdjustSnapshot(), &err)
+	if shift == ShiftCellsRight {
+		insertCellsRight(xlsx, coordinates)
+	} else {
+		insertCellsDown(xlsx, coordinates)
+	}
+	dir, m := newShiftCellsMapping(coordinates, shift)
+	return f.adjustCellReferences(sheet, xlsx, cellCoordinatesCache{}, dir, m)
+}
+
+// DeleteCells provides a function to delete the cells of the range by given
+// worksheet name, area reference and shift direction, it is the opposite of
+// InsertCells. Only the cells of the rows (for ShiftCellsLeft) or columns
+// (for ShiftCellsUp) covered by the range are moved to fill the deleted
+// cells, the rest of the worksheet is left unchanged. For example, delete
+// the cells of Sheet1!B2:C3 and move the cells below them in columns B and C
+// two rows up:
+//
+//    err := f.DeleteCells("Sheet1", "B2:C3", excelize.ShiftCellsUp)
+//
+// The merged cells, hyperlinks, comments, conditional formats and data
+// validations inside the range are deleted, and those of the moved cells and
+// the references in the formulas to the moved cells are updated. An error is
+// returned when a merged cell is only partially inside the range of cells to
+// be deleted or moved, since it could not be moved without splitting it.
+func (f *File) DeleteCells(sheet, rangeRef string, shift ShiftDirection) (err error) {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef = rangeRef + ":" + rangeRef
+	}
+	coordinates, err := areaRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	if shift != ShiftCellsLeft && shift != ShiftCellsUp {
+		return fmt.Errorf("invalid shift direction %d", shift)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if err = deleteCellsMergeCells(xlsx, coordinates, shift); err != nil {
+		return err
+	}
+	defer f.recoverAdjust(f.takeAdjustSnapshot(), &err)
+	if shift == ShiftCellsLeft {
+		deleteCellsLeft(xlsx, coordinates)
+	} else {
+		deleteCellsUp(xlsx, coordinates)
+	}
+	dir, m := newShiftCellsMapping(coordinates, shift)
+	return f.adjustCellReferences(sheet, xlsx, cellCoordinatesCache{}, dir, m)
+}
+
+// ClearRangeOpts directly maps the settings of clearing a range of cells.
+// Clip specifies whether to clip the structures which partially overlap the
+// range instead of returning an error.
+type ClearRangeOpts struct {
+	Clip bool
+}
+
+// ClearRange provides a function to clear the values and styles of the cells
+// in the given range of the worksheet, and remove the merged cells,
+// hyperlinks, comments and data validations which are wholly contained in
+// the range. An error is returned when a merged cell or a data validation
+// partially overlaps the range. For example, clear the cells of range A1:C3
+// on Sheet1:
+//
+//    err := f.ClearRange("Sheet1", "A1:C3")
+//
+// With the Clip option, the cells of the range in the data validations which
+// partially overlap the range are removed from them, and the merged cells
+// which partially overlap the range are left unchanged, so are their
+// top-left cells, which keep the values of the merged cells:
+//
+//    err := f.ClearRange("Sheet1", "A1:C3", excelize.ClearRangeOpts{Clip: true})
+//
+func (f *File) ClearRange(sheet, rangeRef string, opts ...ClearRangeOpts) error {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef = rangeRef + ":" + rangeRef
+	}
+	coordinates, err := areaRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	contains := func(area []int) bool {
+		return area[0] >= coordinates[0] && area[1] >= coordinates[1] &&
+			area[2] <= coordinates[2] && area[3] <= coordinates[3]
+	}
+	var clip bool
+	for _, o := range opts {
+		clip = o.Clip
+	}
+	if !clip {
+		if err = clearRangeOverlaps(xlsx, coordinates, rangeRef); err != nil {
+			return err
+		}
+	}
+	anchors := map[[2]int]bool{}
+	if xlsx.MergeCells != nil {
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			if area, err := areaRefToCoordinates(mergeCell.Ref); err == nil && !contains(area) {
+				anchors[[2]int{area[0], area[1]}] = true
+			}
+		}
+	}
+	for row := coordinates[1]; row <= coordinates[3] && row <= len(xlsx.SheetData.Row); row++ {
+		rowData := &xlsx.SheetData.Row[row-1]
+		for col := coordinates[0]; col <= coordinates[2] && col <= len(rowData.C); col++ {
+			if anchors[[2]int{col, row}] {
+				continue
+			}
+			rowData.C[col-1] = xlsxC{R: rowData.C[col-1].R}
+		}
+	}
+	if xlsx.MergeCells != nil {
+		mergeCells := xlsx.MergeCells.Cells[:0]
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			if area, err := areaRefToCoordinates(mergeCell.Ref); err == nil && contains(area) {
+				continue
+			}
+			mergeCells = append(mergeCells, mergeCell)
+		}
+		xlsx.MergeCells.Cells = mergeCells
+		xlsx.MergeCells.Count = len(mergeCells)
+		if xlsx.MergeCells.Count == 0 {
+			xlsx.MergeCells = nil
+		}
+	}
+	if xlsx.Hyperlinks != nil {
+		hyperlinks := xlsx.Hyperlinks.Hyperlink[:0]
+		for _, link := range xlsx.Hyperlinks.Hyperlink {
+			if area, err := cellRefToCoordinates(link.Ref); err == nil && contains(area) {
+				if link.RID != "" {
+					f.deleteSheetRelationships(sheet, link.RID)
+				}
+				continue
+			}
+			hyperlinks = append(hyperlinks, link)
+		}
+		xlsx.Hyperlinks.Hyperlink = hyperlinks
+		if len(hyperlinks) == 0 {
+			xlsx.Hyperlinks = nil
+		}
+	}
+	f.deleteComments(sheet, xlsx, func(col, row int) bool {
+		return contains([]int{col, row, col, row})
+	})
+	if xlsx.DataValidations != nil {
+		dataValidations := xlsx.DataValidations.DataValidation[:0]
+		for _, dv := range xlsx.DataValidations.DataValidation {
+			var sqref []string
+			for _, ref := range strings.Fields(dv.Sqref) {
+				area, err := cellRefToCoordinates(ref)
+				if err == nil && contains(area) {
+					continue
+				}
+				if err == nil && areaOverlaps(area, coordinates) {
+					sqref = append(sqref, clipArea(area, coordinates)...)
+					continue
+				}
+				sqref = append(sqref, ref)
+			}
+			if len(sqref) == 0 {
+				continue
+			}
+			dv.Sqref = strings.Join(sqref, " ")
+			dataValidations = append(dataValidations, dv)
+		}
+		xlsx.DataValidations.DataValidation = dataValidations
+		xlsx.DataValidations.Count = len(dataValidations)
+		if xlsx.DataValidations.Count == 0 {
+			xlsx.DataValidations = nil
+		}
+	}
+	return err
+}
+
+// clearRangeOverlaps provides a function to check whether any merged cell or
+// data validation partially overlaps the range of cells to be cleared.
+func clearRangeOverlaps(xlsx *xlsxWorksheet, coordinates []int, rangeRef string) error {
+	partial := func(area []int) bool {
+		return areaOverlaps(area, coordinates) && (area[0] < coordinates[0] || area[1] < coordinates[1] ||
+			area[2] > coordinates[2] || area[3] > coordinates[3])
+	}
+	if xlsx.MergeCells != nil {
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			if area, err := areaRefToCoordinates(mergeCell.Ref); err == nil && partial(area) {
+				return fmt.Errorf("merged cell %s partially overlaps the range %s", mergeCell.Ref, rangeRef)
+			}
+		}
+	}
+	if xlsx.DataValidations != nil {
+		for _, dv := range xlsx.DataValidations.DataValidation {
+			for _, ref := range strings.Fields(dv.Sqref) {
+				if area, err := cellRefToCoordinates(ref); err == nil && partial(area) {
+					return fmt.Errorf("data validation %s partially overlaps the range %s", ref, rangeRef)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// clipArea returns the references of the parts of the area outside of the
+// given coordinates, the rows above and below the coordinates, and the cells
+// to the left and right of them.
+func clipArea(area, coordinates []int) []string {
+	var refs []string
+	firstRow, lastRow := area[1], area[3]
+	if area[1] < coordinates[1] {
+		refs = append(refs, coordinatesToSqref([]int{area[0], area[1], area[2], coordinates[1] - 1}))
+		firstRow = coordinates[1]
+	}
+	if area[3] > coordinates[3] {
+		lastRow = coordinates[3]
+	}
+	if area[0] < coordinates[0] {
+		refs = append(refs, coordinatesToSqref([]int{area[0], firstRow, coordinates[0] - 1, lastRow}))
+	}
+	if area[2] > coordinates[2] {
+		refs = append(refs, coordinatesToSqref([]int{coordinates[2] + 1, firstRow, area[2], lastRow}))
+	}
+	if area[3] > coordinates[3] {
+		refs = append(refs, coordinatesToSqref([]int{area[0], coordinates[3] + 1, area[2], area[3]}))
+	}
+	return refs
+}
+
+// RangeStructures directly maps the references of the structures of the
+// worksheet which intersect a range. The data validations and the
+// conditional formats are given by their whole sequences of references.
+type RangeStructures struct {
+	MergeCells         []string
+	Hyperlinks         []string
+	Comments           []string
+	DataValidations    []string
+	ConditionalFormats []string
+}
+
+// GetRangeStructures provides a function to get the merged cells,
+// hyperlinks, comments, data validations and conditional formats of the
+// worksheet which are wholly or partially inside the given range, which helps
+// to find out what will be affected before calling ClearRange or
+// DeleteCells. For example, get the structures intersecting the range A1:C3
+// on Sheet1:
+//
+//    structures, err := f.GetRangeStructures("Sheet1", "A1:C3")
+//
+func (f *File) GetRangeStructures(sheet, rangeRef string) (RangeStructures, error) {
+	var structures RangeStructures
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef = rangeRef + ":" + rangeRef
+	}
+	coordinates, err := areaRefToCoordinates(rangeRef)
+	if err != nil {
+		return structures, err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return structures, err
+	}
+	overlaps := func(sqref string) bool {
+		for _, ref := range strings.Fields(sqref) {
+			if area, err := cellRefToCoordinates(ref); err == nil && areaOverlaps(area, coordinates) {
+				return true
+			}
+		}
+		return false
+	}
+	if xlsx.MergeCells != nil {
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			if overlaps(mergeCell.Ref) {
+				structures.MergeCells = append(structures.MergeCells, mergeCell.Ref)
+			}
+		}
+	}
+	if xlsx.Hyperlinks != nil {
+		for _, link := range xlsx.Hyperlinks.Hyperlink {
+			if overlaps(link.Ref) {
+				structures.Hyperlinks = append(structures.Hyperlinks, link.Ref)
+			}
+		}
+	}
+	if comments, _ := f.sheetCommentsReader(sheet, xlsx); comments != nil {
+		for _, comment := range comments.CommentList.Comment {
+			if overlaps(comment.Ref) {
+				structures.Comments = append(structures.Comments, comment.Ref)
+			}
+		}
+	}
+	if xlsx.DataValidations != nil {
+		for _, dv := range xlsx.DataValidations.DataValidation {
+			if overlaps(dv.Sqref) {
+				structures.DataValidations = append(structures.DataValidations, dv.Sqref)
+			}
+		}
+	}
+	for _, cf := range xlsx.ConditionalFormatting {
+		if overlaps(cf.SQRef) {
+			structures.ConditionalFormats = append(structures.ConditionalFormats, cf.SQRef)
+		}
+	}
+	return structures, err
+}
+
+// ConvertFormulaReferences provides a function to convert the cell
+// references in the formulas of the cells in the given range of the
+// worksheet to the absolute references if absolute is true, otherwise to the
+// relative references, which helps to prepare the formulas of a template
+// before copying them. The formulas of the cells out of the range and the
+// references in the string literals are left unchanged, and the shared
+// formulas in the range are converted to the formulas of each cell. For
+// example, convert the references such as =$A$1 to =A1 in the formulas of
+// range B1:B10 on Sheet1:
+//
+//    err := f.ConvertFormulaReferences("Sheet1", "B1:B10", false)
+//
+func (f *File) ConvertFormulaReferences(sheet, rangeRef string, absolute bool) error {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef = rangeRef + ":" + rangeRef
+	}
+	coordinates, err := areaRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	inRange := func(col, row int) bool {
+		return col >= coordinates[0] && col <= coordinates[2] && row >= coordinates[1] && row <= coordinates[3]
+	}
+	// Record the master cells of the shared formulas, and promote the cells
+	// out of the range to be the new master cells of the shared formulas
+	// whose master cells are in the range.
+	type sharedMaster struct {
+		content  string
+		col, row int
+	}
+	masters := make(map[string]sharedMaster)
+	for rowIdx := range xlsx.SheetData.Row {
+		for colIdx := range xlsx.SheetData.Row[rowIdx].C {
+			master := xlsx.SheetData.Row[rowIdx].C[colIdx].F
+			if master == nil || master.T != STCellFormulaTypeShared || master.Ref == "" {
+				continue
+			}
+			masters[master.Si] = sharedMaster{content: master.Content, col: colIdx + 1, row: rowIdx + 1}
+			if !inRange(colIdx+1, rowIdx+1) {
+				continue
+			}
+			area, err := areaRefToCoordinates(master.Ref)
+			if err != nil {
+				return err
+			}
+			f.promoteSharedFormula(xlsx, master, colIdx+1, rowIdx+1, area, inRange)
+		}
+	}
+	for row := coordinates[1]; row <= coordinates[3] && row <= len(xlsx.SheetData.Row); row++ {
+		cells := xlsx.SheetData.Row[row-1].C
+		for col := coordinates[0]; col <= coordinates[2] && col <= len(cells); col++ {
+			c := &cells[col-1]
+			if c.F == nil {
+				continue
+			}
+			if c.F.T == STCellFormulaTypeShared {
+				master, ok := masters[c.F.Si]
+				if !ok {
+					continue
+				}
+				c.F = &xlsxF{Content: f.offsetReferences(master.content, col-master.col, row-master.row)}
+			}
+			c.F.Content = f.convertReferences(c.F.Content, absolute)
+		}
+	}
+	return nil
+}
+
+// insertCellsShift reports whether the given area intersects and is fully
+// covered by the moved part of the worksheet when inserting cells into the
+// area.
+func insertCellsShift(coordinates, area []int, shift ShiftDirection) (bool, bool) {
+	firstCol, firstRow, lastCol, lastRow := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	if shift == ShiftCellsRight {
+		return area[1] <= lastRow && area[3] >= firstRow && area[2] >= firstCol,
+			area[1] >= firstRow && area[3] <= lastRow && area[0] >= firstCol
+	}
+	return area[0] <= lastCol && area[2] >= firstCol && area[3] >= firstRow,
+		area[0] >= firstCol && area[2] <= lastCol && area[1] >= firstRow
+}
+
+// newShiftCellsMapping returns the direction and the mapping of the rows or
+// columns moved by inserting or deleting the cells of the area, which is
+// limited to the rows or columns covered by the area.
+func newShiftCellsMapping(coordinates []int, shift ShiftDirection) (AdjustDirection, adjustMapping) {
+	firstCol, firstRow, lastCol, lastRow := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	var m adjustMapping
+	switch shift {
+	case ShiftCellsRight:
+		m = newAdjustMapping(firstCol, lastCol-firstCol+1)
+	case ShiftCellsLeft:
+		m = newAdjustMapping(firstCol, firstCol-lastCol-1)
+	case ShiftCellsDown:
+		m = newAdjustMapping(firstRow, lastRow-firstRow+1)
+	default:
+		m = newAdjustMapping(firstRow, firstRow-lastRow-1)
+	}
+	if shift == ShiftCellsRight || shift == ShiftCellsLeft {
+		m.bandFirst, m.bandLast = firstRow, lastRow
+		return columns, m
+	}
+	m.bandFirst, m.bandLast = firstCol, lastCol
+	return rows, m
+}
+
+// insertCellsBound provides a function to check whether the last cell moved
+// by inserting cells into the area is still inside of the worksheet.
+func insertCellsBound(xlsx *xlsxWorksheet, coordinates []int, shift ShiftDirection) error {
+	firstCol, firstRow, lastCol, lastRow := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	if shift == ShiftCellsRight {
+		offset := lastCol - firstCol + 1
+		for row := firstRow; row <= lastRow && row <= len(xlsx.SheetData.Row); row++ {
+			cells := xlsx.SheetData.Row[row-1].C
+			for col := len(cells); col >= firstCol; col-- {
+				if isBlankCell(cells[col-1]) {
+					continue
+				}
+				if col+offset > TotalColumns {
+					return newInvalidColumnNumberError(col + offset)
+				}
+				break
+			}
+		}
+		return nil
+	}
+	offset := lastRow - firstRow + 1
+	for row := len(xlsx.SheetData.Row); row >= firstRow; row-- {
+		cells := xlsx.SheetData.Row[row-1].C
+		for col := firstCol; col <= lastCol && col <= len(cells); col++ {
+			if isBlankCell(cells[col-1]) {
+				continue
+			}
+			if row+offset > TotalRows {
+				return newInvalidRowNumberError(row + offset)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// insertCellsMergeCells provides a function to check the merged cells when
+// inserting cells, the merged cells which located in the moved part of the
+// worksheet are moved with the cells.
+func insertCellsMergeCells(xlsx *xlsxWorksheet, coordinates []int, shift ShiftDirection) error {
+	if xlsx.MergeCells == nil {
+		return nil
+	}
+	for _, mergeCell := range xlsx.MergeCells.Cells {
+		area, err := areaRefToCoordinates(mergeCell.Ref)
+		if err != nil {
+			return err
+		}
+		if intersects, covered := insertCellsShift(coordinates, area, shift); intersects && !covered {
+			return fmt.Errorf("merged cell %s is partially inside the range of cells to be moved", mergeCell.Ref)
+		}
+	}
+	return nil
+}
+
+// insertCellsRight provides a function to move the cells of the rows covered
+// by the area to the right, and fill the area with blank cells.
+func insertCellsRight(xlsx *xlsxWorksheet, coordinates []int) {
+	firstCol, firstRow, lastCol, lastRow := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	offset := lastCol - firstCol + 1
+	for row := firstRow; row <= lastRow && row <= len(xlsx.SheetData.Row); row++ {
+		rowData := &xlsx.SheetData.Row[row-1]
+		if len(rowData.C) < firstCol {
+			continue
+		}
+		cells := make([]xlsxC, 0, len(rowData.C)+offset)
+		cells = append(cells, rowData.C[:firstCol-1]...)
+		for col := firstCol; col <= lastCol; col++ {
+			cellName, _ := CoordinatesToCellName(col, row)
+			cells = append(cells, xlsxC{R: cellName})
+		}
+		for idx, c := range rowData.C[firstCol-1:] {
+			c.R, _ = CoordinatesToCellName(firstCol+idx+offset, row)
+			cells = append(cells, c)
+		}
+		rowData.C = cells
+	}
+}
+
+// insertCellsDown provides a function to move the cells of the columns
+// covered by the area down, and fill the area with blank cells.
+func insertCellsDown(xlsx *xlsxWorksheet, coordinates []int) {
+	firstCol, firstRow, lastCol, lastRow := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	offset := lastRow - firstRow + 1
+	for row := len(xlsx.SheetData.Row); row >= firstRow; row-- {
+		for col := firstCol; col <= lastCol; col++ {
+			src := xlsx.SheetData.Row[row-1].C
+			if col <= len(src) && !isBlankCell(src[col-1]) {
+				prepareSheetXML(xlsx, col, row+offset)
+				c := xlsx.SheetData.Row[row-1].C[col-1]
+				c.R, _ = CoordinatesToCellName(col, row+offset)
+				xlsx.SheetData.Row[row+offset-1].C[col-1] = c
+			} else if row+offset <= len(xlsx.SheetData.Row) && col <= len(xlsx.SheetData.Row[row+offset-1].C) {
+				cellName, _ := CoordinatesToCellName(col, row+offset)
+				xlsx.SheetData.Row[row+offset-1].C[col-1] = xlsxC{R: cellName}
+			}
+			if row < firstRow+offset && col <= len(xlsx.SheetData.Row[row-1].C) {
+				cellName, _ := CoordinatesToCellName(col, row)
+				xlsx.SheetData.Row[row-1].C[col-1] = xlsxC{R: cellName}
+			}
+		}
+	}
+}
+
+// deleteCellsShift reports whether the given area intersects and is fully
+// covered by the moved part of the worksheet or the deleted cells when
+// deleting the cells of the area.
+func deleteCellsShift(coordinates, area []int, shift ShiftDirection) (bool, bool) {
+	inside := area[0] >= coordinates[0] && area[1] >= coordinates[1] &&
+		area[2] <= coordinates[2] && area[3] <= coordinates[3]
+	if shift == ShiftCellsLeft {
+		intersects, covered := insertCellsShift(coordinates, area, ShiftCellsRight)
+		return intersects, covered && (inside || area[0] > coordinates[2])
+	}
+	intersects, covered := insertCellsShift(coordinates, area, ShiftCellsDown)
+	return intersects, covered && (inside || area[1] > coordinates[3])
+}
+
+// deleteCellsMergeCells provides a function to check the merged cells when
+// deleting cells, the merged cells inside the deleted cells are deleted and
+// the merged cells which located in the moved part of the worksheet are moved
+// with the cells.
+func deleteCellsMergeCells(xlsx *xlsxWorksheet, coordinates []int, shift ShiftDirection) error {
+	if xlsx.MergeCells == nil {
+		return nil
+	}
+	for _, mergeCell := range xlsx.MergeCells.Cells {
+		area, err := areaRefToCoordinates(mergeCell.Ref)
+		if err != nil {
+			return err
+		}
+		if intersects, covered := deleteCellsShift(coordinates, area, shift); intersects && !covered {
+			return fmt.Errorf("merged cell %s is partially inside the range of cells to be moved", mergeCell.Ref)
+		}
+	}
+	return nil
+}
+
+// deleteCellsLeft provides a function to delete the cells of the area and
+// move the cells of the rows covered by the area to the left.
+func deleteCellsLeft(xlsx *xlsxWorksheet, coordinates []int) {
+	firstCol, firstRow, lastCol, lastRow := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	for row := firstRow; row <= lastRow && row <= len(xlsx.SheetData.Row); row++ {
+		rowData := &xlsx.SheetData.Row[row-1]
+		if len(rowData.C) < firstCol {
+			continue
+		}
+		cells := rowData.C[:firstCol-1]
+		if lastCol < len(rowData.C) {
+			for idx, c := range rowData.C[lastCol:] {
+				c.R, _ = CoordinatesToCellName(firstCol+idx, row)
+				cells = append(cells, c)
+			}
+		}
+		rowData.C = cells
+	}
+}
+
+// deleteCellsUp provides a function to delete the cells of the area and move
+// the cells of the columns covered by the area up.
+func deleteCellsUp(xlsx *xlsxWorksheet, coordinates []int) {
+	firstCol, firstRow, lastCol, lastRow := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	offset := lastRow - firstRow + 1
+	for row := firstRow; row <= len(xlsx.SheetData.Row); row++ {
+		for col := firstCol; col <= lastCol; col++ {
+			var c xlsxC
+			if src := row + offset; src <= len(xlsx.SheetData.Row) && col <= len(xlsx.SheetData.Row[src-1].C) {
+				c = xlsx.SheetData.Row[src-1].C[col-1]
+			}
+			cellName, _ := CoordinatesToCellName(col, row)
+			if isBlankCell(c) {
+				if col <= len(xlsx.SheetData.Row[row-1].C) {
+					xlsx.SheetData.Row[row-1].C[col-1] = xlsxC{R: cellName}
+				}
+				continue
+			}
+			prepareSheetXML(xlsx, col, row)
+			c.R = cellName
+			xlsx.SheetData.Row[row-1].C[col-1] = c
+		}
+	}
+}
+
+// isBlankCell reports whether the cell has neither value, formula nor style,
+// such cells are dropped from the worksheet when saving.
+func isBlankCell(c xlsxC) bool {
+	return c.S == 0 && c.V == "" && c.F == nil && c.T == ""
+}
+
 // getCellInfo does common preparation for all SetCell* methods.
 func (f *File) prepareCell(xlsx *xlsxWorksheet, sheet, cell string) (*xlsxC, int, int, error) {
 	var err error
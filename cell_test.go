@@ -2,7 +2,9 @@ package excelize
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -94,3 +96,641 @@ func BenchmarkSetCellValue(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkGetCellValue(b *testing.B) {
+	f := NewFile()
+	cells := make([]string, 0, 100)
+	for row := 1; row <= 1000; row++ {
+		for col := 1; col <= 10; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			f.SetCellValue("Sheet1", cell, row*col)
+			if (row*col)%101 == 0 {
+				cells = append(cells, cell)
+			}
+		}
+	}
+	b.Run("GetCellValue", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, cell := range cells {
+				f.GetCellValue("Sheet1", cell)
+			}
+		}
+	})
+	b.Run("GetCellValues", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.GetCellValues("Sheet1", cells)
+		}
+	})
+}
+
+func TestGetCellValues(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", 3.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B10", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D5", "D5"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D5", "E6"))
+	cells := []string{"B10", "A1", "C3", "Z100", "B3", "E6", "a1"}
+	values, err := f.GetCellValues("Sheet1", cells)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "A1", "3.5", "", "", "D5", "A1"}, values)
+	// Test the values are the same as the values of GetCellValue.
+	for i, cell := range cells {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, value, values[i], cell)
+	}
+
+	// Test the values after inserting a row.
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	values, err = f.GetCellValues("Sheet1", []string{"A1", "C4", "B11", "C3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "3.5", "1", ""}, values)
+
+	// Test get the values with invalid arguments.
+	_, err = f.GetCellValues("Sheet1", []string{"A1", "A"})
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	_, err = f.GetCellValues("SheetN", []string{"A1"})
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestSetRange(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E5", "E5"))
+	assert.NoError(t, f.SetRange("Sheet1", "B2", [][]interface{}{{1, 2, 3}, {"a", "b", "c"}, {true, nil, 4.5}}))
+	// Test insert row inside of the block.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	for cell, expected := range map[string]string{
+		"A1": "A1", "B2": "1", "C2": "2", "D2": "3", "B3": "", "C3": "",
+		"B4": "a", "C4": "b", "D4": "c", "B5": "1", "C5": "", "D5": "4.5", "E6": "E5",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+
+	assert.EqualError(t, f.SetRange("Sheet1", "A", nil), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.SetRange("SheetN", "A1", [][]interface{}{{1}}), "sheet SheetN is not exist")
+}
+
+func TestInsertCells(t *testing.T) {
+	sheet := "Sheet1"
+	f := NewFile()
+	for row := 1; row <= 4; row++ {
+		for col := 1; col <= 4; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			assert.NoError(t, f.SetCellValue(sheet, cell, cell))
+		}
+	}
+	assert.NoError(t, f.MergeCell(sheet, "C2", "D3"))
+	// insert a 2x2 block of cells at B2:C3 and move the cells right.
+	assert.NoError(t, f.InsertCells(sheet, "B2:C3", ShiftCellsRight))
+	expected := [][]string{
+		{"A1", "B1", "C1", "D1", "", ""},
+		{"A2", "", "", "B2", "C2", "D2"},
+		{"A3", "", "", "B3", "C3", "D3"},
+		{"A4", "B4", "C4", "D4", "", ""},
+	}
+	rows, err := f.GetRows(sheet)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rows)
+	mergeCells, err := f.GetMergeCells(sheet)
+	assert.NoError(t, err)
+	assert.Equal(t, "E2:F3", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+
+	assert.NoError(t, f.InsertCells(sheet, "D1", ShiftCellsDown))
+	val, err := f.GetCellValue(sheet, "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", val)
+	val, err = f.GetCellValue(sheet, "D5")
+	assert.NoError(t, err)
+	assert.Equal(t, "D4", val)
+	val, err = f.GetCellValue(sheet, "D3")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertCells.xlsx")))
+
+	// test insert cells with a merged cell partially inside the moved range.
+	assert.EqualError(t, f.InsertCells(sheet, "A3:A4", ShiftCellsRight), "merged cell E2:F3 is partially inside the range of cells to be moved")
+	assert.EqualError(t, f.InsertCells(sheet, "F1", ShiftCellsDown), "merged cell E2:F3 is partially inside the range of cells to be moved")
+	// test insert cells with invalid arguments.
+	assert.EqualError(t, f.InsertCells(sheet, "A1:B", ShiftCellsRight), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	assert.EqualError(t, f.InsertCells(sheet, "A1:B1", ShiftDirection(2)), "invalid shift direction 2")
+	assert.EqualError(t, f.InsertCells("SheetN", "A1:B1", ShiftCellsRight), "sheet SheetN is not exist")
+}
+
+func TestInsertCellsAdjustReferences(t *testing.T) {
+	sheet := "Sheet1"
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	for row := 1; row <= 4; row++ {
+		for col := 1; col <= 4; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			assert.NoError(t, f.SetCellValue(sheet, cell, cell))
+		}
+	}
+	assert.NoError(t, f.SetCellFormula(sheet, "F1", "SUM(B2:C2)+B1+D3+B4"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!C3+Sheet1!A3"))
+	assert.NoError(t, f.SetCellHyperLink(sheet, "B3", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.AddComment(sheet, "C2", `{"author":"Excelize: ","text":"This is a comment."}`))
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "C3:D3 B1"
+	assert.NoError(t, dvRange.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation(sheet, dvRange))
+	format, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511"}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat(sheet, "B2:C2", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%d,"value":"6"}]`, format)))
+
+	// Test insert cells and move the cells right, only the references to the
+	// moved cells of rows 2 and 3 are updated.
+	assert.NoError(t, f.InsertCells(sheet, "B2:C3", ShiftCellsRight))
+	formula, err := f.GetCellFormula(sheet, "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(D2:E2)+B1+F3+B4", formula)
+	formula, err = f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!E3+Sheet1!A3", formula)
+	links, err := f.GetHyperLinks(sheet)
+	assert.NoError(t, err)
+	if assert.Len(t, links, 1) {
+		assert.Equal(t, "D3", links[0].Ref)
+	}
+	comments := f.GetComments()
+	if assert.Len(t, comments[sheet], 1) {
+		assert.Equal(t, "E2", comments[sheet][0].Ref)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	assert.NoError(t, err)
+	if assert.Len(t, xlsx.DataValidations.DataValidation, 1) {
+		assert.Equal(t, "E3:F3 B1", xlsx.DataValidations.DataValidation[0].Sqref)
+	}
+	conditionalFormats, err := f.GetConditionalFormats(sheet)
+	assert.NoError(t, err)
+	if assert.Len(t, conditionalFormats, 1) {
+		assert.Equal(t, "D2:E2", conditionalFormats[0].SQRef)
+	}
+
+	// Test insert cells and move the cells down, only the references to the
+	// moved cells of column A are updated.
+	assert.NoError(t, f.InsertCells(sheet, "A2", ShiftCellsDown))
+	formula, err = f.GetCellFormula(sheet, "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(D2:E2)+B1+F3+B4", formula)
+	formula, err = f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!E3+Sheet1!A4", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertCellsAdjustReferences.xlsx")))
+
+	// Test insert cells with the moved cells beyond the last row or column.
+	assert.NoError(t, f.SetCellValue(sheet, "XFC10", "XFC10"))
+	assert.EqualError(t, f.InsertCells(sheet, "A10:B10", ShiftCellsRight), "incorrect column number 16385")
+	assert.NoError(t, f.SetCellValue(sheet, "H1048575", "H1048575"))
+	assert.EqualError(t, f.InsertCells(sheet, "H5:H6", ShiftCellsDown), "invalid row number 1048577")
+	// Test insert cells with the cells outside of the moved columns at the
+	// last row.
+	assert.NoError(t, f.InsertCells(sheet, "G5:G6", ShiftCellsDown))
+}
+
+func TestDeleteCells(t *testing.T) {
+	sheet := "Sheet1"
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		for col := 1; col <= 4; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			assert.NoError(t, f.SetCellValue(sheet, cell, cell))
+		}
+	}
+	assert.NoError(t, f.MergeCell(sheet, "B5", "C5"))
+	assert.NoError(t, f.SetCellHyperLink(sheet, "B2", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink(sheet, "C4", "Sheet1!A1", "Location"))
+	// delete a 2x2 block of cells at B2:C3 and move the cells up.
+	assert.NoError(t, f.DeleteCells(sheet, "B2:C3", ShiftCellsUp))
+	expected := [][]string{
+		{"A1", "B1", "C1", "D1"},
+		{"A2", "B4", "C4", "D2"},
+		{"A3", "B5", "C5", "D3"},
+		{"A4", "", "", "D4"},
+		{"A5", "", "", "D5"},
+	}
+	rows, err := f.GetRows(sheet)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, rows)
+	mergeCells, err := f.GetMergeCells(sheet)
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 1) {
+		assert.Equal(t, "B3:C3", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	}
+	links, err := f.GetHyperLinks(sheet)
+	assert.NoError(t, err)
+	if assert.Len(t, links, 1) {
+		assert.Equal(t, "C2", links[0].Ref)
+	}
+
+	// delete the cells of the merged cell and move the cells left.
+	assert.NoError(t, f.DeleteCells(sheet, "B3:C3", ShiftCellsLeft))
+	rows, err = f.GetRows(sheet)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A3", "D3", "", ""}, rows[2])
+	assert.Equal(t, []string{"A2", "B4", "C4", "D2"}, rows[1])
+	mergeCells, err = f.GetMergeCells(sheet)
+	assert.NoError(t, err)
+	assert.Empty(t, mergeCells)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteCells.xlsx")))
+
+	// test delete cells with a merged cell partially inside the moved range.
+	assert.NoError(t, f.MergeCell(sheet, "A4", "B5"))
+	assert.EqualError(t, f.DeleteCells(sheet, "B1", ShiftCellsUp), "merged cell A4:B5 is partially inside the range of cells to be moved")
+	assert.EqualError(t, f.DeleteCells(sheet, "A5", ShiftCellsLeft), "merged cell A4:B5 is partially inside the range of cells to be moved")
+	assert.EqualError(t, f.DeleteCells(sheet, "A4:B4", ShiftCellsUp), "merged cell A4:B5 is partially inside the range of cells to be moved")
+	// test delete cells with invalid arguments.
+	assert.EqualError(t, f.DeleteCells(sheet, "A1:B", ShiftCellsUp), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+	assert.EqualError(t, f.DeleteCells(sheet, "A1:B1", ShiftCellsDown), "invalid shift direction 1")
+	assert.EqualError(t, f.DeleteCells("SheetN", "A1:B1", ShiftCellsUp), "sheet SheetN is not exist")
+}
+
+func TestDeleteCellsAdjustReferences(t *testing.T) {
+	sheet := "Sheet1"
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	for row := 1; row <= 5; row++ {
+		for col := 1; col <= 4; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			assert.NoError(t, f.SetCellValue(sheet, cell, cell))
+		}
+	}
+	assert.NoError(t, f.SetCellFormula(sheet, "F1", "SUM(B4:C5)+B1+D4"))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!C5+Sheet1!B2"))
+	assert.NoError(t, f.AddComment(sheet, "C4", `{"author":"Excelize: ","text":"This is a comment."}`))
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "B5:C5 D5"
+	assert.NoError(t, dvRange.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation(sheet, dvRange))
+	format, err := f.NewConditionalStyle(`{"font":{"color":"#9A0511"}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat(sheet, "B2:C3", fmt.Sprintf(`[{"type":"cell","criteria":">","format":%d,"value":"6"}]`, format)))
+
+	// Test delete cells and move the cells up, the references to the cells
+	// below the deleted cells are updated, and the references to the deleted
+	// cells are removed.
+	assert.NoError(t, f.DeleteCells(sheet, "B2:C3", ShiftCellsUp))
+	formula, err := f.GetCellFormula(sheet, "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B2:C3)+B1+D4", formula)
+	formula, err = f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!C3+Sheet1!#REF!", formula)
+	comments := f.GetComments()
+	if assert.Len(t, comments[sheet], 1) {
+		assert.Equal(t, "C2", comments[sheet][0].Ref)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	assert.NoError(t, err)
+	if assert.Len(t, xlsx.DataValidations.DataValidation, 1) {
+		assert.Equal(t, "B3:C3 D5", xlsx.DataValidations.DataValidation[0].Sqref)
+	}
+	conditionalFormats, err := f.GetConditionalFormats(sheet)
+	assert.NoError(t, err)
+	assert.Empty(t, conditionalFormats)
+
+	// Test delete cells and move the cells left, the formula moved with the
+	// cells of row 1 only takes the references to the cells of row 1 updated.
+	assert.NoError(t, f.DeleteCells(sheet, "A1", ShiftCellsLeft))
+	formula, err = f.GetCellFormula(sheet, "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B2:C3)+A1+D4", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteCellsAdjustReferences.xlsx")))
+}
+
+func TestFillDown(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC), "x", nil, 5}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{3, time.Date(2019, 10, 2, 0, 0, 0, 0, time.UTC), "y"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "A1*2"))
+	var err error
+	styles := make([]int, 2)
+	for idx, cell := range []string{"B1", "B2"} {
+		styles[idx], err = f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+	}
+
+	// Test extend the numeric and date series, and copy the values.
+	assert.NoError(t, f.FillDown("Sheet1", "A1:C2", "A3:C5"))
+	assert.NoError(t, f.FillDown("Sheet1", "D1:E1", "D2:E3"))
+	for cell, expected := range map[string]string{
+		"A3": "5", "A4": "7", "A5": "9", "C3": "x", "C4": "y", "C5": "x", "E2": "5", "E3": "5",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for row, expected := range []string{"43739", "43740", "43741", "43742", "43743"} {
+		assert.Equal(t, expected, xlsx.SheetData.Row[row].C[1].V)
+		assert.Equal(t, styles[row%2], xlsx.SheetData.Row[row].C[1].S)
+		cellType, err := f.GetCellType("Sheet1", xlsx.SheetData.Row[row].C[1].R)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeDate, cellType)
+	}
+	for cell, expected := range map[string]string{"D2": "A2*2", "D3": "A3*2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestFillDown.xlsx")))
+
+	// Test fill down with the shared formula.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "A1+1", FormulaOpts{Type: STCellFormulaTypeShared, Ref: "F1:F2"}))
+	assert.NoError(t, f.FillDown("Sheet1", "F2", "F3"))
+	formula, err := f.GetCellFormula("Sheet1", "F3")
+	assert.NoError(t, err)
+	assert.Equal(t, "A3+1", formula)
+
+	// Test fill down with invalid arguments.
+	assert.EqualError(t, f.FillDown("Sheet1", "A1:B2", "A3:A5"), "the columns of the destination range A3:A5 are not the same as the source range A1:B2")
+	assert.EqualError(t, f.FillDown("Sheet1", "A1:A2", "A2:A5"), "the destination range A2:A5 is not below the source range A1:A2")
+	assert.EqualError(t, f.FillDown("Sheet1", "A", "A3"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.FillDown("Sheet1", "A1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.FillDown("SheetN", "A1", "A2"), "sheet SheetN is not exist")
+}
+
+func TestGetCellType(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A3", "1/0"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", 1.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "text"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "A6", "inline"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A7", 43739))
+	style, err := f.NewStyle(`{"custom_number_format":"[$-409]\\d\\a\\y\\:\\ 0"}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A7", "A7", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A8", 43739))
+	style, err = f.NewStyle(`{"custom_number_format":"[h]:mm:ss"}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A8", "A8", style))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.SheetData.Row[2].C[0].T, xlsx.SheetData.Row[2].C[0].V = "e", "#DIV/0!"
+	xlsx.SheetData.Row[5].C[0].T = "inlineStr"
+	expected := []CellType{
+		CellTypeBool, CellTypeDate, CellTypeError, CellTypeNumber,
+		CellTypeString, CellTypeString, CellTypeNumber, CellTypeDate, CellTypeBlank,
+	}
+	check := func(col string, firstRow int) {
+		for idx, cellType := range expected {
+			cell := col + fmt.Sprint(firstRow+idx)
+			result, err := f.GetCellType("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, cellType, result, cell)
+		}
+	}
+	check("A", 1)
+
+	// Test the types of the cells are kept after inserting a row and a
+	// column.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.InsertCol("Sheet1", "A"))
+	check("B", 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCellType.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestGetCellType.xlsx"))
+	assert.NoError(t, err)
+	check("B", 2)
+
+	// Test get the type of the merged cell.
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C3"))
+	cellType, err := f.GetCellType("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeBool, cellType)
+
+	// Test get the type with invalid arguments.
+	_, err = f.GetCellType("Sheet1", "A")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	_, err = f.GetCellType("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestGetHyperLinks(t *testing.T) {
+	f := NewFile()
+	links, err := f.GetHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "data"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B3", "Sheet1!A5", "Location"))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.Hyperlinks.Hyperlink[1].Tooltip = "data"
+	xlsx.Hyperlinks.Hyperlink[1].Display = "Sheet1!A5"
+
+	// insert a row between the hyperlinks.
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	links, err = f.GetHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []HyperLink{
+		{Ref: "A1", Type: "External", Target: "https://github.com/360EntSecGroup-Skylar/excelize"},
+		{Ref: "B4", Type: "Location", Target: "Sheet1!A6", Tooltip: "data", Display: "Sheet1!A5"},
+	}, links)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetHyperLinks.xlsx")))
+
+	// test get hyperlinks on not exists worksheet.
+	_, err = f.GetHyperLinks("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestConvertFormulaReferences(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	formulas := map[string]string{
+		"B1": "$A$1*2",
+		"B2": "SUM($A$1:$A$3)+Sheet2!$B$2",
+		"B3": `"$A$1"&$A$1`,
+		"B4": "$A$1",
+	}
+	for cell, formula := range formulas {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	// Test convert the absolute references to the relative references only
+	// in the range.
+	assert.NoError(t, f.ConvertFormulaReferences("Sheet1", "B1:B3", false))
+	for cell, expected := range map[string]string{
+		"B1": "A1*2",
+		"B2": "SUM(A1:A3)+Sheet2!B2",
+		"B3": `"$A$1"&A1`,
+		"B4": "$A$1",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test convert the relative references to the absolute references.
+	assert.NoError(t, f.ConvertFormulaReferences("Sheet1", "B2", true))
+	formula, err := f.GetCellFormula("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM($A$1:$A$3)+Sheet2!$B$2", formula)
+
+	// Test convert the shared formulas in the range.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "$A1*2", FormulaOpts{Type: STCellFormulaTypeShared, Ref: "C1:C3"}))
+	assert.NoError(t, f.ConvertFormulaReferences("Sheet1", "C1:C2", true))
+	for cell, expected := range map[string]string{"C1": "$A$1*2", "C2": "$A$2*2", "C3": "$A3*2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestConvertFormulaReferences.xlsx")))
+
+	// Test convert the formulas with illegal range and on not exists worksheet.
+	assert.EqualError(t, f.ConvertFormulaReferences("Sheet1", "A", false), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.ConvertFormulaReferences("SheetN", "A1", false), "sheet SheetN is not exist")
+}
+
+func TestClearRange(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(`{"fill":{"type":"pattern","color":["#E0EBF5"],"pattern":1}}`)
+	assert.NoError(t, err)
+	for _, cell := range []string{"A1", "B2", "C3", "F6"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "F6", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B2"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C3", "E5"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "F6", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.AddComment("Sheet1", "A2", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "D1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A1:A2 D1"
+	assert.NoError(t, dvRange.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+
+	// Test clear range which partially overlaps merged cells C3:E5 without
+	// the clip option.
+	assert.EqualError(t, f.ClearRange("Sheet1", "A1:C3"), "merged cell C3:E5 partially overlaps the range A1:C3")
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", val)
+
+	// Test clear range which fully contains merged cells A1:B2 and partially
+	// overlaps merged cells C3:E5 with the clip option, the top-left cell of
+	// the merged cells C3:E5 is left unchanged.
+	assert.NoError(t, f.ClearRange("Sheet1", "A1:C3", ClearRangeOpts{Clip: true}))
+	for cell, expected := range map[string]string{"A1": "", "B2": "", "C3": "C3", "F6": "F6"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, styleID)
+	for _, cell := range []string{"C3", "F6"} {
+		styleID, err = f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID, cell)
+	}
+
+	// Test clear range which partially overlaps merged cells C3:E5 without
+	// the top-left cell of the merged cells.
+	assert.NoError(t, f.ClearRange("Sheet1", "D4:D5", ClearRangeOpts{Clip: true}))
+	val, err = f.GetCellValue("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, "C3", val)
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, xlsxC{R: "D4"}, xlsx.SheetData.Row[3].C[3])
+
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 1) {
+		assert.Equal(t, "C3", mergeCells[0].GetStartAxis())
+	}
+	links, err := f.GetHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, links, 1) {
+		assert.Equal(t, "F6", links[0].Ref)
+	}
+	comments := f.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "D1", comments[0].Ref)
+	}
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing1.vml"].Shape, 1)
+	assert.Equal(t, "D1", xlsx.DataValidations.DataValidation[0].Sqref)
+
+	// Test clear range which contains all the structures.
+	assert.NoError(t, f.ClearRange("Sheet1", "A1:F6"))
+	assert.Nil(t, xlsx.MergeCells)
+	assert.Nil(t, xlsx.Hyperlinks)
+	assert.Nil(t, xlsx.DataValidations)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClearRange.xlsx")))
+
+	// Test clear range with illegal range reference.
+	assert.EqualError(t, f.ClearRange("Sheet1", "A:B1"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// Test clear range on not exists worksheet.
+	assert.EqualError(t, f.ClearRange("SheetN", "A1"), "sheet SheetN is not exist")
+
+	// Test clear range which partially overlaps the data validations, the
+	// areas of the data validations are clipped with the clip option.
+	f = NewFile()
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "A1:C6 E5"
+	assert.NoError(t, dvRange.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.EqualError(t, f.ClearRange("Sheet1", "B3:B4"), "data validation A1:C6 partially overlaps the range B3:B4")
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C6 E5", xlsx.DataValidations.DataValidation[0].Sqref)
+	assert.NoError(t, f.ClearRange("Sheet1", "B3:B4", ClearRangeOpts{Clip: true}))
+	assert.Equal(t, "A1:C2 A3:A4 C3:C4 A5:C6 E5", xlsx.DataValidations.DataValidation[0].Sqref)
+	assert.NoError(t, f.ClearRange("Sheet1", "A5:E6", ClearRangeOpts{Clip: true}))
+	assert.Equal(t, "A1:C2 A3:A4 C3:C4", xlsx.DataValidations.DataValidation[0].Sqref)
+}
+
+func TestGetRangeStructures(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D4", "F5"))
+	assert.NoError(t, f.MergeCell("Sheet1", "H1", "I2"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B4", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "G6", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.AddComment("Sheet1", "A1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.AddComment("Sheet1", "E1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A10 D3:D6"
+	assert.NoError(t, dvRange.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	dvRange = NewDataValidation(true)
+	dvRange.Sqref = "H8:H10"
+	assert.NoError(t, dvRange.SetDropList([]string{"1", "2"}))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10 C1:C10", `[{"type":"cell","criteria":">","format":0,"value":"1"}]`))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "J1:J10", `[{"type":"cell","criteria":">","format":0,"value":"1"}]`))
+
+	// Test get the structures wholly inside, partially overlapping and out of
+	// the range.
+	structures, err := f.GetRangeStructures("Sheet1", "D4:A1")
+	assert.NoError(t, err)
+	assert.Equal(t, RangeStructures{
+		MergeCells:         []string{"B2:C3", "D4:F5"},
+		Hyperlinks:         []string{"B4"},
+		Comments:           []string{"A1"},
+		DataValidations:    []string{"A10 D3:D6"},
+		ConditionalFormats: []string{"A1:A10 C1:C10"},
+	}, structures)
+
+	// Test get the structures of a single cell.
+	structures, err = f.GetRangeStructures("Sheet1", "G6")
+	assert.NoError(t, err)
+	assert.Equal(t, RangeStructures{Hyperlinks: []string{"G6"}}, structures)
+
+	// Test get the structures of a range without any structure.
+	structures, err = f.GetRangeStructures("Sheet1", "K1:L10")
+	assert.NoError(t, err)
+	assert.Equal(t, RangeStructures{}, structures)
+
+	_, err = f.GetRangeStructures("Sheet1", "A:B")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	_, err = f.GetRangeStructures("SheetN", "A1:B2")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
@@ -1,6 +1,9 @@
 package excelize
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // GetMergeCells provides a function to get all merged cells from a worksheet currently.
 func (f *File) GetMergeCells(sheet string) ([]MergeCell, error) {
@@ -45,4 +48,189 @@ func (m *MergeCell) GetStartAxis() string {
 func (m *MergeCell) GetEndAxis() string {
 	axis := strings.Split((*m)[0], ":")
 	return axis[1]
-}
\ No newline at end of file
+}
+
+// SetMergeCellStyle provides a function to set the style of merged cells by
+// given worksheet name, coordinate area and style ID. The area will be
+// expanded to cover every merged cell overlapping it, and the style is
+// applied to all of the underlying cells, so that borders and fills are
+// rendered across the whole merged cell. For example, merge Sheet1!D3:E9 and
+// set the borders of the merged cell:
+//
+//    err := f.MergeCell("Sheet1", "D3", "E9")
+//    style, err := f.NewStyle(`{"border":[{"type":"left","color":"0000FF","style":2},{"type":"top","color":"0000FF","style":2},{"type":"bottom","color":"0000FF","style":2},{"type":"right","color":"0000FF","style":2}]}`)
+//    err = f.SetMergeCellStyle("Sheet1", "D3", "E9", style)
+//
+func (f *File) SetMergeCellStyle(sheet, hcell, vcell string, styleID int) error {
+	area, err := areaRefToCoordinates(hcell + ":" + vcell)
+	if err != nil {
+		return err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if xlsx.MergeCells != nil {
+		// Expand the area until it doesn't partially overlap any merged cell.
+		for expanded := true; expanded; {
+			expanded = false
+			for _, mergeCell := range xlsx.MergeCells.Cells {
+				rect, err := areaRefToCoordinates(mergeCell.Ref)
+				if err != nil {
+					return err
+				}
+				if rect[0] > area[2] || rect[2] < area[0] || rect[1] > area[3] || rect[3] < area[1] {
+					continue
+				}
+				for i, v := range rect {
+					if (i < 2 && v < area[i]) || (i >= 2 && v > area[i]) {
+						area[i], expanded = v, true
+					}
+				}
+			}
+		}
+	}
+	hcell, _ = CoordinatesToCellName(area[0], area[1])
+	vcell, _ = CoordinatesToCellName(area[2], area[3])
+	return f.SetCellStyle(sheet, hcell, vcell, styleID)
+}
+
+// RepairMergeCells provides a function to remove the orphaned merged cells
+// of the worksheet by given worksheet name, such as those left pointing at
+// the deleted rows or columns after the worksheet has been edited by other
+// applications. The merged cells with invalid references, the merged cells of
+// a single cell and the merged cells beyond the maximum rows and columns of
+// the worksheet are removed, the merged cells of the empty cells are kept.
+// For example, repair the merged cells of Sheet1:
+//
+//    err := f.RepairMergeCells("Sheet1")
+//
+func (f *File) RepairMergeCells(sheet string) error {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if xlsx.MergeCells == nil {
+		return err
+	}
+	mergeCells := xlsx.MergeCells.Cells[:0]
+	for _, mergeCell := range xlsx.MergeCells.Cells {
+		if mergeCell == nil {
+			continue
+		}
+		rect, err := areaRefToCoordinates(mergeCell.Ref)
+		if err != nil || (rect[0] == rect[2] && rect[1] == rect[3]) || rect[2] > TotalColumns || rect[3] > TotalRows {
+			continue
+		}
+		mergeCells = append(mergeCells, mergeCell)
+	}
+	if len(mergeCells) == 0 {
+		xlsx.MergeCells = nil
+		return err
+	}
+	xlsx.MergeCells.Cells = mergeCells
+	xlsx.MergeCells.Count = len(mergeCells)
+	return err
+}
+
+// MergeSameValue provides a function to merge the adjacent cells with the
+// same value in the given range of the worksheet into the merged cells, like
+// the groups of a grouped report. The cells of each column are merged
+// vertically, and the cells of the range in a single row are merged
+// horizontally. The empty cells are not merged, and only the value of the
+// first cell of each merged cells is kept. It returns an error if any merged
+// cells to be created overlaps the existing merged cells. For example, merge
+// the repeated labels in the column A of Sheet1:
+//
+//    err := f.MergeSameValue("Sheet1", "A2:A20")
+//
+func (f *File) MergeSameValue(sheet, rangeRef string) error {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef = rangeRef + ":" + rangeRef
+	}
+	coordinates, err := areaRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	d := f.sharedStringsReader()
+	value := func(col, row int) string {
+		if row > len(xlsx.SheetData.Row) || col > len(xlsx.SheetData.Row[row-1].C) {
+			return ""
+		}
+		val, _ := xlsx.SheetData.Row[row-1].C[col-1].getValueFrom(f, d)
+		return val
+	}
+	// Find the runs of the adjacent cells with the same value in each line.
+	var lines [][][]int
+	if coordinates[1] == coordinates[3] {
+		var line [][]int
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			line = append(line, []int{col, coordinates[1]})
+		}
+		lines = append(lines, line)
+	} else {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			var line [][]int
+			for row := coordinates[1]; row <= coordinates[3]; row++ {
+				line = append(line, []int{col, row})
+			}
+			lines = append(lines, line)
+		}
+	}
+	var areas [][]int
+	for _, line := range lines {
+		start, startValue := 0, value(line[0][0], line[0][1])
+		for i := 1; i <= len(line); i++ {
+			var val string
+			if i < len(line) {
+				if val = value(line[i][0], line[i][1]); val == startValue {
+					continue
+				}
+			}
+			if i-start > 1 && startValue != "" {
+				areas = append(areas, []int{line[start][0], line[start][1], line[i-1][0], line[i-1][1]})
+			}
+			start, startValue = i, val
+		}
+	}
+	// Check the overlapping with the existing merged cells before merging.
+	var mergeCells []*xlsxMergeCell
+	if xlsx.MergeCells != nil {
+		mergeCells = xlsx.MergeCells.Cells
+	}
+	for _, area := range areas {
+		for _, mergeCell := range mergeCells {
+			rect, err := areaRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return err
+			}
+			if areaOverlaps(area, rect) {
+				hcell, _ := CoordinatesToCellName(area[0], area[1])
+				vcell, _ := CoordinatesToCellName(area[2], area[3])
+				return fmt.Errorf("merged cells %s overlaps with %s", hcell+":"+vcell, mergeCell.Ref)
+			}
+		}
+	}
+	for _, area := range areas {
+		for row := area[1]; row <= area[3]; row++ {
+			for col := area[0]; col <= area[2]; col++ {
+				if col == area[0] && row == area[1] {
+					continue
+				}
+				c := &xlsx.SheetData.Row[row-1].C[col-1]
+				c.T, c.V, c.F, c.IS = "", "", nil, nil
+			}
+		}
+		hcell, _ := CoordinatesToCellName(area[0], area[1])
+		vcell, _ := CoordinatesToCellName(area[2], area[3])
+		mergeCells = append(mergeCells, &xlsxMergeCell{Ref: hcell + ":" + vcell})
+	}
+	if len(areas) > 0 {
+		xlsx.MergeCells = &xlsxMergeCells{Count: len(mergeCells), Cells: mergeCells}
+	}
+	return err
+}
@@ -52,3 +52,141 @@ func TestGetMergeCells(t *testing.T) {
 	_, err = f.GetMergeCells("SheetN")
 	assert.EqualError(t, err, "sheet SheetN is not exist")
 }
+
+func TestSetMergeCellStyle(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "merged"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C4"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D4", "E5"))
+	style, err := f.NewStyle(`{"border":[{"type":"left","color":"0000FF","style":2},{"type":"top","color":"0000FF","style":2},{"type":"bottom","color":"0000FF","style":2},{"type":"right","color":"0000FF","style":2}]}`)
+	assert.NoError(t, err)
+	// the area overlaps both of the merged cells.
+	assert.NoError(t, f.SetMergeCellStyle("Sheet1", "C3", "D4", style))
+	for row := 1; row <= 6; row++ {
+		for col := 1; col <= 6; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			// the area is expanded to cover both of the merged cells.
+			ok, _ := checkCellInArea(cell, "B2:E5")
+			expected := 0
+			if ok {
+				expected = style
+			}
+			cellStyle, err := f.GetCellStyle("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, cellStyle, cell)
+		}
+	}
+	// the style is moved with the merged cell.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B3", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "C5", mergeCells[0].GetEndAxis())
+	for _, cell := range []string{"B3", "C3", "B5", "C5"} {
+		cellStyle, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, cellStyle, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetMergeCellStyle.xlsx")))
+
+	// test set merge cell style with illegal cell coordinates.
+	assert.EqualError(t, f.SetMergeCellStyle("Sheet1", "A", "B1", style), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	// test set merge cell style on not exists worksheet.
+	assert.EqualError(t, f.SetMergeCellStyle("SheetN", "A1", "B1", style), "sheet SheetN is not exist")
+	// test set merge cell style with illegal merged cell.
+	f.Sheet["xl/worksheets/sheet1.xml"].MergeCells.Cells[0].Ref = "A1:B"
+	assert.EqualError(t, f.SetMergeCellStyle("Sheet1", "A1", "B1", style), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
+}
+
+func TestRepairMergeCells(t *testing.T) {
+	f := NewFile()
+	f.XLSX["xl/worksheets/sheet1.xml"] = []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>1</v></c><c r="C1"><v>3</v></c></row><row r="3"><c r="B3"><v>2</v></c></row></sheetData><mergeCells count="8"><mergeCell ref="A1:B1"/><mergeCell ref="B2:C3"/><mergeCell ref="A3:A4"/><mergeCell ref="C1:D1"/><mergeCell ref="A"/><mergeCell ref="B5:B5"/><mergeCell ref="XFD1:XFE1"/><mergeCell ref="A1048576:A1048577"/></mergeCells></worksheet>`)
+	assert.NoError(t, f.RepairMergeCells("Sheet1"))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, xlsx.MergeCells.Cells, 4) {
+		assert.Equal(t, 4, xlsx.MergeCells.Count)
+		for i, ref := range []string{"A1:B1", "B2:C3", "A3:A4", "C1:D1"} {
+			assert.Equal(t, ref, xlsx.MergeCells.Cells[i].Ref)
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRepairMergeCells.xlsx")))
+
+	// Test the merged cells of the empty cells beyond the last cell of the
+	// worksheet are kept.
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Title"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "D1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 2))
+	assert.NoError(t, f.RepairMergeCells("Sheet1"))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 1) {
+		assert.Equal(t, "A1", mergeCells[0].GetStartAxis())
+		assert.Equal(t, "D1", mergeCells[0].GetEndAxis())
+	}
+
+	// Test remove all the orphaned merged cells.
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.MergeCells.Cells = []*xlsxMergeCell{{Ref: "D1"}, nil}
+	assert.NoError(t, f.RepairMergeCells("Sheet1"))
+	assert.Nil(t, xlsx.MergeCells)
+	assert.NoError(t, f.RepairMergeCells("Sheet1"))
+
+	// Test repair merged cells on not exists worksheet.
+	assert.EqualError(t, f.RepairMergeCells("SheetN"), "sheet SheetN is not exist")
+}
+
+func TestMergeSameValue(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Region", "Product", "Amount"},
+		{"East", "Apple", 1},
+		{"East", "Apple", 2},
+		{"East", "Pear", 2},
+		{"West", "Pear", 4},
+		{"West", nil, 5},
+		{"North", nil, 6},
+	} {
+		cell, _ := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	// Test merge the repeated labels of the columns into the groups.
+	assert.NoError(t, f.MergeSameValue("Sheet1", "A2:B7"))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A2:A4", "East"}, {"A5:A6", "West"}, {"B2:B3", "Apple"}, {"B4:B5", "Pear"}}, mergeCells)
+	for _, cell := range []string{"A3", "A4", "A6", "B3", "B5"} {
+		xlsx, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		col, row, _ := CellNameToCoordinates(cell)
+		assert.Empty(t, xlsx.SheetData.Row[row-1].C[col-1].V, cell)
+	}
+	// Test the merged groups are adjusted when inserting a row.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, MergeCell{"A2:A5", "East"}, mergeCells[0])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMergeSameValue.xlsx")))
+
+	// Test merge the cells of a single row horizontally.
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A10", &[]interface{}{"Total", "Total", 21, 21}))
+	assert.NoError(t, f.MergeSameValue("Sheet1", "A10:D10"))
+	mergeCells, err = f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []MergeCell{{"A10:B10", "Total"}, {"C10:D10", "21"}}, mergeCells[4:])
+
+	// Test merge the cells overlapping the existing merged cells.
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"x"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"x"}))
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "B3"))
+	assert.EqualError(t, f.MergeSameValue("Sheet1", "A1:A2"), "merged cells A1:A2 overlaps with A2:B3")
+	assert.NoError(t, f.MergeSameValue("Sheet1", "C1"))
+
+	// Test merge the cells with illegal range and on not exists worksheet.
+	assert.EqualError(t, f.MergeSameValue("Sheet1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.MergeSameValue("SheetN", "A1:A2"), "sheet SheetN is not exist")
+}
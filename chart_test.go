@@ -3,6 +3,7 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -101,3 +102,26 @@ func TestAddDrawingChart(t *testing.T) {
 	f := NewFile()
 	assert.EqualError(t, f.addDrawingChart("SheetN", "", "", 0, 0, 0, nil), `cannot convert cell "" to coordinates: invalid cell name ""`)
 }
+
+func TestAdjustChartDefinedName(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(idx+1), &row))
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Fruits", RefersTo: "Sheet1!$B$1:$D$1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$B$2:$D$3", Scope: "Sheet1"}))
+	assert.NoError(t, f.AddChart("Sheet1", "E4", `{"type":"col","series":[{"name":"Sheet1!$A$2","categories":"[0]!Fruits","values":"Sheet1!Amount"}],"title":{"name":"Fruit Column Chart"}}`))
+
+	// Test the defined names used by the chart grow after inserting a row
+	// inside of the data, and the chart is still bound to the names.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.NoError(t, f.InsertCol("Sheet1", "C"))
+	refersTo := map[string]string{}
+	for _, definedName := range f.GetDefinedName() {
+		refersTo[definedName.Name] = definedName.RefersTo
+	}
+	assert.Equal(t, map[string]string{"Fruits": "Sheet1!$B$1:$E$1", "Amount": "Sheet1!$B$2:$E$4"}, refersTo)
+	chart := string(f.XLSX["xl/charts/chart1.xml"])
+	assert.Contains(t, chart, "<c:f>[0]!Fruits</c:f>")
+	assert.Contains(t, chart, "<c:f>Sheet1!Amount</c:f>")
+}
@@ -9,15 +9,95 @@
 
 package excelize
 
-import "math"
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
 
 // Define the default cell size and EMU unit of measurement.
 const (
+	defaultColWidth        float64 = 8.43
 	defaultColWidthPixels  float64 = 64
+	defaultRowHeight       float64 = 15
 	defaultRowHeightPixels float64 = 20
+	defaultFontSize        float64 = 11
+	maxColWidth            float64 = 255
+	maxRowHeight           float64 = 409
 	EMU                    int     = 9525
 )
 
+// ColIterator defines an iterator to the columns of a worksheet.
+type ColIterator struct {
+	col, totalCols int
+	sheet          *xlsxWorksheet
+	f              *File
+}
+
+// Next will return true if find the next column.
+func (cols *ColIterator) Next() bool {
+	cols.col++
+	return cols.col <= cols.totalCols
+}
+
+// Error will return the error when the find next column, it always returns
+// nil because the columns are read from the worksheet in memory.
+func (cols *ColIterator) Error() error {
+	return nil
+}
+
+// Rows return the current column's cell values of each row, the trailing
+// empty cells of the column are trimmed.
+func (cols *ColIterator) Rows() ([]string, error) {
+	if cols.col < 1 || cols.col > cols.totalCols {
+		return []string{}, nil
+	}
+	d := cols.f.sharedStringsReader()
+	rows := make([]string, len(cols.sheet.SheetData.Row))
+	var count int
+	for rowIdx, rowData := range cols.sheet.SheetData.Row {
+		if cols.col > len(rowData.C) {
+			continue
+		}
+		val, err := rowData.C[cols.col-1].getValueFrom(cols.f, d)
+		if err != nil {
+			return rows[:count], err
+		}
+		if rows[rowIdx] = val; val != "" {
+			count = rowIdx + 1
+		}
+	}
+	return rows[:count], nil
+}
+
+// Cols return a columns iterator, the cell values are read from the
+// worksheet in memory column by column, so the changes of the worksheet are
+// reflected. For example:
+//
+//    cols, err := f.Cols("Sheet1")
+//    for cols.Next() {
+//        col, err := cols.Rows()
+//        for _, rowCell := range col {
+//            fmt.Print(rowCell, "\t")
+//        }
+//        fmt.Println()
+//    }
+//
+func (f *File) Cols(sheet string) (*ColIterator, error) {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	cols := ColIterator{sheet: xlsx, f: f}
+	for _, rowData := range xlsx.SheetData.Row {
+		if len(rowData.C) > cols.totalCols {
+			cols.totalCols = len(rowData.C)
+		}
+	}
+	return &cols, nil
+}
+
 // GetColVisible provides a function to get visible of a single column by given
 // worksheet name and column name. For example, get visible state of column D
 // in Sheet1:
@@ -155,6 +235,97 @@ func (f *File) SetColOutlineLevel(sheet, col string, level uint8) error {
 	return err
 }
 
+// SetColStyle provides a function to set style of columns by given worksheet
+// name, columns range and style ID. The style is only set on the columns, so
+// the existing cells of the columns are not written, and the cells without
+// their own style will get the style of the columns when reading or writing
+// them. The other settings of the columns, such as the width and the
+// visibility, are kept. For example set style of column H on Sheet1:
+//
+//    err = f.SetColStyle("Sheet1", "H", style)
+//
+// Set style of columns C:F on Sheet1:
+//
+//    err = f.SetColStyle("Sheet1", "C:F", style)
+//
+func (f *File) SetColStyle(sheet, columns string, styleID int) error {
+	cols := strings.Split(columns, ":")
+	if len(cols) > 2 {
+		return newInvalidColumnNameError(columns)
+	}
+	min, err := ColumnNameToNumber(cols[0])
+	if err != nil {
+		return err
+	}
+	max := min
+	if len(cols) == 2 {
+		if max, err = ColumnNameToNumber(cols[1]); err != nil {
+			return err
+		}
+	}
+	if min > max {
+		min, max = max, min
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if xlsx.Cols == nil {
+		xlsx.Cols = &xlsxCols{}
+	}
+	// The columns without any settings get the default width of the
+	// worksheet, so only the style of them is changed.
+	width := defaultColWidth
+	if xlsx.SheetFormatPr != nil && xlsx.SheetFormatPr.DefaultColWidth != 0 {
+		width = xlsx.SheetFormatPr.DefaultColWidth
+	}
+	sortCols(xlsx.Cols.Col)
+	// Split the columns which have been set at the bounds of the columns, so
+	// the other settings of them are kept.
+	styled := make([]xlsxCol, 0, len(xlsx.Cols.Col)+2)
+	next := min
+	for _, c := range xlsx.Cols.Col {
+		if c.Max < min || c.Min > max {
+			styled = append(styled, c)
+			continue
+		}
+		if c.Min < min {
+			before := c
+			before.Max = min - 1
+			styled = append(styled, before)
+			c.Min = min
+		}
+		if c.Min > next {
+			styled = append(styled, xlsxCol{Min: next, Max: c.Min - 1, Width: width, Style: styleID})
+		}
+		after := c
+		if c.Max > max {
+			c.Max = max
+		}
+		c.Style = styleID
+		styled = append(styled, c)
+		next = c.Max + 1
+		if after.Max > max {
+			after.Min = max + 1
+			styled = append(styled, after)
+		}
+	}
+	if next <= max {
+		styled = append(styled, xlsxCol{Min: next, Max: max, Width: width, Style: styleID})
+	}
+	sortCols(styled)
+	xlsx.Cols.Col = styled
+	return err
+}
+
+// sortCols provides a function to sort the columns information of the
+// worksheet by the first column of them.
+func sortCols(cols []xlsxCol) {
+	sort.SliceStable(cols, func(i, j int) bool {
+		return cols[i].Min < cols[j].Min
+	})
+}
+
 // SetColWidth provides a function to set the width of a single column or
 // multiple columns. For example:
 //
@@ -194,6 +365,136 @@ func (f *File) SetColWidth(sheet, startcol, endcol string, width float64) error
 	return err
 }
 
+// SetColWidthAutoFit provides a function to set the width of the column to
+// fit the widest displayed value of its cells by given worksheet name and
+// column name. The width of the values formatted by the number formats is
+// estimated by the approximate character widths of the cell fonts, the style
+// of the column and then the style of the row are used for the cells without
+// style, and the merged cells are skipped. The width is left unchanged if the column has no
+// value. For example, fit the width of column A in Sheet1:
+//
+//    err := f.SetColWidthAutoFit("Sheet1", "A")
+//
+func (f *File) SetColWidthAutoFit(sheet, col string) error {
+	colNum, err := ColumnNameToNumber(col)
+	if err != nil {
+		return err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var merged [][]int
+	if xlsx.MergeCells != nil {
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			rect, err := areaRefToCoordinates(mergeCell.Ref)
+			if err == nil && rect[0] <= colNum && colNum <= rect[2] {
+				merged = append(merged, rect)
+			}
+		}
+	}
+	isMerged := func(row int) bool {
+		for _, rect := range merged {
+			if rect[1] <= row && row <= rect[3] {
+				return true
+			}
+		}
+		return false
+	}
+	d := f.sharedStringsReader()
+	var width float64
+	for _, rowData := range xlsx.SheetData.Row {
+		if len(rowData.C) < colNum || isMerged(rowData.R) {
+			continue
+		}
+		c := rowData.C[colNum-1]
+		if c.S = f.prepareCellStyle(xlsx, colNum, c.S); c.S == 0 && rowData.CustomFormat {
+			c.S = rowData.S
+		}
+		value, err := c.getValueFrom(f, d)
+		if err != nil {
+			return err
+		}
+		if w := f.textWidth(value, c.S); w > width {
+			width = w
+		}
+	}
+	if width == 0 {
+		return err
+	}
+	return f.SetColWidth(sheet, col, col, math.Min(math.Ceil(width)+1, maxColWidth))
+}
+
+// textWidth provides a function to estimate the width of the text in the
+// number of characters of the maximum digit width by given text and cell
+// style index. The widths of the characters are approximated for the
+// default font, and scaled by the size and weight of the cell font.
+func (f *File) textWidth(text string, styleID int) float64 {
+	size, bold := f.cellFont(styleID)
+	var width float64
+	for _, line := range strings.Split(text, "\n") {
+		var w float64
+		for _, r := range line {
+			switch {
+			case strings.ContainsRune(" !'(),./:;I[]`fijlrt|", r):
+				w += 0.5
+			case strings.ContainsRune("%@MWmw", r):
+				w += 1.5
+			case unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana):
+				w += 2
+			case unicode.IsUpper(r):
+				w += 1.2
+			default:
+				w++
+			}
+		}
+		if w > width {
+			width = w
+		}
+	}
+	width *= size / defaultFontSize
+	if bold {
+		width *= 1.1
+	}
+	return width
+}
+
+// cellFont provides a function to get the size and weight of the font by
+// given cell style index. The size of the default font is returned if the
+// font of the style is not found.
+func (f *File) cellFont(styleID int) (float64, bool) {
+	styleSheet := f.stylesReader()
+	if styleSheet.CellXfs == nil || styleID >= len(styleSheet.CellXfs.Xf) || styleSheet.Fonts == nil {
+		return defaultFontSize, false
+	}
+	fontID := styleSheet.CellXfs.Xf[styleID].FontID
+	if fontID >= len(styleSheet.Fonts.Font) {
+		return defaultFontSize, false
+	}
+	font := extractFont(styleSheet.Fonts.Font[fontID])
+	if font.Size > 0 {
+		return float64(font.Size), font.Bold
+	}
+	return defaultFontSize, font.Bold
+}
+
+// colWidth provides a function to get the width of the column in the number
+// of characters by given worksheet and column index.
+func colWidth(xlsx *xlsxWorksheet, col int) float64 {
+	width := defaultColWidth
+	if xlsx.SheetFormatPr != nil && xlsx.SheetFormatPr.DefaultColWidth != 0 {
+		width = xlsx.SheetFormatPr.DefaultColWidth
+	}
+	if xlsx.Cols != nil {
+		for _, c := range xlsx.Cols.Col {
+			if c.Min <= col && col <= c.Max && c.Width != 0 {
+				width = c.Width
+			}
+		}
+	}
+	return width
+}
+
 // positionObjectPixels calculate the vertices that define the position of a
 // graphical object within the worksheet in pixels.
 //
@@ -350,7 +651,9 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 //
 //    err := f.InsertCol("Sheet1", "C")
 //
-func (f *File) InsertCol(sheet, col string) error {
+func (f *File) InsertCol(sheet, col string) (err error) {
+	defer f.recoverAdjust(f.takeAdjustSnapshot(), &err)
+
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
@@ -367,7 +670,9 @@ func (f *File) InsertCol(sheet, col string) error {
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) RemoveCol(sheet, col string) error {
+func (f *File) RemoveCol(sheet, col string) (err error) {
+	defer f.recoverAdjust(f.takeAdjustSnapshot(), &err)
+
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
@@ -377,6 +682,9 @@ func (f *File) RemoveCol(sheet, col string) error {
 	if err != nil {
 		return err
 	}
+	if err = f.promoteSharedFormulas(xlsx, columns, newAdjustMapping(num, -1)); err != nil {
+		return err
+	}
 	for rowIdx := range xlsx.SheetData.Row {
 		rowData := &xlsx.SheetData.Row[rowIdx]
 		for colIdx := range rowData.C {
@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -67,6 +68,20 @@ func (f *File) getSheetComments(sheetID int) string {
 	return ""
 }
 
+// getSheetThreadedComments provides the method to get the target threaded
+// comments reference by given worksheet file path.
+func (f *File) getSheetThreadedComments(sheetID int) string {
+	var rels = "xl/worksheets/_rels/sheet" + strconv.Itoa(sheetID) + ".xml.rels"
+	if sheetRels := f.workSheetRelsReader(rels); sheetRels != nil {
+		for _, v := range sheetRels.Relationships {
+			if v.Type == SourceRelationshipThreadedComment {
+				return v.Target
+			}
+		}
+	}
+	return ""
+}
+
 // AddComment provides the method to add comment in a sheet by given worksheet
 // index, cell and format set (such as author and text). Note that the max
 // author length is 255 and the max text length is 32512. For example, add a
@@ -118,6 +133,28 @@ func (f *File) AddComment(sheet, cell, format string) error {
 	return err
 }
 
+// DeleteComment provides the method to delete comment in a sheet by given
+// worksheet name and cell. The shape of the comment will be removed, and the
+// authors which have no comments will be removed from the list of authors.
+// For example, delete the comment in Sheet1!$A$30:
+//
+//    err := f.DeleteComment("Sheet1", "A30")
+//
+func (f *File) DeleteComment(sheet, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	f.deleteComments(sheet, xlsx, func(c, r int) bool {
+		return c == col && r == row
+	})
+	return err
+}
+
 // addDrawingVML provides a function to create comment as
 // xl/drawings/vmlDrawing%d.vml by given commit ID and cell.
 func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount, colCount int) error {
@@ -127,35 +164,7 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 	}
 	yAxis := col - 1
 	xAxis := row - 1
-	vml := f.VMLDrawing[drawingVML]
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv:  "urn:schemas-microsoft-com:vml",
-			XMLNSo:  "urn:schemas-microsoft-com:office:office",
-			XMLNSx:  "urn:schemas-microsoft-com:office:excel",
-			XMLNSmv: "http://macVmlSchemaUri",
-			Shapelayout: &xlsxShapelayout{
-				Ext: "edit",
-				IDmap: &xlsxIDmap{
-					Ext:  "edit",
-					Data: commentID,
-				},
-			},
-			Shapetype: &xlsxShapetype{
-				ID:        "_x0000_t202",
-				Coordsize: "21600,21600",
-				Spt:       202,
-				Path:      "m0,0l0,21600,21600,21600,21600,0xe",
-				Stroke: &xlsxStroke{
-					Joinstyle: "miter",
-				},
-				VPath: &vPath{
-					Gradientshapeok: "t",
-					Connecttype:     "miter",
-				},
-			},
-		}
-	}
+	vml := f.vmlDrawingReader(commentID, drawingVML)
 	sp := encodeShape{
 		Fill: &vFill{
 			Color2: "#fbfe82",
@@ -199,23 +208,229 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 		Strokecolor: "#edeaa1",
 		Val:         string(s[13 : len(s)-14]),
 	}
-	d := f.decodeVMLDrawingReader(drawingVML)
-	if d != nil {
+	vml.Shape = append(vml.Shape, shape)
+	return err
+}
+
+// vmlDrawingReader provides a function to get the pointer to the structure
+// of xl/drawings/vmlDrawing%d.vml for editing, the shapes of the existing
+// drawing will be loaded on first access.
+func (f *File) vmlDrawingReader(commentID int, drawingVML string) *vmlDrawing {
+	vml := f.VMLDrawing[drawingVML]
+	if vml != nil {
+		return vml
+	}
+	vml = &vmlDrawing{
+		XMLNSv:  "urn:schemas-microsoft-com:vml",
+		XMLNSo:  "urn:schemas-microsoft-com:office:office",
+		XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+		XMLNSmv: "http://macVmlSchemaUri",
+		Shapelayout: &xlsxShapelayout{
+			Ext: "edit",
+			IDmap: &xlsxIDmap{
+				Ext:  "edit",
+				Data: strconv.Itoa(commentID),
+			},
+		},
+		Shapetype: &xlsxShapetype{
+			ID:        "_x0000_t202",
+			Coordsize: "21600,21600",
+			Spt:       202,
+			Path:      "m0,0l0,21600,21600,21600,21600,0xe",
+			Stroke: &xlsxStroke{
+				Joinstyle: "miter",
+			},
+			VPath: &vPath{
+				Gradientshapeok: "t",
+				Connecttype:     "miter",
+			},
+		},
+	}
+	if d := f.decodeVMLDrawingReader(drawingVML); d != nil {
+		if d.Shapelayout != nil && d.Shapelayout.IDmap != nil && d.Shapelayout.IDmap.Data != "" {
+			vml.Shapelayout.IDmap.Data = d.Shapelayout.IDmap.Data
+		}
+		// Keep the shapetypes of the existing drawing, such as the shapetype
+		// of the form controls.
+		for _, v := range d.Shapetype {
+			if v.ID == vml.Shapetype.ID {
+				vml.Shapetype = nil
+			}
+			vml.Shapetypes = append(vml.Shapetypes, vmlShapetype{ID: v.ID, Attr: vmlAttrs(v.Attr), Val: v.Val})
+		}
 		for _, v := range d.Shape {
+			// Keep the attributes of the existing shape, such as the
+			// visibility of the comment in the style.
 			s := xlsxShape{
-				ID:          "_x0000_s1025",
-				Type:        "#_x0000_t202",
-				Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
-				Fillcolor:   "#fbf6d6",
-				Strokecolor: "#edeaa1",
+				ID:          v.ID,
+				Type:        v.Type,
+				Style:       v.Style,
+				Fillcolor:   v.Fillcolor,
+				Insetmode:   v.Insetmode,
+				Strokecolor: v.Strokecolor,
+				Attr:        vmlAttrs(v.Attr),
 				Val:         v.Val,
 			}
+			if s.ID == "" {
+				s.ID = "_x0000_s1025"
+			}
+			if s.Type == "" {
+				s.Type = "#_x0000_t202"
+			}
+			if s.Style == "" {
+				s.Style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
+			}
 			vml.Shape = append(vml.Shape, s)
 		}
 	}
-	vml.Shape = append(vml.Shape, shape)
 	f.VMLDrawing[drawingVML] = vml
-	return err
+	return vml
+}
+
+// vmlNameSpacePrefixes defines the prefixes of the namespaces of the
+// attributes in the VML drawing.
+var vmlNameSpacePrefixes = map[string]string{
+	"urn:schemas-microsoft-com:vml":               "v",
+	"urn:schemas-microsoft-com:office:office":     "o",
+	"urn:schemas-microsoft-com:office:excel":      "x",
+	"urn:schemas-microsoft-com:office:powerpoint": "p",
+}
+
+// vmlAttrs provides a function to convert the namespaces of the attributes
+// parsed from the VML drawing to the prefixes declared in the root element of
+// the drawing, so that the attributes are serialized with the same names.
+func vmlAttrs(attrs []xml.Attr) []xml.Attr {
+	converted := make([]xml.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		if prefix, ok := vmlNameSpacePrefixes[attr.Name.Space]; ok {
+			attr.Name = xml.Name{Local: prefix + ":" + attr.Name.Local}
+		}
+		converted = append(converted, attr)
+	}
+	return converted
+}
+
+// vmlShapeRowRegexp and vmlShapeColumnRegexp match the zero-based row and
+// column number of the cell which the shape of the comment is anchored to.
+var (
+	vmlShapeRowRegexp    = regexp.MustCompile(`<x:Row>(\d+)</x:Row>`)
+	vmlShapeColumnRegexp = regexp.MustCompile(`<x:Column>(\d+)</x:Column>`)
+)
+
+// vmlShapeCell provides a function to get the one-based column and row number
+// of the cell which the shape of the comment is anchored to. The third return
+// value reports whether the shape is anchored to a cell.
+func vmlShapeCell(shape xlsxShape) (int, int, bool) {
+	rowMatch := vmlShapeRowRegexp.FindStringSubmatch(shape.Val)
+	colMatch := vmlShapeColumnRegexp.FindStringSubmatch(shape.Val)
+	if rowMatch == nil || colMatch == nil {
+		return 0, 0, false
+	}
+	row, _ := strconv.Atoi(rowMatch[1])
+	col, _ := strconv.Atoi(colMatch[1])
+	return col + 1, row + 1, true
+}
+
+// sheetCommentsReader provides a function to get the comments and the
+// drawing of the shapes of the comments in the worksheet. It returns nil if
+// the worksheet doesn't have any comment.
+func (f *File) sheetCommentsReader(sheet string, xlsx *xlsxWorksheet) (*xlsxComments, *vmlDrawing) {
+	if xlsx.LegacyDrawing == nil {
+		return nil, nil
+	}
+	comments := f.commentsReader("xl" + strings.TrimPrefix(f.getSheetComments(f.GetSheetIndex(sheet)), ".."))
+	sheetRelationshipsDrawingVML := f.getSheetRelationshipsTargetByID(sheet, xlsx.LegacyDrawing.RID)
+	if sheetRelationshipsDrawingVML == "" {
+		return comments, nil
+	}
+	commentID, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(sheetRelationshipsDrawingVML, "../drawings/vmlDrawing"), ".vml"))
+	return comments, f.vmlDrawingReader(commentID, strings.Replace(sheetRelationshipsDrawingVML, "..", "xl", -1))
+}
+
+// deleteComments provides a function to delete the comments and the shapes
+// of the comments in the worksheet which the given function reports true for
+// the column and row number of the cell.
+func (f *File) deleteComments(sheet string, xlsx *xlsxWorksheet, fn func(col, row int) bool) {
+	comments, vml := f.sheetCommentsReader(sheet, xlsx)
+	if comments != nil {
+		commentList := comments.CommentList.Comment[:0]
+		for _, comment := range comments.CommentList.Comment {
+			if col, row, err := CellNameToCoordinates(comment.Ref); err == nil && fn(col, row) {
+				continue
+			}
+			commentList = append(commentList, comment)
+		}
+		comments.CommentList.Comment = commentList
+		compactCommentAuthors(comments)
+	}
+	if threadedComments := f.sheetThreadedCommentsReader(sheet); threadedComments != nil {
+		adjustThreadedComments(threadedComments, func(ref string) (string, bool) {
+			col, row, err := CellNameToCoordinates(ref)
+			return ref, err != nil || !fn(col, row)
+		})
+	}
+	if vml != nil {
+		shapes := vml.Shape[:0]
+		for _, shape := range vml.Shape {
+			if col, row, ok := vmlShapeCell(shape); ok && fn(col, row) {
+				continue
+			}
+			shapes = append(shapes, shape)
+		}
+		vml.Shape = shapes
+	}
+}
+
+// sheetThreadedCommentsReader provides a function to get the threaded
+// comments of the worksheet, it returns nil if the worksheet doesn't have the
+// threaded comments.
+func (f *File) sheetThreadedCommentsReader(sheet string) *xlsxThreadedComments {
+	target := f.getSheetThreadedComments(f.GetSheetIndex(sheet))
+	if target == "" {
+		return nil
+	}
+	return f.threadedCommentsReader("xl" + strings.TrimPrefix(target, ".."))
+}
+
+// adjustThreadedComments provides a function to update the cell references of
+// the threaded comments by given function, which returns the new cell
+// reference and reports whether the comment is kept. The replies of the
+// removed comments will be removed with them.
+func adjustThreadedComments(threadedComments *xlsxThreadedComments, fn func(ref string) (string, bool)) {
+	removed := make(map[string]bool)
+	commentList := threadedComments.ThreadedComment[:0]
+	for _, comment := range threadedComments.ThreadedComment {
+		var ok bool
+		if comment.Ref, ok = fn(comment.Ref); !ok || removed[comment.ParentID] {
+			removed[comment.ID] = true
+			continue
+		}
+		commentList = append(commentList, comment)
+	}
+	threadedComments.ThreadedComment = commentList
+}
+
+// compactCommentAuthors provides a function to remove the authors which have
+// no comments from the list of authors, and update the author index of the
+// comments.
+func compactCommentAuthors(comments *xlsxComments) {
+	authorIDs := make(map[int]int, len(comments.Authors))
+	for _, comment := range comments.CommentList.Comment {
+		authorIDs[comment.AuthorID] = -1
+	}
+	authors := comments.Authors[:0]
+	for idx, author := range comments.Authors {
+		if _, ok := authorIDs[idx]; ok {
+			authorIDs[idx] = len(authors)
+			authors = append(authors, author)
+		}
+	}
+	comments.Authors = authors
+	for idx, comment := range comments.CommentList.Comment {
+		if authorID := authorIDs[comment.AuthorID]; authorID >= 0 {
+			comments.CommentList.Comment[idx].AuthorID = authorID
+		}
+	}
 }
 
 // addComment provides a function to create chart as xl/comments%d.xml by
@@ -333,6 +548,31 @@ func (f *File) commentsReader(path string) *xlsxComments {
 	return f.Comments[path]
 }
 
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) *xlsxThreadedComments {
+	if f.ThreadedComments[path] == nil {
+		content, ok := f.XLSX[path]
+		if ok {
+			c := xlsxThreadedComments{}
+			_ = xml.Unmarshal(namespaceStrictToTransitional(content), &c)
+			f.ThreadedComments[path] = &c
+		}
+	}
+	return f.ThreadedComments[path]
+}
+
+// threadedCommentsWriter provides a function to save
+// xl/threadedComments/threadedComment%d.xml after serialize structure.
+func (f *File) threadedCommentsWriter() {
+	for path, c := range f.ThreadedComments {
+		if c != nil {
+			v, _ := xml.Marshal(c)
+			f.saveFileList(path, v)
+		}
+	}
+}
+
 // commentsWriter provides a function to save xl/comments%d.xml after
 // serialize structure.
 func (f *File) commentsWriter() {
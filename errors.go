@@ -15,6 +15,10 @@ func newInvalidColumnNameError(col string) error {
 	return fmt.Errorf("invalid column name %q", col)
 }
 
+func newInvalidColumnNumberError(col int) error {
+	return fmt.Errorf("incorrect column number %d", col)
+}
+
 func newInvalidRowNumberError(row int) error {
 	return fmt.Errorf("invalid row number %d", row)
 }
@@ -23,6 +23,8 @@ import (
 
 // File define a populated XLSX file struct.
 type File struct {
+	adjustOptions    adjustOptions
+	adjustStats      map[string]*AdjustStats
 	checked          map[string]bool
 	sheetMap         map[string]string
 	CalcChain        *xlsxCalcChain
@@ -36,6 +38,7 @@ type File struct {
 	SheetCount       int
 	Styles           *xlsxStyleSheet
 	Theme            *xlsxTheme
+	ThreadedComments map[string]*xlsxThreadedComments
 	DecodeVMLDrawing map[string]*decodeVmlDrawing
 	VMLDrawing       map[string]*vmlDrawing
 	WorkBook         *xlsxWorkbook
@@ -83,6 +86,7 @@ func OpenReader(r io.Reader) (*File, error) {
 		Drawings:         make(map[string]*xlsxWsDr),
 		Sheet:            make(map[string]*xlsxWorksheet),
 		SheetCount:       sheetCount,
+		ThreadedComments: make(map[string]*xlsxThreadedComments),
 		DecodeVMLDrawing: make(map[string]*decodeVmlDrawing),
 		VMLDrawing:       make(map[string]*vmlDrawing),
 		WorkSheetRels:    make(map[string]*xlsxWorkbookRels),
@@ -111,7 +115,10 @@ func (f *File) setDefaultTimeStyle(sheet, axis string, format int) error {
 }
 
 // workSheetReader provides a function to get the pointer to the structure
-// after deserialization by given worksheet name.
+// after deserialization by given worksheet name. Each worksheet is
+// deserialized into its own structure and the copied worksheets are deep
+// copied, so the worksheets never share the pointers and adjusting one of
+// them leaves the others unchanged.
 func (f *File) workSheetReader(sheet string) (*xlsxWorksheet, error) {
 	name, ok := f.sheetMap[trimSheetName(sheet)]
 	if !ok {
@@ -212,6 +212,64 @@ func TestBrokenFile(t *testing.T) {
 	})
 }
 
+func TestClone(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		cell, _ := CoordinatesToCellName(1, row)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &[]interface{}{row, row * 2}))
+	}
+	assert.NoError(t, f.MergeCell("Sheet1", "C2", "D4"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "Sheet1!B5", "Location"))
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "B5", ""))
+	assert.NoError(t, f.AddComment("Sheet1", "B4", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClone.xlsx")))
+
+	f, err := OpenFile(filepath.Join("test", "TestClone.xlsx"))
+	assert.NoError(t, err)
+	f.SetAdjustOptions(FullCalcOnLoad(true))
+	clone, err := f.Clone()
+	assert.NoError(t, err)
+	var option FullCalcOnLoad
+	clone.GetAdjustOptions(&option)
+	assert.Equal(t, FullCalcOnLoad(true), option)
+
+	// Test remove the rows on the copy doesn't affect the original file.
+	assert.NoError(t, clone.RemoveRowsByIndex("Sheet1", []int{2, 3}))
+	for _, c := range []struct {
+		f                           *File
+		value, mergeCell, link, ref string
+	}{
+		{f, "3", "C2:D4", "A3", "A1:B5"},
+		{clone, "5", "C2:D2", "", "A1:B3"},
+	} {
+		value, err := c.f.GetCellValue("Sheet1", "A3")
+		assert.NoError(t, err)
+		assert.Equal(t, c.value, value)
+		mergeCells, err := c.f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		if assert.Len(t, mergeCells, 1) {
+			assert.Equal(t, c.mergeCell, mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+		}
+		links, err := c.f.GetHyperLinks("Sheet1")
+		assert.NoError(t, err)
+		if c.link == "" {
+			assert.Empty(t, links)
+		} else if assert.Len(t, links, 1) {
+			assert.Equal(t, c.link, links[0].Ref)
+		}
+		xlsx, err := c.f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.ref, xlsx.AutoFilter.Ref)
+	}
+	comments := clone.GetComments()["Sheet1"]
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "B2", comments[0].Ref)
+	}
+	assert.Equal(t, "B4", f.GetComments()["Sheet1"][0].Ref)
+	assert.NoError(t, clone.SaveAs(filepath.Join("test", "TestCloneCopy.xlsx")))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestClone.xlsx")))
+}
+
 func TestNewFile(t *testing.T) {
 	// Test create a XLSX file.
 	f := NewFile()
@@ -268,6 +326,141 @@ func TestColWidth(t *testing.T) {
 	convertRowHeightToPixels(0)
 }
 
+func TestSetColWidthAutoFit(t *testing.T) {
+	f := NewFile()
+	width := func(col string) float64 {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		return width
+	}
+	// Test the width grows with longer content.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "short"))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "A"))
+	short := width("A")
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "a much longer text"))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "A"))
+	assert.True(t, width("A") > short)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", strings.Repeat("W", 300)))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "A"))
+	assert.Equal(t, maxColWidth, width("A"))
+
+	// Test the width of the value displayed by the number format.
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 43739))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "B"))
+	number := width("B")
+	style, err := f.NewStyle(`{"number_format":22}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "B"))
+	assert.True(t, width("B") > number)
+
+	// Test the larger and bold font grows the width.
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", "text"))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "C"))
+	text := width("C")
+	style, err = f.NewStyle(`{"font":{"bold":true,"size":20}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C1", style))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "C"))
+	assert.True(t, width("C") > text)
+
+	// Test the style of the column and the style of the row are used for the
+	// cells without style.
+	assert.NoError(t, f.SetCellValue("Sheet1", "G1", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "H2", "text"))
+	assert.NoError(t, f.SetColStyle("Sheet1", "G", style))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.SheetData.Row[0].C[6].S = 0
+	xlsx.SheetData.Row[1].S, xlsx.SheetData.Row[1].CustomFormat = style, true
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "G"))
+	assert.Equal(t, width("C"), width("G"))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "H"))
+	assert.Equal(t, width("C"), width("H"))
+
+	// Test the merged cells are skipped.
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D2", "a much longer text in merged cells"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D2", "E2"))
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "D"))
+	assert.Equal(t, text, width("D"))
+
+	// Test the width of the column without value is left unchanged.
+	assert.NoError(t, f.SetColWidthAutoFit("Sheet1", "F"))
+	assert.Equal(t, defaultColWidthPixels, width("F"))
+
+	// Test auto fit column width with invalid arguments.
+	assert.EqualError(t, f.SetColWidthAutoFit("Sheet1", "*"), `invalid column name "*"`)
+	assert.EqualError(t, f.SetColWidthAutoFit("SheetN", "A"), "sheet SheetN is not exist")
+}
+
+func TestSetColStyle(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "C", "C", 20))
+	style, err := f.NewStyle(`{"fill":{"type":"pattern","color":["#E0EBF5"],"pattern":1}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "D:B", style))
+	// Test the existing cells of the columns are not written.
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, xlsx.SheetData.Row[1].C[1].S)
+	// Test reads return the column style for the cells.
+	for _, cell := range []string{"B2", "C5", "D1"} {
+		s, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, s, cell)
+	}
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+
+	// Test the column style shifts on inserting column.
+	assert.NoError(t, f.InsertCol("Sheet1", "A"))
+	for cell, expected := range map[string]int{"B1": 0, "C1": style, "E9": style, "F1": 0} {
+		s, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, s, cell)
+	}
+	assert.NoError(t, f.SetColStyle("Sheet1", "H", style))
+	s, err := f.GetCellStyle("Sheet1", "H1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, s)
+
+	// Test set column style with illegal column name.
+	assert.EqualError(t, f.SetColStyle("Sheet1", "*", style), `invalid column name "*"`)
+	assert.EqualError(t, f.SetColStyle("Sheet1", "A:*", style), `invalid column name "*"`)
+	assert.EqualError(t, f.SetColStyle("Sheet1", "A:B:C", style), `invalid column name "A:B:C"`)
+	// Test set column style on not exists worksheet.
+	assert.EqualError(t, f.SetColStyle("SheetN", "E", style), "sheet SheetN is not exist")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetColStyle.xlsx")))
+
+	f = NewFile()
+	assert.NoError(t, f.SetColStyle("Sheet1", "A", style))
+	s, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, s)
+
+	// Test the columns which have been set are split at the bounds of the
+	// columns without overlapping, and keep the other settings.
+	f = NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "J", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "L", false))
+	assert.NoError(t, f.SetColStyle("Sheet1", "C:F", style))
+	assert.NoError(t, f.SetColStyle("Sheet1", "J:M", style))
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxCol{
+		{Min: 1, Max: 2, Width: 20, CustomWidth: true},
+		{Min: 3, Max: 6, Width: 20, CustomWidth: true, Style: style},
+		{Min: 7, Max: 9, Width: 20, CustomWidth: true},
+		{Min: 10, Max: 10, Width: 20, CustomWidth: true, Style: style},
+		{Min: 11, Max: 11, Width: defaultColWidth, Style: style},
+		{Min: 12, Max: 12, Hidden: true, CustomWidth: true, Style: style},
+		{Min: 13, Max: 13, Width: defaultColWidth, Style: style},
+	}, xlsx.Cols.Col)
+}
+
 func TestAddDrawingVML(t *testing.T) {
 	// Test addDrawingVML with illegal cell coordinates.
 	f := NewFile()
@@ -347,6 +540,38 @@ func TestSetCellFormula(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula3.xlsx")))
 }
 
+func TestSetCellFormulaWithOpts(t *testing.T) {
+	f := NewFile()
+	// Test set array formula and shared formula, and insert row into them.
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "ROW(C1:C3)", FormulaOpts{Type: STCellFormulaTypeArray, Ref: "A1:A3"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "C1*2", FormulaOpts{Type: STCellFormulaTypeShared, Ref: "B1:B3"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "C1*3", FormulaOpts{Type: STCellFormulaTypeShared, Ref: "D1:E1"}))
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxF{Content: "ROW(C1:C4)", T: STCellFormulaTypeArray, Ref: "A1:A4"}, xlsx.SheetData.Row[0].C[0].F)
+	assert.Equal(t, &xlsxF{Content: "C1*2", T: STCellFormulaTypeShared, Ref: "B1:B4", Si: "0"}, xlsx.SheetData.Row[0].C[1].F)
+	assert.Equal(t, &xlsxF{Content: "C1*3", T: STCellFormulaTypeShared, Ref: "D1:E1", Si: "1"}, xlsx.SheetData.Row[0].C[3].F)
+	assert.Equal(t, &xlsxF{T: STCellFormulaTypeShared, Si: "1"}, xlsx.SheetData.Row[0].C[4].F)
+	for _, cell := range []string{"B3", "B4"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "C1*2", formula)
+	}
+	for _, cell := range []string{"A2", "A3", "A4", "B2"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "C1", FormulaOpts{Type: STCellFormulaTypeNormal}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormulaWithOpts.xlsx")))
+
+	// Test set formula with unsupported type and invalid range.
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "C1", FormulaOpts{Type: STCellFormulaTypeDataTable, Ref: "A1"}), `unsupported formula type "dataTable"`)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "C1", FormulaOpts{Type: STCellFormulaTypeArray, Ref: "A:A3"}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A2", "C1", FormulaOpts{Type: STCellFormulaTypeShared, Ref: "A1:A3"}), "formula range A1:A3 must start with the cell A2")
+}
+
 func TestSetSheetBackground(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -799,6 +1024,38 @@ func TestCopySheet(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheet.xlsx")))
 }
 
+func TestCopySheetAdjust(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "B3"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1", "C3", ""))
+
+	idx := f.NewSheet("CopySheet")
+	assert.NoError(t, f.CopySheet(1, idx))
+	// Test insert row on the copied worksheet doesn't change the source.
+	assert.NoError(t, f.InsertRow("CopySheet", 1))
+
+	for sheet, expected := range map[string][]string{
+		"Sheet1":    {"A2:B3", "C3", "A1:C3"},
+		"CopySheet": {"A3:B4", "C4", "A2:C4"},
+	} {
+		mergeCells, err := f.GetMergeCells(sheet)
+		assert.NoError(t, err)
+		if assert.Len(t, mergeCells, 1) {
+			assert.Equal(t, expected[0], mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+		}
+		link, target, err := f.GetCellHyperLink(sheet, expected[1])
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "https://github.com/360EntSecGroup-Skylar/excelize", target)
+		xlsx, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[2], xlsx.AutoFilter.Ref)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCopySheetAdjust.xlsx")))
+}
+
 func TestCopySheetError(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -848,6 +1105,19 @@ func TestAddTable(t *testing.T) {
 	assert.EqualError(t, f.addTable("sheet1", "", 1, 1, 0, 0, 0, nil), "invalid cell coordinates [0, 0]")
 }
 
+func TestCountTables(t *testing.T) {
+	f := NewFile()
+	assert.Equal(t, 0, f.countTables())
+	// Test get the largest number of the table files after some of the tables
+	// have been deleted.
+	for _, path := range []string{"xl/tables/table1.xml", "xl/tables/table3.xml", "xl/tables/table4.xml", "xl/tables/_rels/table9.xml.rels", "xl/tables/tableX.xml"} {
+		f.XLSX[path] = nil
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, 4, f.countTables())
+	}
+}
+
 func TestAddShape(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -878,6 +1148,143 @@ func TestAddComments(t *testing.T) {
 	}
 }
 
+func TestAddCommentKeepVMLDrawing(t *testing.T) {
+	f := NewFile()
+	// Add a comment to the worksheet with the VML drawing of a check box
+	// saved by Excel.
+	f.XLSX["xl/drawings/vmlDrawing1.vml"] = []byte(fmt.Sprintf(formControlsVML, "$B$2"))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.LegacyDrawing = &xlsxLegacyDrawing{RID: "rId" + strconv.Itoa(f.addSheetRelationships("Sheet1", SourceRelationshipDrawingVML, "../drawings/vmlDrawing1.vml", ""))}
+	assert.NoError(t, f.AddComment("Sheet1", "D5", `{"author":"Excelize: ","text":"This is a comment."}`))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddCommentKeepVMLDrawing.xlsx")))
+
+	vml := string(f.XLSX["xl/drawings/vmlDrawing1.vml"])
+	assert.NotContains(t, vml, "xmlns:_")
+	assert.Contains(t, vml, `<o:idmap v:ext="edit" data="2"></o:idmap>`)
+	assert.Contains(t, vml, `<v:shapetype id="_x0000_t202"`)
+	assert.Contains(t, vml, `<v:shapetype id="_x0000_t201" coordsize="21600,21600" o:spt="201" path="m,l,21600r21600,l21600,xe">`)
+	assert.Contains(t, vml, `<o:lock v:ext="edit" shapetype="t"/>`)
+	assert.Contains(t, vml, `fillcolor="window [65]" o:insetmode="auto" strokecolor="windowText [64]" filled="f" stroked="f" o:button="t">`)
+	assert.Contains(t, vml, `<x:FmlaLink>$B$2</x:FmlaLink>`)
+	assert.Equal(t, 1, strings.Count(vml, `<v:shapetype id="_x0000_t201"`))
+}
+
+func TestDeleteComment(t *testing.T) {
+	f := NewFile()
+	for _, cell := range []string{"A1", "B2", "C3"} {
+		assert.NoError(t, f.AddComment("Sheet1", cell, `{"author":"Excelize: ","text":"This is a comment."}`))
+	}
+	comments := f.commentsReader("xl/comments1.xml")
+	comments.Authors = []xlsxAuthor{{Author: "A"}, {Author: "B"}, {Author: "C"}}
+	for idx := range comments.CommentList.Comment {
+		comments.CommentList.Comment[idx].AuthorID = idx
+	}
+
+	assert.NoError(t, f.DeleteComment("Sheet1", "B2"))
+	sheetComments := f.GetComments()["Sheet1"]
+	if assert.Len(t, sheetComments, 2) {
+		assert.Equal(t, "A1", sheetComments[0].Ref)
+		assert.Equal(t, "A", sheetComments[0].Author)
+		assert.Equal(t, "C3", sheetComments[1].Ref)
+		assert.Equal(t, "C", sheetComments[1].Author)
+		assert.Equal(t, 1, sheetComments[1].AuthorID)
+	}
+	assert.Equal(t, []xlsxAuthor{{Author: "A"}, {Author: "C"}}, comments.Authors)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 2) {
+		assert.Contains(t, vml.Shape[0].Val, "<x:Row>0</x:Row>")
+		assert.Contains(t, vml.Shape[1].Val, "<x:Row>2</x:Row>")
+	}
+	// Test delete the comment which doesn't exist.
+	assert.NoError(t, f.DeleteComment("Sheet1", "B2"))
+	assert.Len(t, f.GetComments()["Sheet1"], 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteComment.xlsx")))
+
+	// Test delete the comment on the worksheet without comments.
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.DeleteComment("Sheet2", "A1"))
+	assert.EqualError(t, f.DeleteComment("Sheet1", "A"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.DeleteComment("SheetN", "A1"), "sheet SheetN is not exist")
+}
+
+func TestRepackRelationships(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 6; row++ {
+		cell := "A" + strconv.Itoa(row)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", cell, "https://github.com/360EntSecGroup-Skylar/excelize/"+cell, "External"))
+	}
+	assert.NoError(t, f.AddPicture("Sheet1", "C1", filepath.Join("test", "images", "excel.jpg"), ""))
+	assert.NoError(t, f.AddComment("Sheet1", "D1", `{"author":"Excelize: ","text":"This is a comment."}`))
+	// Test remove the rows of the hyperlinks to leave gaps in the index.
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	sheetRels := f.workSheetRelsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.Equal(t, "rId3", sheetRels.Relationships[1].ID)
+
+	assert.NoError(t, f.RepackRelationships("Sheet1"))
+	for idx, rel := range sheetRels.Relationships {
+		assert.Equal(t, "rId"+strconv.Itoa(idx+1), rel.ID)
+	}
+	assert.Len(t, sheetRels.Relationships, 6)
+	for cell, expected := range map[string]string{"A1": "A1", "A2": "A3", "A3": "A6"} {
+		link, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "https://github.com/360EntSecGroup-Skylar/excelize/"+expected, target)
+	}
+	file, raw, err := f.GetPicture("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "image1.jpeg", file)
+	assert.NotEmpty(t, raw)
+	assert.Len(t, f.GetComments()["Sheet1"], 1)
+	// Test add a hyperlink after repacking the relationships.
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A4", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	_, target, err := f.GetCellHyperLink("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/360EntSecGroup-Skylar/excelize", target)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRepackRelationships.xlsx")))
+
+	// Test repack the relationships with a relationship referenced in the
+	// extension list only, which keeps its index.
+	f = NewFile()
+	for row := 1; row <= 4; row++ {
+		cell := "A" + strconv.Itoa(row)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, cell))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", cell, "https://github.com/360EntSecGroup-Skylar/excelize/"+cell, "External"))
+	}
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	sheetRels = f.workSheetRelsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	sheetRels.Relationships = append(sheetRels.Relationships, xlsxWorkbookRelation{
+		ID: "rId2", Target: "../media/image1.png", Type: SourceRelationshipImage,
+	})
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ext := `<ext uri="{00000000-0000-0000-0000-000000000000}"><x:image xmlns:x="x" r:id="rId2"/></ext>`
+	xlsx.ExtLst = &xlsxExtLst{Ext: ext}
+	assert.NoError(t, f.RepackRelationships("Sheet1"))
+	var rIDs []string
+	for _, rel := range sheetRels.Relationships {
+		rIDs = append(rIDs, rel.ID)
+	}
+	assert.Equal(t, []string{"rId1", "rId3", "rId2"}, rIDs)
+	assert.Equal(t, ext, xlsx.ExtLst.Ext)
+	for cell, expected := range map[string]string{"A1": "A3", "A2": "A4"} {
+		link, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "https://github.com/360EntSecGroup-Skylar/excelize/"+expected, target)
+	}
+
+	// Test repack the relationships on the worksheet without relationships.
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.RepackRelationships("Sheet2"))
+	assert.EqualError(t, f.RepackRelationships("SheetN"), "sheet SheetN is not exist")
+}
+
 func TestGetSheetComments(t *testing.T) {
 	f := NewFile()
 	assert.Equal(t, "", f.getSheetComments(0))
@@ -915,6 +1322,57 @@ func TestAutoFilter(t *testing.T) {
 	assert.EqualError(t, f.AutoFilter("Sheet1", "A1", "B", ""), `cannot convert cell "B" to coordinates: invalid cell name "B"`)
 }
 
+func TestGetAutoFilter(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "B"+strconv.Itoa(row), row))
+	}
+	filter, err := f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	assert.NoError(t, f.AutoFilter("Sheet1", "B1", "D5", `{"column":"C","expression":"x == 1 or x == 2"}`))
+	filter, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &AutoFilterDefinition{
+		Ref:     "B1:D5",
+		Columns: []AutoFilterColumn{{ColID: 1, Column: "C", Filters: []string{"1", "2"}}},
+	}, filter)
+
+	// Test get the auto filter with the custom filters and the sort state.
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.AutoFilter.FilterColumn = &xlsxFilterColumn{ColID: 2, CustomFilters: &xlsxCustomFilters{
+		And: true,
+		CustomFilter: []*xlsxCustomFilter{
+			{Operator: "greaterThan", Val: "1"},
+			{Operator: "lessThan", Val: "5"},
+		},
+	}}
+	xlsx.AutoFilter.SortState = &xlsxSortState{Ref: "B2:D5", SortCondition: []*xlsxSortCondition{{Descending: true, Ref: "D2:D5"}}}
+
+	// Test the range of the auto filter reflects the shift after deleting a row.
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	filter, err = f.GetAutoFilter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &AutoFilterDefinition{
+		Ref: "B1:D4",
+		Columns: []AutoFilterColumn{{ColID: 2, Column: "D", And: true, CustomFilters: []AutoFilterCustomFilter{
+			{Operator: "greaterThan", Val: "1"},
+			{Operator: "lessThan", Val: "5"},
+		}}},
+		SortState: &AutoFilterSortState{Ref: "B2:D4", Conditions: []AutoFilterSortCondition{{Ref: "D2:D4", Descending: true}}},
+	}, filter)
+
+	// Test get the auto filter on not exists worksheet.
+	_, err = f.GetAutoFilter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	// Test get the auto filter with illegal cell coordinates.
+	xlsx.AutoFilter.Ref = "B1:D"
+	_, err = f.GetAutoFilter("Sheet1")
+	assert.EqualError(t, err, `cannot convert cell "D" to coordinates: invalid cell name "D"`)
+}
+
 func TestAutoFilterError(t *testing.T) {
 	outFile := filepath.Join("test", "TestAutoFilterError%d.xlsx")
 
@@ -1036,6 +1494,80 @@ func TestRemoveCol(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRemoveCol.xlsx")))
 }
 
+func BenchmarkInsertCol(b *testing.B) {
+	f := NewFile()
+	for row := 1; row <= 100000; row++ {
+		for col, value := range []string{"A", "B", "C"} {
+			cell, _ := CoordinatesToCellName(col+1, row)
+			f.SetCellStr("Sheet1", cell, value)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// insert and remove the column after the used range.
+		f.InsertCol("Sheet1", "D")
+		f.RemoveCol("Sheet1", "D")
+	}
+}
+
+func TestCols(t *testing.T) {
+	f := NewFile()
+	cols, err := f.Cols("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, cols.Next())
+	col, err := cols.Rows()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, col)
+
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A1", "B1", "C1"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A2", nil, 3}))
+	cols, err = f.Cols("Sheet1")
+	assert.NoError(t, err)
+	// Test the changes of the worksheet are reflected by the iterator.
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", "B3"))
+	var result [][]string
+	for cols.Next() {
+		col, err := cols.Rows()
+		assert.NoError(t, err)
+		result = append(result, col)
+	}
+	assert.NoError(t, cols.Error())
+	assert.Equal(t, [][]string{{"A1", "A2"}, {"B1", "", "B3"}, {"C1", "3"}}, result)
+
+	// Test get columns iterator on not exists worksheet.
+	_, err = f.Cols("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func BenchmarkCols(b *testing.B) {
+	f := NewFile()
+	for row := 1; row <= 10000; row++ {
+		assert.NoError(b, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{"A", "B", "C", "D", "E"}))
+	}
+	b.Run("Iterator", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			cols, _ := f.Cols("Sheet1")
+			for cols.Next() {
+				_, _ = cols.Rows()
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			rows, _ := f.GetRows("Sheet1")
+			cols := make([][]string, len(rows[0]))
+			for col := range cols {
+				cols[col] = make([]string, len(rows))
+				for row := range rows {
+					cols[col][row] = rows[row][col]
+				}
+			}
+		}
+	})
+}
+
 func TestSetPane(t *testing.T) {
 	f := NewFile()
 	f.SetPanes("Sheet1", `{"freeze":false,"split":false}`)
@@ -15,6 +15,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+
+	"github.com/mohae/deepcopy"
 )
 
 // NewFile provides a function to create new file by default template. For
@@ -45,6 +47,7 @@ func NewFile() *File {
 	f.DrawingRels = make(map[string]*xlsxWorkbookRels)
 	f.Drawings = make(map[string]*xlsxWsDr)
 	f.Styles = f.stylesReader()
+	f.ThreadedComments = make(map[string]*xlsxThreadedComments)
 	f.DecodeVMLDrawing = make(map[string]*decodeVmlDrawing)
 	f.VMLDrawing = make(map[string]*vmlDrawing)
 	f.WorkBook = f.workbookReader()
@@ -56,6 +59,51 @@ func NewFile() *File {
 	return f
 }
 
+// Clone provides a function to create a deep copy of the file, including the
+// worksheets with their merged cells, hyperlinks and auto filters, the
+// drawings, comments, styles, workbook and the other parts of the file, and
+// the options of adjusting the worksheets. Inserting or deleting rows or
+// columns on the copy doesn't affect the original file. For example, try to
+// remove the rows on a copy of the file:
+//
+//    clone, err := f.Clone()
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    err = clone.RemoveRow("Sheet1", 3)
+//
+func (f *File) Clone() (*File, error) {
+	xlsx := make(map[string][]byte, len(f.XLSX))
+	for k, v := range f.XLSX {
+		xlsx[k] = append([]byte(nil), v...)
+	}
+	clone := &File{
+		adjustOptions:    f.adjustOptions,
+		adjustStats:      deepcopy.Copy(f.adjustStats).(map[string]*AdjustStats),
+		checked:          deepcopy.Copy(f.checked).(map[string]bool),
+		sheetMap:         deepcopy.Copy(f.sheetMap).(map[string]string),
+		CalcChain:        deepcopy.Copy(f.CalcChain).(*xlsxCalcChain),
+		Comments:         deepcopy.Copy(f.Comments).(map[string]*xlsxComments),
+		ContentTypes:     deepcopy.Copy(f.ContentTypes).(*xlsxTypes),
+		DrawingRels:      deepcopy.Copy(f.DrawingRels).(map[string]*xlsxWorkbookRels),
+		Drawings:         deepcopy.Copy(f.Drawings).(map[string]*xlsxWsDr),
+		Path:             f.Path,
+		SharedStrings:    deepcopy.Copy(f.SharedStrings).(*xlsxSST),
+		Sheet:            deepcopy.Copy(f.Sheet).(map[string]*xlsxWorksheet),
+		SheetCount:       f.SheetCount,
+		Styles:           deepcopy.Copy(f.Styles).(*xlsxStyleSheet),
+		Theme:            deepcopy.Copy(f.Theme).(*xlsxTheme),
+		ThreadedComments: deepcopy.Copy(f.ThreadedComments).(map[string]*xlsxThreadedComments),
+		DecodeVMLDrawing: deepcopy.Copy(f.DecodeVMLDrawing).(map[string]*decodeVmlDrawing),
+		VMLDrawing:       deepcopy.Copy(f.VMLDrawing).(map[string]*vmlDrawing),
+		WorkBook:         deepcopy.Copy(f.WorkBook).(*xlsxWorkbook),
+		WorkBookRels:     deepcopy.Copy(f.WorkBookRels).(*xlsxWorkbookRels),
+		WorkSheetRels:    deepcopy.Copy(f.WorkSheetRels).(map[string]*xlsxWorkbookRels),
+		XLSX:             xlsx,
+	}
+	return clone, nil
+}
+
 // Save provides a function to override the xlsx file with origin path.
 func (f *File) Save() error {
 	if f.Path == "" {
@@ -105,6 +153,7 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	f.workSheetWriter()
 	f.workSheetRelsWriter()
 	f.styleSheetWriter()
+	f.threadedCommentsWriter()
 
 	for path, content := range f.XLSX {
 		fi, err := zw.Create(path)
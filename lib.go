@@ -144,11 +144,11 @@ func ColumnNameToNumber(name string) (int, error) {
 //
 func ColumnNumberToName(num int) (string, error) {
 	if num < 1 {
-		return "", fmt.Errorf("incorrect column number %d", num)
+		return "", newInvalidColumnNumberError(num)
 	}
 	var col string
 	for num > 0 {
-		col = string((num-1)%26+65) + col
+		col = string(rune((num-1)%26+65)) + col
 		num = (num - 1) / 26
 	}
 	return col, nil
@@ -196,6 +196,62 @@ func CoordinatesToCellName(col, row int) (string, error) {
 	return fmt.Sprintf("%s%d", colname, row), nil
 }
 
+// RangeToCoordinates provides a function to convert range reference to a
+// pair of coordinates. The range must consist of two cells, and the returned
+// coordinates are sorted, so the first pair is always the top left cell of
+// the range. For example, convert "D3:B1" to (2, 1, 4, 3):
+//
+//    c1, r1, c2, r2, err := excelize.RangeToCoordinates("D3:B1")
+//
+func RangeToCoordinates(ref string) (c1, r1, c2, r2 int, err error) {
+	rng := strings.Split(ref, ":")
+	if len(rng) != 2 {
+		err = fmt.Errorf("invalid area %q", ref)
+		return
+	}
+	if c1, r1, err = CellNameToCoordinates(rng[0]); err != nil {
+		return
+	}
+	if c2, r2, err = CellNameToCoordinates(rng[1]); err != nil {
+		return
+	}
+	if c2 < c1 {
+		c1, c2 = c2, c1
+	}
+	if r2 < r1 {
+		r1, r2 = r2, r1
+	}
+	return
+}
+
+// areaRefToCoordinates provides a function to convert area reference to a
+// pair of coordinates. The returned coordinates are sorted, so the first pair
+// is always the top left cell of the area. For example, convert "D3:B1" to
+// []int{2, 1, 4, 3}.
+func areaRefToCoordinates(ref string) ([]int, error) {
+	c1, r1, c2, r2, err := RangeToCoordinates(ref)
+	if err != nil {
+		return nil, err
+	}
+	return []int{c1, r1, c2, r2}, nil
+}
+
+// cellRefToCoordinates provides a function to convert a cell reference or an
+// area reference to the coordinates of the area.
+func cellRefToCoordinates(ref string) ([]int, error) {
+	if strings.Contains(ref, ":") {
+		return areaRefToCoordinates(ref)
+	}
+	col, row, err := CellNameToCoordinates(ref)
+	return []int{col, row, col, row}, err
+}
+
+// areaOverlaps provides a function to check if two areas given by the
+// coordinates of their top left and bottom right cells intersect.
+func areaOverlaps(a, b []int) bool {
+	return a[0] <= b[2] && b[0] <= a[2] && a[1] <= b[3] && b[1] <= a[3]
+}
+
 // boolPtr returns a pointer to a bool with the given value.
 func boolPtr(b bool) *bool { return &b }
 
@@ -203,3 +203,30 @@ func TestCoordinatesToCellName_Error(t *testing.T) {
 		}
 	}
 }
+
+func TestRangeToCoordinates_OK(t *testing.T) {
+	for ref, expected := range map[string][]int{
+		"A1:C3": {1, 1, 3, 3},
+		"C3:A1": {1, 1, 3, 3},
+		"A3:C1": {1, 1, 3, 3},
+		"B2:B2": {2, 2, 2, 2},
+	} {
+		c1, r1, c2, r2, err := RangeToCoordinates(ref)
+		if assert.NoErrorf(t, err, "Range: %q", ref) {
+			assert.Equalf(t, expected, []int{c1, r1, c2, r2}, "Range: %q", ref)
+		}
+	}
+}
+
+func TestRangeToCoordinates_Error(t *testing.T) {
+	for ref, msg := range map[string]string{
+		"":         `invalid area ""`,
+		"A1":       `invalid area "A1"`,
+		"A1:B2:C3": `invalid area "A1:B2:C3"`,
+		"A:B1":     `cannot convert cell "A" to coordinates: invalid cell name "A"`,
+		"A1:B":     `cannot convert cell "B" to coordinates: invalid cell name "B"`,
+	} {
+		_, _, _, _, err := RangeToCoordinates(ref)
+		assert.EqualErrorf(t, err, msg, "Range: %q", ref)
+	}
+}
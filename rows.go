@@ -15,7 +15,10 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // GetRows return all the rows in a sheet by given worksheet name (case
@@ -92,16 +95,118 @@ func (f *File) GetRows(sheet string) ([][]string, error) {
 	return rows[:row], nil
 }
 
+// GetUsedRange provides a function to get the values of the used range of
+// the worksheet by given worksheet name, the trailing empty rows and columns
+// are trimmed, so that the last row and the last column of the result both
+// have at least one non-empty value. The cells which only have a style are
+// treated as empty. For example, get the used range of Sheet1:
+//
+//    rows, err := f.GetUsedRange("Sheet1")
+//
+func (f *File) GetUsedRange(sheet string) ([][]string, error) {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	var colCount int
+	for _, row := range rows {
+		for col := len(row); col > colCount; col-- {
+			if row[col-1] != "" {
+				colCount = col
+				break
+			}
+		}
+	}
+	for idx := range rows {
+		rows[idx] = rows[idx][:colCount]
+	}
+	return rows, nil
+}
+
+// GetRowValues provides a function to get the typed values of the cells of
+// the row by given worksheet name and Excel row number, the counterpart of
+// SetSheetRow. The values are float64 for the numbers, bool for the boolean
+// values, time.Time for the numbers with a date or time number format and
+// string for the others, and nil for the blank cells and the cells without
+// value, such as the formulas not calculated yet. The trailing blank cells
+// are trimmed. For example, get the values of row 2 on Sheet1:
+//
+//    values, err := f.GetRowValues("Sheet1", 2)
+//
+func (f *File) GetRowValues(sheet string, row int) ([]interface{}, error) {
+	if row < 1 {
+		return nil, newInvalidRowNumberError(row)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	var values []interface{}
+	if row > len(xlsx.SheetData.Row) {
+		return values, err
+	}
+	d := f.sharedStringsReader()
+	var date1904 bool
+	if wb := f.workbookReader(); wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	for i := range xlsx.SheetData.Row[row-1].C {
+		c := &xlsx.SheetData.Row[row-1].C[i]
+		cellType := f.cellType(c)
+		if cellType == CellTypeBlank || c.getRawValueFrom(d) == "" {
+			continue
+		}
+		col, _, err := CellNameToCoordinates(c.R)
+		if err != nil {
+			return values, err
+		}
+		for len(values) < col {
+			values = append(values, nil)
+		}
+		values[col-1] = c.getTypedValueFrom(cellType, d, date1904)
+	}
+	return values, err
+}
+
+// getTypedValueFrom provides a function to convert the raw value of the cell
+// to the typed value by given cell type, the values which can't be converted
+// are returned as string. The times are rounded to milliseconds, which is the
+// precision of the times in the spreadsheet application.
+func (xlsx *xlsxC) getTypedValueFrom(cellType CellType, d *xlsxSST, date1904 bool) interface{} {
+	raw := xlsx.getRawValueFrom(d)
+	switch cellType {
+	case CellTypeBool:
+		return raw == "1"
+	case CellTypeDate:
+		if xlsx.T == "d" {
+			if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+				return t
+			}
+			return raw
+		}
+		if excelTime, err := strconv.ParseFloat(raw, 64); err == nil {
+			return timeFromExcelTime(excelTime, date1904).Round(time.Millisecond)
+		}
+	case CellTypeNumber:
+		if number, err := strconv.ParseFloat(raw, 64); err == nil {
+			return number
+		}
+	}
+	return raw
+}
+
 // Rows defines an iterator to a sheet
 type Rows struct {
 	decoder *xml.Decoder
 	token   xml.Token
+	row     *xlsxRow
 	err     error
 	f       *File
 }
 
 // Next will return true if find the next row element.
 func (rows *Rows) Next() bool {
+	rows.row = nil
 	for {
 		rows.token, rows.err = rows.decoder.Token()
 		if rows.err == io.EOF {
@@ -126,14 +231,23 @@ func (rows *Rows) Error() error {
 	return rows.err
 }
 
+// currentRow provides a function to decode the current row element once, so
+// that Columns and Cells can be called on the same row.
+func (rows *Rows) currentRow() *xlsxRow {
+	if rows.row == nil {
+		startElement := rows.token.(xml.StartElement)
+		rows.row = &xlsxRow{}
+		_ = rows.decoder.DecodeElement(rows.row, &startElement)
+	}
+	return rows.row
+}
+
 // Columns return the current row's column values
 func (rows *Rows) Columns() ([]string, error) {
 	if rows.token == nil {
 		return []string{}, nil
 	}
-	startElement := rows.token.(xml.StartElement)
-	r := xlsxRow{}
-	_ = rows.decoder.DecodeElement(&r, &startElement)
+	r := rows.currentRow()
 	d := rows.f.sharedStringsReader()
 	columns := make([]string, len(r.C))
 	for _, colCell := range r.C {
@@ -147,6 +261,53 @@ func (rows *Rows) Columns() ([]string, error) {
 	return columns, nil
 }
 
+// RowCell defined the cell of the current row of the rows iterator with the
+// type, the raw value and the formatted value of the cell. The raw value is
+// the value without number format, such as the serial number of a date.
+type RowCell struct {
+	Axis  string
+	Type  CellType
+	Raw   string
+	Value string
+}
+
+// Cells return the current row's cells with their types, the raw values and
+// the formatted values, the blank cells are skipped. The row is decoded once,
+// so both Cells and Columns can be called on the same row. For example, read
+// the dates on Sheet1 with their serial numbers:
+//
+//    rows, err := f.Rows("Sheet1")
+//    for rows.Next() {
+//        cells, err := rows.Cells()
+//        for _, cell := range cells {
+//            if cell.Type == excelize.CellTypeDate {
+//                fmt.Println(cell.Axis, cell.Raw, cell.Value)
+//            }
+//        }
+//    }
+//
+func (rows *Rows) Cells() ([]RowCell, error) {
+	if rows.token == nil {
+		return []RowCell{}, nil
+	}
+	r := rows.currentRow()
+	d := rows.f.sharedStringsReader()
+	var cells []RowCell
+	for i := range r.C {
+		c := &r.C[i]
+		cellType := rows.f.cellType(c)
+		if cellType == CellTypeBlank {
+			continue
+		}
+		val, err := c.getValueFrom(rows.f, d)
+		if err != nil {
+			return cells, err
+		}
+		cells = append(cells, RowCell{Axis: c.R, Type: cellType, Raw: c.getRawValueFrom(d), Value: val})
+	}
+	return cells, nil
+}
+
 // ErrSheetNotExist defines an error of sheet is not exist
 type ErrSheetNotExist struct {
 	SheetName string
@@ -244,6 +405,77 @@ func (f *File) SetRowHeight(sheet string, row int, height float64) error {
 	return nil
 }
 
+// SetRowHeightAutoFit provides a function to set the height of the row to fit
+// the values of its cells by given worksheet name and Excel row number. The
+// wrapped text is broken into lines by the width of the column, or the width
+// of the merged columns for the merged cells of the row, and the height of
+// the lines is estimated by the size of the cell font. The merged cells
+// spanning several rows are skipped. For example, fit the height of the first
+// row in Sheet1:
+//
+//    err := f.SetRowHeightAutoFit("Sheet1", 1)
+//
+func (f *File) SetRowHeightAutoFit(sheet string, row int) error {
+	if row < 1 {
+		return newInvalidRowNumberError(row)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	height := defaultRowHeight
+	if row > len(xlsx.SheetData.Row) {
+		return f.SetRowHeight(sheet, row, height)
+	}
+	var merged [][]int
+	if xlsx.MergeCells != nil {
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			rect, err := areaRefToCoordinates(mergeCell.Ref)
+			if err == nil && rect[1] <= row && row <= rect[3] {
+				merged = append(merged, rect)
+			}
+		}
+	}
+	d := f.sharedStringsReader()
+	styleSheet := f.stylesReader()
+	for colIdx, c := range xlsx.SheetData.Row[row-1].C {
+		col, lastCol, skip := colIdx+1, colIdx+1, false
+		for _, rect := range merged {
+			if rect[0] <= col && col <= rect[2] {
+				skip, lastCol = rect[0] != col || rect[1] != rect[3], rect[2]
+			}
+		}
+		if skip {
+			continue
+		}
+		value, err := c.getValueFrom(f, d)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			continue
+		}
+		style := f.prepareCellStyle(xlsx, col, c.S)
+		lines := 1
+		if styleSheet.CellXfs != nil && style < len(styleSheet.CellXfs.Xf) &&
+			styleSheet.CellXfs.Xf[style].Alignment != nil && styleSheet.CellXfs.Xf[style].Alignment.WrapText {
+			var width float64
+			for i := col; i <= lastCol; i++ {
+				width += colWidth(xlsx, i)
+			}
+			lines = 0
+			for _, line := range strings.Split(value, "\n") {
+				lines += int(math.Max(1, math.Ceil(f.textWidth(line, style)/width)))
+			}
+		}
+		size, _ := f.cellFont(style)
+		if h := float64(lines) * size / defaultFontSize * defaultRowHeight; h > height {
+			height = h
+		}
+	}
+	return f.SetRowHeight(sheet, row, math.Min(height, maxRowHeight))
+}
+
 // getRowHeight provides a function to get row height in pixels by given sheet
 // name and row index.
 func (f *File) getRowHeight(sheet string, row int) int {
@@ -271,8 +503,12 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 	if err != nil {
 		return defaultRowHeightPixels, err
 	}
+	ht := defaultRowHeightPixels // it will be better to use 0, but we take care with BC
+	if xlsx.SheetFormatPr != nil && xlsx.SheetFormatPr.CustomHeight {
+		ht = xlsx.SheetFormatPr.DefaultRowHeight
+	}
 	if row > len(xlsx.SheetData.Row) {
-		return defaultRowHeightPixels, nil // it will be better to use 0, but we take care with BC
+		return ht, nil
 	}
 	for _, v := range xlsx.SheetData.Row {
 		if v.R == row && v.Ht != 0 {
@@ -280,7 +516,48 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 		}
 	}
 	// Optimisation for when the row heights haven't changed.
-	return defaultRowHeightPixels, nil
+	return ht, nil
+}
+
+// GetDefaultRowHeight provides a function to get the default height of the
+// rows without height by given worksheet name. For example, get the default
+// row height of Sheet1:
+//
+//    height, err := f.GetDefaultRowHeight("Sheet1")
+//
+func (f *File) GetDefaultRowHeight(sheet string) (float64, error) {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return defaultRowHeight, err
+	}
+	if xlsx.SheetFormatPr == nil || xlsx.SheetFormatPr.DefaultRowHeight == 0 {
+		return defaultRowHeight, err
+	}
+	return xlsx.SheetFormatPr.DefaultRowHeight, err
+}
+
+// SetDefaultRowHeight provides a function to set the default height of the
+// rows without height by given worksheet name and height in points, the rows
+// which have their own heights are left unchanged. GetRowHeight returns the
+// default height for the rows without height after setting it. For example,
+// set the default row height of Sheet1 to 20:
+//
+//    err := f.SetDefaultRowHeight("Sheet1", 20)
+//
+func (f *File) SetDefaultRowHeight(sheet string, height float64) error {
+	if height <= 0 || height > maxRowHeight {
+		return fmt.Errorf("invalid row height %v", height)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if xlsx.SheetFormatPr == nil {
+		xlsx.SheetFormatPr = &xlsxSheetFormatPr{}
+	}
+	xlsx.SheetFormatPr.DefaultRowHeight = height
+	xlsx.SheetFormatPr.CustomHeight = true
+	return err
 }
 
 // sharedStringsReader provides a function to get the pointer to the structure
@@ -323,6 +600,27 @@ func (xlsx *xlsxC) getValueFrom(f *File, d *xlsxSST) (string, error) {
 	}
 }
 
+// getRawValueFrom return a value from a column/row cell without the number
+// format, the shared strings and the inline strings are resolved.
+func (xlsx *xlsxC) getRawValueFrom(d *xlsxSST) string {
+	switch xlsx.T {
+	case "s":
+		xlsxSI, _ := strconv.Atoi(xlsx.V)
+		if len(d.SI[xlsxSI].R) > 0 {
+			value := ""
+			for _, v := range d.SI[xlsxSI].R {
+				value += v.T
+			}
+			return value
+		}
+		return d.SI[xlsxSI].T
+	case "inlineStr":
+		return xlsx.IS.T
+	default:
+		return xlsx.V
+	}
+}
+
 // SetRowVisible provides a function to set visible of a single row by given
 // worksheet name and Excel row number. For example, hide row 2 in Sheet1:
 //
@@ -363,6 +661,32 @@ func (f *File) GetRowVisible(sheet string, row int) (bool, error) {
 	return !xlsx.SheetData.Row[row-1].Hidden, nil
 }
 
+// GetFilteredRows provides a function to get the Excel row numbers of the
+// rows hidden by the auto filter by given worksheet name. The hidden rows in
+// the range of the auto filter below its header row are returned in
+// ascending order, and nil is returned if the worksheet has no auto filter.
+// For example, get the rows hidden by the auto filter in Sheet1:
+//
+//    rows, err := f.GetFilteredRows("Sheet1")
+//
+func (f *File) GetFilteredRows(sheet string) ([]int, error) {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil || xlsx.AutoFilter == nil {
+		return nil, err
+	}
+	coordinates, err := cellRefToCoordinates(xlsx.AutoFilter.Ref)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []int
+	for _, rowData := range xlsx.SheetData.Row {
+		if rowData.Hidden && coordinates[1] < rowData.R && rowData.R <= coordinates[3] {
+			filtered = append(filtered, rowData.R)
+		}
+	}
+	return filtered, err
+}
+
 // SetRowOutlineLevel provides a function to set outline level number of a
 // single row by given worksheet name and Excel row number. For example,
 // outline row 2 in Sheet1 to level 1:
@@ -402,6 +726,47 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 	return xlsx.SheetData.Row[row-1].OutlineLevel, nil
 }
 
+// RowFormatting directly maps the formatting settings of a row.
+type RowFormatting struct {
+	Style        int
+	Height       float64
+	CustomHeight bool
+	Hidden       bool
+	OutlineLevel uint8
+}
+
+// GetRowFormatting provides a function to get the style index, height,
+// visibility and outline level of a single row by given worksheet name and
+// Excel row number. The default height will be returned if the row doesn't
+// have custom height. For example, get the formatting of row 2 in Sheet1:
+//
+//    formatting, err := f.GetRowFormatting("Sheet1", 2)
+//
+func (f *File) GetRowFormatting(sheet string, row int) (RowFormatting, error) {
+	formatting := RowFormatting{Height: defaultRowHeightPixels}
+	if row < 1 {
+		return formatting, newInvalidRowNumberError(row)
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return formatting, err
+	}
+	if row > len(xlsx.SheetData.Row) {
+		return formatting, nil
+	}
+	r := xlsx.SheetData.Row[row-1]
+	if r.CustomFormat {
+		formatting.Style = r.S
+	}
+	if r.Ht != 0 {
+		formatting.Height = r.Ht
+	}
+	formatting.CustomHeight = r.CustomHeight
+	formatting.Hidden = r.Hidden
+	formatting.OutlineLevel = r.OutlineLevel
+	return formatting, nil
+}
+
 // RemoveRow provides a function to remove single row by given worksheet name
 // and Excel row number. For example, remove row 3 in Sheet1:
 //
@@ -411,7 +776,9 @@ func (f *File) GetRowOutlineLevel(sheet string, row int) (uint8, error) {
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) RemoveRow(sheet string, row int) error {
+func (f *File) RemoveRow(sheet string, row int) (err error) {
+	defer f.recoverAdjust(f.takeAdjustSnapshot(), &err)
+
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -423,6 +790,12 @@ func (f *File) RemoveRow(sheet string, row int) error {
 	if row > len(xlsx.SheetData.Row) {
 		return nil
 	}
+	if err = promoteMergeCellAnchors(xlsx, newAdjustMapping(row, -1)); err != nil {
+		return err
+	}
+	if err = f.promoteSharedFormulas(xlsx, rows, newAdjustMapping(row, -1)); err != nil {
+		return err
+	}
 	for rowIdx := range xlsx.SheetData.Row {
 		if xlsx.SheetData.Row[rowIdx].R == row {
 			xlsx.SheetData.Row = append(xlsx.SheetData.Row[:rowIdx],
@@ -433,19 +806,152 @@ func (f *File) RemoveRow(sheet string, row int) error {
 	return nil
 }
 
+// RemoveRowWithStats provides a function to remove single row by given
+// worksheet name and Excel row number like RemoveRow, and returns the changes
+// of the worksheet made by removing the row, such as the number of the cells
+// moved. For example, remove row 3 in Sheet1:
+//
+//    stats, err := f.RemoveRowWithStats("Sheet1", 3)
+//
+func (f *File) RemoveRowWithStats(sheet string, row int) (AdjustStats, error) {
+	return f.adjustStatsDelta(sheet, func() error {
+		return f.RemoveRow(sheet, row)
+	})
+}
+
+// RemoveRowsByIndex provides a function to remove multiple rows by given
+// worksheet name and Excel row numbers in one pass. The result is the same as
+// removing the rows one by one from the bottom, but the cells of the
+// worksheet are only renumbered once. For example, remove rows 3, 5 and 6 in
+// Sheet1:
+//
+//    err := f.RemoveRowsByIndex("Sheet1", []int{3, 5, 6})
+//
+// Use this method with caution, which will affect changes in references such
+// as formulas, charts, and so on. If there is any referenced value of the
+// worksheet, it will cause a file error when you open it. The excelize only
+// partially updates these references currently.
+func (f *File) RemoveRowsByIndex(sheet string, rowNums []int) (err error) {
+	defer f.recoverAdjust(f.takeAdjustSnapshot(), &err)
+
+	for _, row := range rowNums {
+		if row < 1 {
+			return newInvalidRowNumberError(row)
+		}
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	removed := make([]int, 0, len(rowNums))
+	for _, row := range rowNums {
+		if row <= len(xlsx.SheetData.Row) {
+			removed = append(removed, row)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	sort.Ints(removed)
+	unique := removed[:1]
+	for _, row := range removed[1:] {
+		if row != unique[len(unique)-1] {
+			unique = append(unique, row)
+		}
+	}
+	m := newRemoveMapping(unique)
+	if err = promoteMergeCellAnchors(xlsx, m); err != nil {
+		return err
+	}
+	if err = f.promoteSharedFormulas(xlsx, rows, m); err != nil {
+		return err
+	}
+	cache := cellCoordinatesCache{}
+	stats := newAdjustStatsCollector(xlsx, cache, rows, m.num)
+	stats.cellsShifted = f.removeRowDimensions(xlsx, m.deleted)
+	if err = f.adjustCellReferences(sheet, xlsx, cache, rows, m); err != nil {
+		return err
+	}
+	if err = f.adjustTables(sheet, xlsx, rows, m); err != nil {
+		return err
+	}
+	checkSheet(xlsx)
+	checkRow(xlsx)
+	adjustRowOutlines(xlsx, m.num, m.offset)
+	stats.collect(f.adjustStatsReader(f.sheetMap[trimSheetName(sheet)]), xlsx)
+	return nil
+}
+
+// promoteMergeCellAnchors provides a function to move the top-left cell of
+// the merged cells which start at the rows to be deleted and span the rows
+// below them, to the first row of the merged cells which is left after
+// deleting the rows, so the merged cells still show the value after deleting
+// the rows. The cell is not moved if the cell of that row isn't empty.
+func promoteMergeCellAnchors(xlsx *xlsxWorksheet, m adjustMapping) error {
+	if xlsx.MergeCells == nil {
+		return nil
+	}
+	for _, mergeCell := range xlsx.MergeCells.Cells {
+		area, err := areaRefToCoordinates(mergeCell.Ref)
+		if err != nil {
+			return err
+		}
+		next := area[1]
+		for next <= area[3] && m.isDeleted(next) {
+			next++
+		}
+		if next == area[1] || next > area[3] {
+			continue
+		}
+		cells := xlsx.SheetData.Row[area[1]-1].C
+		if area[0] > len(cells) || isEmptyCellValue(cells[area[0]-1]) {
+			continue
+		}
+		prepareSheetXML(xlsx, area[0], next)
+		target := &xlsx.SheetData.Row[next-1].C[area[0]-1]
+		if !isEmptyCellValue(*target) {
+			continue
+		}
+		anchor := xlsx.SheetData.Row[area[1]-1].C[area[0]-1]
+		anchor.R = target.R
+		*target = anchor
+	}
+	return nil
+}
+
+// isEmptyCellValue reports whether the cell has neither value nor formula.
+func isEmptyCellValue(c xlsxC) bool {
+	return c.V == "" && c.F == nil && c.IS == nil
+}
+
 // InsertRow provides a function to insert a new row after given Excel row
 // number starting from 1. For example, create a new row before row 3 in
 // Sheet1:
 //
 //    err := f.InsertRow("Sheet1", 3)
 //
-func (f *File) InsertRow(sheet string, row int) error {
+func (f *File) InsertRow(sheet string, row int) (err error) {
+	defer f.recoverAdjust(f.takeAdjustSnapshot(), &err)
+
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
 	return f.adjustHelper(sheet, rows, row, 1)
 }
 
+// InsertRowWithStats provides a function to insert a new row before given
+// Excel row number like InsertRow, and returns the changes of the worksheet
+// made by inserting the row, such as the number of the cells moved. For
+// example, create a new row before row 3 in Sheet1:
+//
+//    stats, err := f.InsertRowWithStats("Sheet1", 3)
+//
+func (f *File) InsertRowWithStats(sheet string, row int) (AdjustStats, error) {
+	return f.adjustStatsDelta(sheet, func() error {
+		return f.InsertRow(sheet, row)
+	})
+}
+
 // DuplicateRow inserts a copy of specified row (by its Excel row number) below
 //
 //    err := f.DuplicateRow("Sheet1", 2)
@@ -467,7 +973,9 @@ func (f *File) DuplicateRow(sheet string, row int) error {
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) DuplicateRowTo(sheet string, row, row2 int) error {
+func (f *File) DuplicateRowTo(sheet string, row, row2 int) (err error) {
+	defer f.recoverAdjust(f.takeAdjustSnapshot(), &err)
+
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -597,3 +1105,147 @@ func convertRowHeightToPixels(height float64) float64 {
 	pixels = math.Ceil(4.0 / 3.0 * height)
 	return pixels
 }
+
+// CellDiff directly maps a difference of a cell between two worksheets found
+// by RowDiff. Field is one of "value", "style", "formula" and "merge", Value
+// and OtherValue are the values of the field in the worksheet and in the
+// other worksheet. The style is compared by the definition of the style given
+// by GetStyleDefinition, so the workbooks with different style tables can be
+// compared, and the style is empty if it's the same as the default style. The
+// difference of the merged cells is reported at the top-left cell of the
+// merged cells with the ranges of the merged cells as the values.
+type CellDiff struct {
+	Cell       string
+	Field      string
+	Value      string
+	OtherValue string
+}
+
+// diffCell records the fields of a cell compared by RowDiff.
+type diffCell struct {
+	value, style, formula, merge string
+}
+
+// RowDiff provides a function to compare the worksheet with the worksheet of
+// another workbook row by row, and returns the differences of the values,
+// the styles, the formulas and the merged cells ordered by row and column.
+// It helps to verify the result of editing a workbook, such as inserting or
+// deleting rows or columns. For example, compare Sheet1 of the workbook with
+// Sheet1 of the expected workbook:
+//
+//    diffs, err := f.RowDiff("Sheet1", expected, "Sheet1")
+//
+func (f *File) RowDiff(sheet string, other *File, otherSheet string) ([]CellDiff, error) {
+	cells, err := f.diffCells(sheet)
+	if err != nil {
+		return nil, err
+	}
+	otherCells, err := other.diffCells(otherSheet)
+	if err != nil {
+		return nil, err
+	}
+	coordinates := make([][2]int, 0, len(cells))
+	for key := range cells {
+		coordinates = append(coordinates, key)
+	}
+	for key := range otherCells {
+		if _, ok := cells[key]; !ok {
+			coordinates = append(coordinates, key)
+		}
+	}
+	sort.Slice(coordinates, func(i, j int) bool {
+		if coordinates[i][1] != coordinates[j][1] {
+			return coordinates[i][1] < coordinates[j][1]
+		}
+		return coordinates[i][0] < coordinates[j][0]
+	})
+	var diffs []CellDiff
+	for _, key := range coordinates {
+		var cell, otherCell diffCell
+		if c, ok := cells[key]; ok {
+			cell = *c
+		}
+		if c, ok := otherCells[key]; ok {
+			otherCell = *c
+		}
+		name, _ := CoordinatesToCellName(key[0], key[1])
+		for _, field := range []struct {
+			name         string
+			value, other string
+		}{
+			{"value", cell.value, otherCell.value},
+			{"style", cell.style, otherCell.style},
+			{"formula", cell.formula, otherCell.formula},
+			{"merge", cell.merge, otherCell.merge},
+		} {
+			if field.value != field.other {
+				diffs = append(diffs, CellDiff{Cell: name, Field: field.name, Value: field.value, OtherValue: field.other})
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// diffCells provides a function to get the fields of the cells compared by
+// RowDiff keyed by the column and row number of the cells. The empty cells
+// are skipped.
+func (f *File) diffCells(sheet string) (map[[2]int]*diffCell, error) {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	cells := map[[2]int]*diffCell{}
+	cellReader := func(col, row int) *diffCell {
+		cell, ok := cells[[2]int{col, row}]
+		if !ok {
+			cell = &diffCell{}
+			cells[[2]int{col, row}] = cell
+		}
+		return cell
+	}
+	sst := f.sharedStringsReader()
+	defaultStyle, _ := f.GetStyleDefinition(0)
+	styles := map[int]string{0: ""}
+	styleReader := func(styleID int) string {
+		style, ok := styles[styleID]
+		if !ok {
+			var err error
+			if style, err = f.GetStyleDefinition(styleID); err != nil {
+				style = strconv.Itoa(styleID)
+			} else if style == defaultStyle {
+				style = ""
+			}
+			styles[styleID] = style
+		}
+		return style
+	}
+	for _, rowData := range xlsx.SheetData.Row {
+		for _, colData := range rowData.C {
+			col, row, err := CellNameToCoordinates(colData.R)
+			if err != nil {
+				return nil, err
+			}
+			value, _ := colData.getValueFrom(f, sst)
+			var formula string
+			if colData.F != nil {
+				formula = colData.F.Content
+			}
+			style := styleReader(colData.S)
+			if value == "" && formula == "" && style == "" {
+				continue
+			}
+			cell := cellReader(col, row)
+			cell.value, cell.style, cell.formula = value, style, formula
+		}
+	}
+	if xlsx.MergeCells != nil {
+		for _, mergeCell := range xlsx.MergeCells.Cells {
+			coordinates, err := areaRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return nil, err
+			}
+			cellReader(coordinates[0], coordinates[1]).merge = mergeCell.Ref
+		}
+	}
+	return cells, nil
+}
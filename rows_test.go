@@ -3,8 +3,12 @@ package excelize
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/mohae/deepcopy"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,6 +48,104 @@ func TestRows(t *testing.T) {
 	r.Columns()
 }
 
+func TestRowsCells(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Date", "Amount", true}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC), 2.5}))
+	// Test the cells reflect the worksheet after inserting a row.
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var cells []RowCell
+	for rows.Next() {
+		rowCells, err := rows.Cells()
+		assert.NoError(t, err)
+		cells = append(cells, rowCells...)
+	}
+	assert.NoError(t, rows.Error())
+	assert.Equal(t, []RowCell{
+		{Axis: "A1", Type: CellTypeString, Raw: "Date", Value: "Date"},
+		{Axis: "B1", Type: CellTypeString, Raw: "Amount", Value: "Amount"},
+		{Axis: "C1", Type: CellTypeBool, Raw: "1", Value: "1"},
+		{Axis: "A3", Type: CellTypeDate, Raw: "43739", Value: "10/1/19 00:00"},
+		{Axis: "B3", Type: CellTypeNumber, Raw: "2.5", Value: "2.5"},
+	}, cells)
+
+	// Test call both Columns and Cells on the same row.
+	rows, err = f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var columns [][]string
+	cells = nil
+	for rows.Next() {
+		rowCells, err := rows.Cells()
+		assert.NoError(t, err)
+		cells = append(cells, rowCells...)
+		row, err := rows.Columns()
+		assert.NoError(t, err)
+		columns = append(columns, row)
+	}
+	assert.NoError(t, rows.Error())
+	assert.Len(t, cells, 5)
+	assert.Equal(t, [][]string{{"Date", "Amount", "1"}, {}, {"10/1/19 00:00", "2.5"}}, columns)
+
+	r := Rows{}
+	cells, err = r.Cells()
+	assert.NoError(t, err)
+	assert.Empty(t, cells)
+}
+
+func TestGetRowValues(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2019, 10, 1, 12, 30, 0, 0, time.UTC)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount", "Paid", "Date"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Excelize", 2.5, true, date, nil, 10}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H2", "B2*2"))
+	values, err := f.GetRowValues("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Excelize", 2.5, true, date, nil, 10.0}, values)
+
+	// Test read back the shifted row with the types after inserting a row
+	// above it.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	values, err = f.GetRowValues("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Excelize", 2.5, true, date, nil, 10.0}, values)
+	values, err = f.GetRowValues("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"Name", "Amount", "Paid", "Date"}, values)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetRowValues.xlsx")))
+
+	// Test get the values of the empty rows.
+	values, err = f.GetRowValues("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+	values, err = f.GetRowValues("Sheet1", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+
+	// Test get the values of the date, the error and the invalid values.
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.SheetData.Row[2].C = []xlsxC{
+		{R: "A3", T: "d", V: "2019-10-01T12:30:00Z"},
+		{R: "B3", T: "d", V: "October"},
+		{R: "C3", T: "e", V: "#DIV/0!"},
+		{R: "D3", V: "N/A"},
+		{R: "E3", S: xlsx.SheetData.Row[2].C[3].S, V: "N/A"},
+	}
+	values, err = f.GetRowValues("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{date, "October", "#DIV/0!", "N/A", "N/A"}, values)
+
+	xlsx.SheetData.Row[2].C = []xlsxC{{R: "A", V: "1"}}
+	_, err = f.GetRowValues("Sheet1", 3)
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	_, err = f.GetRowValues("Sheet1", 0)
+	assert.EqualError(t, err, "invalid row number 0")
+	_, err = f.GetRowValues("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestRowsError(t *testing.T) {
 	xlsx, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -95,6 +197,109 @@ func TestRowHeight(t *testing.T) {
 	convertColWidthToPixels(0)
 }
 
+func TestDefaultRowHeight(t *testing.T) {
+	f := NewFile()
+	height, err := f.GetDefaultRowHeight("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRowHeight, height)
+
+	assert.NoError(t, f.SetDefaultRowHeight("Sheet1", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "A4"))
+	// Test the default row height is kept on inserting and deleting rows.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.InsertRow("Sheet1", 4))
+	assert.NoError(t, f.RemoveRow("Sheet1", 2))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefaultRowHeight.xlsx")))
+
+	f, err = OpenFile(filepath.Join("test", "TestDefaultRowHeight.xlsx"))
+	assert.NoError(t, err)
+	height, err = f.GetDefaultRowHeight("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, height)
+	for row, expected := range map[int]float64{1: 20, 2: 30, 3: 20, 4: 20, 5: 20, 10: 20} {
+		height, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, height, row)
+	}
+
+	// Test set the default row height with invalid arguments.
+	assert.EqualError(t, f.SetDefaultRowHeight("Sheet1", 0), "invalid row height 0")
+	assert.EqualError(t, f.SetDefaultRowHeight("Sheet1", 410), "invalid row height 410")
+	assert.EqualError(t, f.SetDefaultRowHeight("SheetN", 20), "sheet SheetN is not exist")
+	_, err = f.GetDefaultRowHeight("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.SheetFormatPr = nil
+	assert.NoError(t, f.SetDefaultRowHeight("Sheet1", 12.75))
+	height, err = f.GetDefaultRowHeight("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.75, height)
+}
+
+func TestSetRowHeightAutoFit(t *testing.T) {
+	f := NewFile()
+	height := func(row int) float64 {
+		height, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		return height
+	}
+	wrap, err := f.NewStyle(`{"alignment":{"wrap_text":true}}`)
+	assert.NoError(t, err)
+	text := strings.Repeat("text ", 10)
+
+	// Test the text without wrapping fits in one line.
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", text))
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 1))
+	assert.Equal(t, defaultRowHeight, height(1))
+
+	// Test the wrapped text forces a taller row.
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", wrap))
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 1))
+	wrapped := height(1)
+	assert.True(t, wrapped > defaultRowHeight)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", text+"\n"+text))
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 1))
+	assert.Equal(t, wrapped*2, height(1))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 40))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", text))
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 1))
+	assert.Equal(t, defaultRowHeight, height(1))
+
+	// Test the larger font forces a taller row.
+	style, err := f.NewStyle(`{"font":{"size":22}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "text"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", style))
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 2))
+	assert.Equal(t, defaultRowHeight*2, height(2))
+
+	// Test the wrapped text in the merged cells uses the merged columns.
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", text))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B3", wrap))
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 3))
+	unmerged := height(3)
+	assert.NoError(t, f.MergeCell("Sheet1", "B3", "E3"))
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 3))
+	assert.True(t, height(3) < unmerged)
+
+	// Test the merged cells spanning several rows are skipped.
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", text))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B4", "B4", wrap))
+	assert.NoError(t, f.MergeCell("Sheet1", "B4", "B5"))
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 4))
+	assert.Equal(t, defaultRowHeight, height(4))
+
+	// Test fit the height of the row without cells.
+	assert.NoError(t, f.SetRowHeightAutoFit("Sheet1", 10))
+	assert.Equal(t, defaultRowHeight, height(10))
+
+	// Test auto fit row height with invalid arguments.
+	assert.EqualError(t, f.SetRowHeightAutoFit("Sheet1", 0), "invalid row number 0")
+	assert.EqualError(t, f.SetRowHeightAutoFit("SheetN", 1), "sheet SheetN is not exist")
+}
+
 func TestRowVisibility(t *testing.T) {
 	xlsx, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -114,6 +319,77 @@ func TestRowVisibility(t *testing.T) {
 	assert.NoError(t, xlsx.SaveAs(filepath.Join("test", "TestRowVisibility.xlsx")))
 }
 
+func TestGetFilteredRows(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 8; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(row), row))
+	}
+	// Test get the filtered rows without auto filter.
+	filtered, err := f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, filtered)
+
+	assert.NoError(t, f.AutoFilter("Sheet1", "A2", "A7", `{"column":"A","expression":"x != 4"}`))
+	for _, row := range []int{1, 4, 6, 8} {
+		assert.NoError(t, f.SetRowVisible("Sheet1", row, false))
+	}
+	filtered, err = f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 6}, filtered)
+
+	// Test the filtered rows shift on deleting a row.
+	assert.NoError(t, f.RemoveRow("Sheet1", 5))
+	filtered, err = f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, filtered)
+	assert.NoError(t, f.RemoveRow("Sheet1", 4))
+	filtered, err = f.GetFilteredRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4}, filtered)
+
+	// Test get the filtered rows with invalid arguments.
+	_, err = f.GetFilteredRows("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.AutoFilter.Ref = "A1:A"
+	_, err = f.GetFilteredRows("Sheet1")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestGetRowFormatting(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(`{"fill":{"type":"pattern","color":["#E0EBF5"],"pattern":1}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 2, false))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 2, 1))
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.SheetData.Row[1].S, xlsx.SheetData.Row[1].CustomFormat = style, true
+
+	expected := RowFormatting{Style: style, Height: 30, CustomHeight: true, Hidden: true, OutlineLevel: 1}
+	formatting, err := f.GetRowFormatting("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, formatting)
+
+	// Test get the formatting of the row after inserting a row before it.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	formatting, err = f.GetRowFormatting("Sheet1", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, formatting)
+	for _, row := range []int{2, 100} {
+		formatting, err = f.GetRowFormatting("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, RowFormatting{Height: defaultRowHeightPixels}, formatting)
+	}
+
+	_, err = f.GetRowFormatting("Sheet1", 0)
+	assert.EqualError(t, err, "invalid row number 0")
+	_, err = f.GetRowFormatting("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
 func TestRemoveRow(t *testing.T) {
 	xlsx := NewFile()
 	sheet1 := xlsx.GetSheetName(1)
@@ -202,6 +478,300 @@ func TestInsertRow(t *testing.T) {
 	assert.NoError(t, xlsx.SaveAs(filepath.Join("test", "TestInsertRow.xlsx")))
 }
 
+func TestInsertRowSharedStrings(t *testing.T) {
+	f := NewFile()
+	const (
+		colCount = 5
+		rowCount = 20
+	)
+	sst := f.sharedStringsReader()
+	expected := make([][]string, rowCount)
+	for row := 1; row <= rowCount; row++ {
+		for col := 1; col <= colCount; col++ {
+			cell, err := CoordinatesToCellName(col, row)
+			assert.NoError(t, err)
+			// Set the cells of the same column with the same shared string.
+			assert.NoError(t, f.SetCellValue("Sheet1", cell, 0))
+			xlsx, err := f.workSheetReader("Sheet1")
+			assert.NoError(t, err)
+			xlsx.SheetData.Row[row-1].C[col-1].T = "s"
+			xlsx.SheetData.Row[row-1].C[col-1].V = strconv.Itoa(col - 1)
+			expected[row-1] = append(expected[row-1], "string "+strconv.Itoa(col))
+		}
+	}
+	for col := 1; col <= colCount; col++ {
+		sst.SI = append(sst.SI, xlsxSI{T: "string " + strconv.Itoa(col)})
+	}
+	sst.Count, sst.UniqueCount = colCount*rowCount, colCount
+	snapshot := deepcopy.Copy(*sst).(xlsxSST)
+
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	assert.NoError(t, f.InsertCol("Sheet1", "C"))
+	assert.Equal(t, snapshot, *f.sharedStringsReader())
+	expected = append(expected[:2], append([][]string{nil}, expected[2:]...)...)
+	for i := range expected {
+		if len(expected[i]) > 0 {
+			expected[i] = append(expected[i][:2], append([]string{""}, expected[i][2:]...)...)
+		}
+	}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, rows, rowCount+1) {
+		for i := range rows {
+			if len(expected[i]) == 0 {
+				expected[i] = make([]string, colCount+1)
+			}
+			assert.Equal(t, expected[i], rows[i], i)
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertRowSharedStrings.xlsx")))
+}
+
+func TestInsertAndRemoveRowWithStats(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{1, 2, 3}))
+	}
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "B3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C4", "C5"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A3", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B5", "Sheet1!A1", "Location"))
+
+	stats, err := f.InsertRowWithStats("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, AdjustStats{CellsShifted: 12, MergeCellsAdjusted: 2, HyperlinksAdjusted: 2}, stats)
+	// Test remove the row of the hyperlink and the last row of merged cells.
+	stats, err = f.RemoveRowWithStats("Sheet1", 6)
+	assert.NoError(t, err)
+	assert.Equal(t, AdjustStats{MergeCellsDropped: 1, HyperlinksDropped: 1}, stats)
+	stats, err = f.RemoveRowWithStats("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, AdjustStats{CellsShifted: 9, MergeCellsAdjusted: 1, HyperlinksAdjusted: 1}, stats)
+	// Test the cumulative counters include the changes.
+	total, err := f.GetAdjustStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 21, total.CellsShifted)
+
+	stats, err = f.InsertRowWithStats("Sheet1", 0)
+	assert.EqualError(t, err, "invalid row number 0")
+	assert.Equal(t, AdjustStats{}, stats)
+	_, err = f.RemoveRowWithStats("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestInsertRowInCollapsedGroup(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 6; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(row), row))
+	}
+	// Group the rows 2:4 with the collapsed summary row 5.
+	for row := 2; row <= 4; row++ {
+		assert.NoError(t, f.SetRowOutlineLevel("Sheet1", row, 1))
+		assert.NoError(t, f.SetRowVisible("Sheet1", row, false))
+	}
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.SheetData.Row[4].Collapsed = true
+
+	// Test insert row inside of the collapsed group.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	// Test insert row before the first row of the group, and between the last
+	// row of the group and the summary row.
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	assert.NoError(t, f.InsertRow("Sheet1", 7))
+	for idx, expected := range []struct {
+		level     uint8
+		hidden    bool
+		collapsed bool
+	}{{0, false, false}, {0, false, false}, {1, true, false}, {1, true, false}, {1, true, false}, {1, true, false}, {1, true, false}, {0, false, true}, {0, false, false}} {
+		row := xlsx.SheetData.Row[idx]
+		assert.Equal(t, expected.level, row.OutlineLevel, row.R)
+		assert.Equal(t, expected.hidden, row.Hidden, row.R)
+		assert.Equal(t, expected.collapsed, row.Collapsed, row.R)
+	}
+
+	// Test insert row inside of the expanded group.
+	assert.NoError(t, f.SetRowVisible("Sheet1", 4, true))
+	assert.NoError(t, f.InsertRow("Sheet1", 4))
+	level, err := f.GetRowOutlineLevel("Sheet1", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), level)
+	visible, err := f.GetRowVisible("Sheet1", 4)
+	assert.NoError(t, err)
+	assert.True(t, visible)
+
+	// Test remove all rows of the group.
+	for row := 3; row <= 8; row++ {
+		assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	}
+	assert.Equal(t, "5", xlsx.SheetData.Row[2].C[0].V)
+	assert.False(t, xlsx.SheetData.Row[2].Collapsed)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertRowInCollapsedGroup.xlsx")))
+}
+
+func TestRemoveRowMergeCellAnchor(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "A3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B1", "B2"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C2", "C3"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "ROW()"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "B1"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C2", "C2"))
+
+	// Test remove the row of the top-left cell of the vertical merged cells.
+	assert.NoError(t, f.RemoveRow("Sheet1", 1))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 2) {
+		assert.Equal(t, MergeCell{"A1:A2", "A1"}, mergeCells[0])
+		assert.Equal(t, MergeCell{"C1:C2", "C2"}, mergeCells[1])
+	}
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ROW()", formula)
+	// Test the top-left cell is not moved while the next cell isn't empty.
+	val, err := f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", val)
+
+	// Test remove the row of merged cells with illegal cell coordinates.
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.MergeCells.Cells[0].Ref = "A:A2"
+	assert.EqualError(t, f.RemoveRow("Sheet1", 1), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestRemoveRowsByIndex(t *testing.T) {
+	prepare := func() *File {
+		f := NewFile()
+		for row := 1; row <= 30; row++ {
+			assert.NoError(t, f.SetSheetRow("Sheet1", "A"+strconv.Itoa(row), &[]interface{}{row, row * 2}))
+			assert.NoError(t, f.SetCellFormula("Sheet1", "C"+strconv.Itoa(row), fmt.Sprintf("SUM(A%d:B%d)", row, row)))
+		}
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(A1:A30)"))
+		assert.NoError(t, f.MergeCell("Sheet1", "A4", "B8"))
+		assert.NoError(t, f.MergeCell("Sheet1", "D10", "D12"))
+		assert.NoError(t, f.MergeCell("Sheet1", "E20", "E21"))
+		assert.NoError(t, f.SetCellValue("Sheet1", "G4", "merged"))
+		assert.NoError(t, f.MergeCell("Sheet1", "G4", "G12"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "A15", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", "A25", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+		f.NewSheet("Sheet2")
+		assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "SUM(Sheet1!A1:A30)+Sheet1!A11+Sheet1!B26+Sheet1!A5:A12"))
+		assert.NoError(t, f.SetCellHyperLink("Sheet2", "A2", "Sheet1!A22", "Location"))
+		return f
+	}
+	removed := []int{25, 4, 10, 11, 20, 21, 5, 30, 11, 100}
+
+	// Test the result is the same as removing the rows one by one.
+	f1, f2 := prepare(), prepare()
+	assert.NoError(t, f1.RemoveRowsByIndex("Sheet1", removed))
+	for _, row := range []int{100, 30, 25, 21, 20, 11, 10, 5, 4} {
+		assert.NoError(t, f2.RemoveRow("Sheet1", row))
+	}
+	f1.workSheetWriter()
+	f2.workSheetWriter()
+	assert.Equal(t, string(f2.XLSX["xl/worksheets/sheet1.xml"]), string(f1.XLSX["xl/worksheets/sheet1.xml"]))
+	assert.Equal(t, string(f2.XLSX["xl/worksheets/sheet2.xml"]), string(f1.XLSX["xl/worksheets/sheet2.xml"]))
+	stats1, err := f1.GetAdjustStats("Sheet1")
+	assert.NoError(t, err)
+	stats2, err := f2.GetAdjustStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, stats2.HyperlinksDropped, stats1.HyperlinksDropped)
+	assert.Equal(t, stats2.MergeCellsDropped, stats1.MergeCellsDropped)
+	rows, err := f1.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 22)
+	assert.Equal(t, "3", rows[2][0])
+	assert.Equal(t, "6", rows[3][0])
+	// Test the top-left cell of the merged cells is moved across the runs of
+	// the removed rows.
+	cell, err := f1.GetCellValue("Sheet1", "G4")
+	assert.NoError(t, err)
+	assert.Equal(t, "merged", cell)
+
+	// Test remove rows without the rows.
+	assert.NoError(t, f1.RemoveRowsByIndex("Sheet1", nil))
+	assert.NoError(t, f1.RemoveRowsByIndex("Sheet1", []int{100}))
+
+	assert.EqualError(t, f1.RemoveRowsByIndex("Sheet1", []int{1, 0}), "invalid row number 0")
+	assert.EqualError(t, f1.RemoveRowsByIndex("SheetN", []int{1}), "sheet SheetN is not exist")
+
+	// Test remove rows of merged cells with illegal cell coordinates.
+	xlsx, err := f1.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	xlsx.MergeCells.Cells[0].Ref = "A:A2"
+	assert.EqualError(t, f1.RemoveRowsByIndex("Sheet1", []int{1}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.NoError(t, f2.SaveAs(filepath.Join("test", "TestRemoveRowsByIndex.xlsx")))
+}
+
+func TestRemoveRowsByIndexHyperlinks(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 12; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", "A"+strconv.Itoa(row), row))
+	}
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A11", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A12", "Sheet1!A1", "Location"))
+	// Test the hyperlinks of all the rows of a run are removed.
+	assert.NoError(t, f.RemoveRowsByIndex("Sheet1", []int{10, 11}))
+	links, err := f.GetHyperLinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []HyperLink{{Ref: "A10", Type: "Location", Target: "Sheet1!A1"}}, links)
+	rels := f.workSheetRelsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	if rels != nil {
+		assert.Empty(t, rels.Relationships)
+	}
+}
+
+func BenchmarkRemoveRowsByIndex(b *testing.B) {
+	prepare := func(b *testing.B) (*File, []int) {
+		f := NewFile()
+		removed := make([]int, 0, 1000)
+		for row := 1; row <= 2000; row++ {
+			r := strconv.Itoa(row)
+			if err := f.SetSheetRow("Sheet1", "A"+r, &[]interface{}{row, row * 2, row * 3}); err != nil {
+				b.Error(err)
+			}
+			if err := f.SetCellFormula("Sheet1", "D"+r, "SUM(A"+r+":C"+r+")+$A$2000"); err != nil {
+				b.Error(err)
+			}
+			if row%10 == 1 {
+				if err := f.MergeCell("Sheet1", "E"+r, "F"+strconv.Itoa(row+5)); err != nil {
+					b.Error(err)
+				}
+			}
+			if row%2 == 0 {
+				removed = append(removed, row)
+			}
+		}
+		return f, removed
+	}
+	b.Run("RemoveRowsByIndex", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			f, removed := prepare(b)
+			b.StartTimer()
+			if err := f.RemoveRowsByIndex("Sheet1", removed); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	b.Run("RemoveRow", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			f, removed := prepare(b)
+			b.StartTimer()
+			for idx := len(removed) - 1; idx >= 0; idx-- {
+				if err := f.RemoveRow("Sheet1", removed[idx]); err != nil {
+					b.Error(err)
+				}
+			}
+		}
+	})
+}
+
 // Testing internal sructure state after insert operations.
 // It is important for insert workflow to be constant to avoid side effect with functions related to internal structure.
 func TestInsertRowInEmptyFile(t *testing.T) {
@@ -682,3 +1252,107 @@ func trimSliceSpace(s []string) []string {
 	}
 	return s
 }
+
+func TestRowDiff(t *testing.T) {
+	expected := NewFile()
+	assert.NoError(t, expected.SetSheetRow("Sheet1", "A1", &[]interface{}{"a", 1}))
+	assert.NoError(t, expected.SetSheetRow("Sheet1", "A3", &[]interface{}{"b", 2}))
+	assert.NoError(t, expected.SetCellFormula("Sheet1", "C3", "SUM(B1:B3)"))
+	assert.NoError(t, expected.MergeCell("Sheet1", "D3", "E4"))
+
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"a", 1}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"b", 2}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "SUM(B1:B2)"))
+	assert.NoError(t, f.MergeCell("Sheet1", "D2", "E3"))
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+
+	// Test compare the worksheets without differences.
+	diffs, err := f.RowDiff("Sheet1", expected, "Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	// Test detect the shifted value, the misplaced merged cells and the
+	// changed style.
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", nil))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B4", 2))
+	f.Sheet["xl/worksheets/sheet1.xml"].MergeCells.Cells[0].Ref = "D4:E5"
+	style, err := f.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	definition, err := f.GetStyleDefinition(style)
+	assert.NoError(t, err)
+	diffs, err = f.RowDiff("Sheet1", expected, "Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []CellDiff{
+		{Cell: "A1", Field: "style", Value: definition, OtherValue: ""},
+		{Cell: "B3", Field: "value", Value: "", OtherValue: "2"},
+		{Cell: "D3", Field: "merge", Value: "", OtherValue: "D3:E4"},
+		{Cell: "B4", Field: "value", Value: "2", OtherValue: ""},
+		{Cell: "D4", Field: "merge", Value: "D4:E5", OtherValue: ""},
+	}, diffs)
+
+	// Test compare the worksheets of the workbooks with different style
+	// tables, the same style with different indexes is not a difference.
+	_, err = expected.NewStyle(`{"font":{"italic":true}}`)
+	assert.NoError(t, err)
+	otherStyle, err := expected.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NotEqual(t, style, otherStyle)
+	assert.NoError(t, expected.SetCellStyle("Sheet1", "A1", "A1", otherStyle))
+	diffs, err = f.RowDiff("Sheet1", expected, "Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, diffs, 4)
+	assert.Equal(t, "B3", diffs[0].Cell)
+	// Test compare the cells with different definitions of the styles.
+	expected.Styles.CellXfs.Xf[otherStyle].FontID = expected.Styles.CellXfs.Xf[otherStyle-1].FontID
+	diffs, err = f.RowDiff("Sheet1", expected, "Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, diffs, 5)
+	assert.Equal(t, CellDiff{Cell: "A1", Field: "style", Value: definition, OtherValue: diffs[0].OtherValue}, diffs[0])
+	assert.NotEqual(t, definition, diffs[0].OtherValue)
+
+	// Test compare the worksheets with invalid arguments.
+	_, err = f.RowDiff("SheetN", expected, "Sheet1")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	_, err = f.RowDiff("Sheet1", expected, "SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+	f.Sheet["xl/worksheets/sheet1.xml"].MergeCells.Cells[0].Ref = "D4"
+	_, err = f.RowDiff("Sheet1", expected, "Sheet1")
+	assert.EqualError(t, err, `invalid area "D4"`)
+	f.Sheet["xl/worksheets/sheet1.xml"].SheetData.Row[0].C[0].R = "A"
+	_, err = f.RowDiff("Sheet1", expected, "Sheet1")
+	assert.EqualError(t, err, `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+}
+
+func TestGetUsedRange(t *testing.T) {
+	f := NewFile()
+	// Test get the used range of the empty worksheet.
+	rows, err := f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+
+	// Test trim the trailing empty rows and columns of the sparse worksheet.
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "A4"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "D3", "D3"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "F6", ""))
+	style, err := f.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "H8", "H8", style))
+	rows, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"", "", "", ""},
+		{"", "B2", "", ""},
+		{"", "", "", "D3"},
+		{"A4", "", "", ""},
+	}, rows)
+
+	// Test the used range shrinks after removing the last column and row.
+	assert.NoError(t, f.RemoveCol("Sheet1", "D"))
+	assert.NoError(t, f.RemoveRow("Sheet1", 4))
+	rows, err = f.GetUsedRange("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"", ""}, {"", "B2"}}, rows)
+}
@@ -15,6 +15,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"html"
 	"io/ioutil"
 	"os"
 	"path"
@@ -287,10 +288,12 @@ func (f *File) GetActiveSheetIndex() int {
 }
 
 // SetSheetName provides a function to set the worksheet name be given old and
-// new worksheet name. Maximum 31 characters are allowed in sheet title and
-// this function only changes the name of the sheet and will not update the
-// sheet name in the formula or reference associated with the cell. So there
-// may be problem formula error or reference missing.
+// new worksheet name. Maximum 31 characters are allowed in sheet title. The
+// references to the worksheet in the formulas of the cells, the defined
+// names, the locations of the hyperlinks, the formulas of the data
+// validations and the conditional formats, and the charts of the workbook
+// are updated with the new name, which is quoted if required, such as
+// 'Sheet 1'!A1.
 func (f *File) SetSheetName(oldName, newName string) {
 	oldName = trimSheetName(oldName)
 	newName = trimSheetName(newName)
@@ -300,10 +303,90 @@ func (f *File) SetSheetName(oldName, newName string) {
 			content.Sheets.Sheet[k].Name = newName
 			f.sheetMap[newName] = f.sheetMap[oldName]
 			delete(f.sheetMap, oldName)
+			f.renameSheetReferences(oldName, newName)
 		}
 	}
 }
 
+// renameSheetReferences provides a function to update the references to the
+// renamed worksheet in the workbook by given old and new worksheet name.
+func (f *File) renameSheetReferences(oldName, newName string) {
+	rename := func(formula string) string {
+		return renameSheetInFormula(formula, oldName, newName)
+	}
+	for name := range f.sheetMap {
+		xlsx, err := f.workSheetReader(name)
+		if err != nil {
+			continue
+		}
+		for rowIdx := range xlsx.SheetData.Row {
+			for _, c := range xlsx.SheetData.Row[rowIdx].C {
+				if c.F != nil {
+					c.F.Content = rename(c.F.Content)
+				}
+			}
+		}
+		if xlsx.Hyperlinks != nil {
+			for i := range xlsx.Hyperlinks.Hyperlink {
+				link := &xlsx.Hyperlinks.Hyperlink[i]
+				link.Location = rename(link.Location)
+			}
+		}
+		if xlsx.DataValidations != nil {
+			for _, dv := range xlsx.DataValidations.DataValidation {
+				dv.Formula1 = renameDataValidationFormula(dv.Formula1, rename)
+				dv.Formula2 = renameDataValidationFormula(dv.Formula2, rename)
+			}
+		}
+		for _, cf := range xlsx.ConditionalFormatting {
+			for _, rule := range cf.CfRule {
+				for i := range rule.Formula {
+					rule.Formula[i] = rename(rule.Formula[i])
+				}
+			}
+		}
+	}
+	if wb := f.workbookReader(); wb.DefinedNames != nil {
+		for i := range wb.DefinedNames.DefinedName {
+			definedName := &wb.DefinedNames.DefinedName[i]
+			definedName.Data = rename(definedName.Data)
+		}
+	}
+	for path, content := range f.XLSX {
+		if !strings.HasPrefix(path, "xl/charts/chart") {
+			continue
+		}
+		f.XLSX[path] = chartFormulaRegexp.ReplaceAllFunc(content, func(element []byte) []byte {
+			match := chartFormulaRegexp.FindSubmatch(element)
+			var value bytes.Buffer
+			_ = xml.EscapeText(&value, []byte(rename(html.UnescapeString(string(match[2])))))
+			return []byte("<" + string(match[1]) + "f>" + value.String() + "</" + string(match[1]) + "f>" + string(match[3]))
+		})
+	}
+}
+
+// dataValidationFormulaRegexp matches the formula elements in the inner XML
+// of the data validation.
+var dataValidationFormulaRegexp = regexp.MustCompile(`(<(?:\w+:)?formula[12]>)([^<]*)(</(?:\w+:)?formula[12]>)`)
+
+// renameDataValidationFormula provides a function to update the references to
+// the renamed worksheet in the formulas of the inner XML of the data
+// validation, the text of the formulas is unescaped before renaming and
+// escaped after that.
+func renameDataValidationFormula(innerXML string, rename func(string) string) string {
+	return dataValidationFormulaRegexp.ReplaceAllStringFunc(innerXML, func(element string) string {
+		match := dataValidationFormulaRegexp.FindStringSubmatch(element)
+		formula := html.UnescapeString(match[2])
+		renamed := rename(formula)
+		if renamed == formula {
+			return element
+		}
+		var value bytes.Buffer
+		_ = xml.EscapeText(&value, []byte(renamed))
+		return match[1] + value.String() + match[3]
+	})
+}
+
 // GetSheetName provides a function to get worksheet name of XLSX by given
 // worksheet index. If given sheet index is invalid, will return an empty
 // string.
@@ -369,6 +452,25 @@ func (f *File) GetSheetMap() map[int]string {
 	return sheetMap
 }
 
+// GetSheetList provides a function to get the names of the worksheets of the
+// workbook in the order of the worksheet tabs. For example:
+//
+//    f, err := excelize.OpenFile("./Book1.xlsx")
+//    if err != nil {
+//        return
+//    }
+//    for _, name := range f.GetSheetList() {
+//        fmt.Println(name)
+//    }
+//
+func (f *File) GetSheetList() []string {
+	var list []string
+	for _, sheet := range f.workbookReader().Sheets.Sheet {
+		list = append(list, sheet.Name)
+	}
+	return list
+}
+
 // getSheetMap provides a function to get worksheet name and XML file path map of
 // XLSX.
 func (f *File) getSheetMap() map[string]string {
@@ -487,6 +589,13 @@ func (f *File) copySheet(from, to int) error {
 	if ok {
 		f.XLSX[toRels] = f.XLSX[fromRels]
 	}
+	// The relationships of the source worksheet may have been changed in
+	// memory, such as added hyperlinks, copy them to keep the references of
+	// the worksheet available.
+	delete(f.WorkSheetRels, toRels)
+	if rels, ok := f.WorkSheetRels[fromRels]; ok && rels != nil {
+		f.WorkSheetRels[toRels] = deepcopy.Copy(rels).(*xlsxWorkbookRels)
+	}
 	return err
 }
 
@@ -967,6 +1076,108 @@ func (f *File) UnprotectSheet(sheet string) error {
 	return err
 }
 
+// SetDefinedName provides a function to set the defined names of the workbook
+// or worksheet. If not specified scope, the default scope is workbook. The
+// references of the defined name will be updated when inserting or deleting
+// rows or columns of the worksheet which it refers to. For example:
+//
+//    f.SetDefinedName(&excelize.DefinedName{
+//        Name:     "Amount",
+//        RefersTo: "Sheet1!$A$2:$D$5",
+//        Comment:  "defined name comment",
+//        Scope:    "Sheet2",
+//    })
+//
+func (f *File) SetDefinedName(definedName *DefinedName) error {
+	wb := f.workbookReader()
+	d := xlsxDefinedName{
+		Name:    definedName.Name,
+		Comment: definedName.Comment,
+		Data:    definedName.RefersTo,
+	}
+	if definedName.Scope != "" {
+		localSheetID, err := f.getSheetPosition(definedName.Scope)
+		if err != nil {
+			return err
+		}
+		d.LocalSheetID = &localSheetID
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	for _, dn := range wb.DefinedNames.DefinedName {
+		if dn.Name == definedName.Name && f.getDefinedNameScope(dn) == definedName.Scope {
+			return fmt.Errorf("the same name %q already exists on the scope", definedName.Name)
+		}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, d)
+	return nil
+}
+
+// GetDefinedName provides a function to get the defined names of the workbook
+// or worksheet.
+func (f *File) GetDefinedName() []DefinedName {
+	var definedNames []DefinedName
+	wb := f.workbookReader()
+	if wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			definedNames = append(definedNames, DefinedName{
+				Name:     dn.Name,
+				Comment:  dn.Comment,
+				RefersTo: dn.Data,
+				Scope:    f.getDefinedNameScope(dn),
+			})
+		}
+	}
+	return definedNames
+}
+
+// DeleteDefinedName provides a function to delete the defined names of the
+// workbook or worksheet by given name and scope. If not specified scope, the
+// default scope is workbook. For example:
+//
+//    f.DeleteDefinedName(&excelize.DefinedName{
+//        Name:  "Amount",
+//        Scope: "Sheet2",
+//    })
+//
+func (f *File) DeleteDefinedName(definedName *DefinedName) error {
+	wb := f.workbookReader()
+	if wb.DefinedNames != nil {
+		for idx, dn := range wb.DefinedNames.DefinedName {
+			if dn.Name == definedName.Name && f.getDefinedNameScope(dn) == definedName.Scope {
+				wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName[:idx], wb.DefinedNames.DefinedName[idx+1:]...)
+				if len(wb.DefinedNames.DefinedName) == 0 {
+					wb.DefinedNames = nil
+				}
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("defined name %q not found on the scope", definedName.Name)
+}
+
+// getDefinedNameScope provides a function to get the worksheet name of the
+// scope of the defined name, the scope is empty for the global name.
+func (f *File) getDefinedNameScope(dn xlsxDefinedName) string {
+	wb := f.workbookReader()
+	if dn.LocalSheetID == nil || *dn.LocalSheetID < 0 || *dn.LocalSheetID >= len(wb.Sheets.Sheet) {
+		return ""
+	}
+	return wb.Sheets.Sheet[*dn.LocalSheetID].Name
+}
+
+// getSheetPosition provides a function to get the zero-based position of the
+// worksheet in the workbook by given worksheet name.
+func (f *File) getSheetPosition(name string) (int, error) {
+	for idx, sheet := range f.workbookReader().Sheets.Sheet {
+		if sheet.Name == trimSheetName(name) {
+			return idx, nil
+		}
+	}
+	return -1, fmt.Errorf("sheet %s is not exist", name)
+}
+
 // trimSheetName provides a function to trim invaild characters by given worksheet
 // name.
 func trimSheetName(name string) string {
@@ -1221,6 +1432,81 @@ func (f *File) workSheetRelsReader(path string) *xlsxWorkbookRels {
 	return f.WorkSheetRels[path]
 }
 
+// extRelationshipIDRegexp matches the references to the relationships in the
+// extension list of the worksheet.
+var extRelationshipIDRegexp = regexp.MustCompile(`\br:id="([^"]*)"`)
+
+// RepackRelationships provides a function to renumber the relationship
+// index of the worksheet contiguously by given worksheet name, and update the
+// references of the hyperlinks, drawing, comments, background picture, page
+// setup and tables to the relationships. The gaps in the relationship index
+// may be left after removing hyperlinks by deleting rows or columns. The
+// relationships referenced in the extension list of the worksheet keep their
+// index, and the others are renumbered around them. For example:
+//
+//    err := f.RepackRelationships("Sheet1")
+//
+func (f *File) RepackRelationships(sheet string) error {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	name := f.sheetMap[trimSheetName(sheet)]
+	sheetRels := f.workSheetRelsReader("xl/worksheets/_rels/" + strings.TrimPrefix(name, "xl/worksheets/") + ".rels")
+	if sheetRels == nil {
+		return err
+	}
+	var refs []*string
+	if xlsx.Hyperlinks != nil {
+		for idx := range xlsx.Hyperlinks.Hyperlink {
+			refs = append(refs, &xlsx.Hyperlinks.Hyperlink[idx].RID)
+		}
+	}
+	if xlsx.Drawing != nil {
+		refs = append(refs, &xlsx.Drawing.RID)
+	}
+	if xlsx.LegacyDrawing != nil {
+		refs = append(refs, &xlsx.LegacyDrawing.RID)
+	}
+	if xlsx.Picture != nil {
+		refs = append(refs, &xlsx.Picture.RID)
+	}
+	if xlsx.PageSetUp != nil {
+		refs = append(refs, &xlsx.PageSetUp.RID)
+	}
+	if xlsx.TableParts != nil {
+		for _, tablePart := range xlsx.TableParts.TableParts {
+			refs = append(refs, &tablePart.RID)
+		}
+	}
+	kept := map[string]bool{}
+	if xlsx.ExtLst != nil {
+		for _, match := range extRelationshipIDRegexp.FindAllStringSubmatch(xlsx.ExtLst.Ext, -1) {
+			kept[match[1]] = true
+		}
+	}
+	rIDs := make(map[string]string, len(sheetRels.Relationships))
+	idx := 1
+	for i := range sheetRels.Relationships {
+		rel := &sheetRels.Relationships[i]
+		if kept[rel.ID] {
+			continue
+		}
+		for kept["rId"+strconv.Itoa(idx)] {
+			idx++
+		}
+		rIDs[rel.ID] = "rId" + strconv.Itoa(idx)
+		rel.ID = rIDs[rel.ID]
+		idx++
+	}
+	for _, rID := range refs {
+		if newID, ok := rIDs[*rID]; ok {
+			*rID = newID
+		}
+	}
+	return err
+}
+
 // workSheetRelsWriter provides a function to save
 // xl/worksheets/_rels/sheet%d.xml.rels after serialize structure.
 func (f *File) workSheetRelsWriter() {
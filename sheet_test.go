@@ -142,3 +142,162 @@ func TestSetHeaderFooter(t *testing.T) {
 	}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetHeaderFooter.xlsx")))
 }
+
+func TestGetSheetList(t *testing.T) {
+	f := excelize.NewFile()
+	f.NewSheet("Sheet3")
+	f.NewSheet("Sheet2")
+	assert.Equal(t, []string{"Sheet1", "Sheet3", "Sheet2"}, f.GetSheetList())
+	f.DeleteSheet("Sheet3")
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+}
+
+func TestAdjustSheetIsolation(t *testing.T) {
+	f := excelize.NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "A5"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B2", "C3"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "D4", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	// Test the copied worksheet is isolated from the source worksheet.
+	assert.NoError(t, f.CopySheet(1, f.NewSheet("Sheet2")))
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	for sheet, expected := range map[string][]string{"Sheet1": {"B3:C4", "D5"}, "Sheet2": {"B2:C3", "D4"}} {
+		mergeCells, err := f.GetMergeCells(sheet)
+		assert.NoError(t, err)
+		if assert.Len(t, mergeCells, 1) {
+			assert.Equal(t, expected[0], mergeCells[0][0], sheet)
+		}
+		links, err := f.GetHyperLinks(sheet)
+		assert.NoError(t, err)
+		if assert.Len(t, links, 1) {
+			assert.Equal(t, expected[1], links[0].Ref, sheet)
+		}
+	}
+}
+
+func TestSetSheetNameReferences(t *testing.T) {
+	f := excelize.NewFile()
+	f.NewSheet("Sheet2")
+	f.NewSheet("Sheet10")
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", `Sheet1!A1+SUM('Sheet1'!B1:B3)+Sheet10!A1&"Sheet1!A1"`))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A2", "A1+Sheet1!$A$3"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet2", "B1", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.SetDefinedName(&excelize.DefinedName{
+		Name:     "Amount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Scope:    "Sheet1",
+	}))
+	assert.NoError(t, f.SetDefinedName(&excelize.DefinedName{
+		Name:     "Header",
+		RefersTo: "Sheet1!$A$1,Sheet2!$A$1",
+	}))
+
+	// Test rename the worksheet to a name which requires quoting.
+	f.SetSheetName("Sheet1", "Sales Data")
+	for _, c := range []struct{ sheet, cell, expected string }{
+		{"Sheet2", "A1", `'Sales Data'!A1+SUM('Sales Data'!B1:B3)+Sheet10!A1&"Sheet1!A1"`},
+		{"Sales Data", "A2", "A1+'Sales Data'!$A$3"},
+	} {
+		formula, err := f.GetCellFormula(c.sheet, c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, formula)
+	}
+	_, location, err := f.GetCellHyperLink("Sheet2", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'Sales Data'!A1", location)
+	definedNames := f.GetDefinedName()
+	if assert.Len(t, definedNames, 2) {
+		assert.Equal(t, excelize.DefinedName{Name: "Amount", RefersTo: "'Sales Data'!$A$2:$D$5", Scope: "Sales Data"}, definedNames[0])
+		assert.Equal(t, "'Sales Data'!$A$1,Sheet2!$A$1", definedNames[1].RefersTo)
+	}
+
+	// Test rename the worksheet back to a name which doesn't require quoting.
+	f.SetSheetName("Sales Data", "Sales")
+	formula, err := f.GetCellFormula("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, `Sales!A1+SUM(Sales!B1:B3)+Sheet10!A1&"Sheet1!A1"`, formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetNameReferences.xlsx")))
+}
+
+func TestSetSheetNameEscapedReferences(t *testing.T) {
+	f := excelize.NewFile()
+	f.NewSheet("R&D")
+	dv := excelize.NewDataValidation(true)
+	dv.Sqref = "A1"
+	dv.Formula1 = `<formula1>'R&amp;D'!$A$1:$A$3</formula1>`
+	dv.Type = "list"
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	// Test rename the worksheet which name contains the ampersand.
+	f.SetSheetName("R&D", "P&L")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetNameEscapedReferences.xlsx")))
+	assert.Contains(t, string(f.XLSX["xl/worksheets/sheet1.xml"]), `<formula1>&#39;P&amp;L&#39;!$A$1:$A$3</formula1>`)
+
+	f, err := excelize.OpenFile(filepath.Join("test", "TestSetSheetNameEscapedReferences.xlsx"))
+	assert.NoError(t, err)
+	f.SetSheetName("P&L", "Sheet2")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetNameEscapedReferences.xlsx")))
+	assert.Contains(t, string(f.XLSX["xl/worksheets/sheet1.xml"]), `<formula1>Sheet2!$A$1:$A$3</formula1>`)
+}
+
+func TestDefinedName(t *testing.T) {
+	f := excelize.NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetDefinedName(&excelize.DefinedName{
+		Name:     "Amount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Comment:  "defined name comment",
+		Scope:    "Sheet1",
+	}))
+	assert.NoError(t, f.SetDefinedName(&excelize.DefinedName{
+		Name:     "Amount",
+		RefersTo: "Sheet2!$A$2:$D$5",
+	}))
+	assert.NoError(t, f.SetDefinedName(&excelize.DefinedName{
+		Name:     "Header",
+		RefersTo: "'Sheet1'!$A$1,Sheet1!$B$3,Sheet2!$A$3",
+	}))
+	assert.EqualError(t, f.SetDefinedName(&excelize.DefinedName{
+		Name:     "Amount",
+		RefersTo: "Sheet1!$A$2:$D$5",
+		Scope:    "Sheet1",
+	}), `the same name "Amount" already exists on the scope`)
+	assert.EqualError(t, f.SetDefinedName(&excelize.DefinedName{
+		Name:     "Amount",
+		RefersTo: "SheetN!$A$2:$D$5",
+		Scope:    "SheetN",
+	}), "sheet SheetN is not exist")
+
+	// Test adjust the references of the defined names after inserting row.
+	assert.NoError(t, f.InsertRow("Sheet1", 2))
+	definedNames := f.GetDefinedName()
+	assert.Equal(t, excelize.DefinedName{
+		Name:     "Amount",
+		Comment:  "defined name comment",
+		RefersTo: "Sheet1!$A$3:$D$6",
+		Scope:    "Sheet1",
+	}, definedNames[0])
+	assert.Equal(t, "Sheet2!$A$2:$D$5", definedNames[1].RefersTo)
+	assert.Equal(t, "'Sheet1'!$A$1,Sheet1!$B$4,Sheet2!$A$3", definedNames[2].RefersTo)
+
+	// Test adjust the references of the defined names after removing column.
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", "E1"))
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	definedNames = f.GetDefinedName()
+	assert.Equal(t, "Sheet1!$A$3:$C$6", definedNames[0].RefersTo)
+	assert.Equal(t, "'Sheet1'!$A$1,Sheet1!#REF!,Sheet2!$A$3", definedNames[2].RefersTo)
+
+	assert.EqualError(t, f.DeleteDefinedName(&excelize.DefinedName{
+		Name:  "Amount",
+		Scope: "Sheet2",
+	}), `defined name "Amount" not found on the scope`)
+	assert.NoError(t, f.DeleteDefinedName(&excelize.DefinedName{
+		Name:  "Amount",
+		Scope: "Sheet1",
+	}))
+	definedNames = f.GetDefinedName()
+	assert.Len(t, definedNames, 2)
+	assert.Equal(t, "", definedNames[0].Scope)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefinedName.xlsx")))
+}
@@ -37,6 +37,10 @@ type (
 	ZoomScale float64
 	// TopLeftCell is a SheetViewOption.
 	TopLeftCell string
+	// FreezePanes is a SheetViewOption, the cell reference of the top left
+	// cell of the bottom right pane. The rows above and the columns to the
+	// left of the cell are frozen.
+	FreezePanes string
 	/* TODO
 	// ShowWhiteSpace is a SheetViewOption.
 	ShowWhiteSpace bool
@@ -108,6 +112,47 @@ func (o *ZoomScale) getSheetViewOption(view *xlsxSheetView) {
 	*o = ZoomScale(view.ZoomScale)
 }
 
+func (o FreezePanes) setSheetViewOption(view *xlsxSheetView) {
+	col, row := 1, 1
+	if o != "" {
+		var err error
+		if col, row, err = CellNameToCoordinates(string(o)); err != nil {
+			return
+		}
+	}
+	if col == 1 && row == 1 {
+		// Unfreeze panes by an empty or top left cell reference.
+		view.Pane = nil
+		for _, selection := range view.Selection {
+			selection.Pane = ""
+		}
+		return
+	}
+	activePane := "bottomRight"
+	if col == 1 {
+		activePane = "bottomLeft"
+	} else if row == 1 {
+		activePane = "topRight"
+	}
+	view.Pane = &xlsxPane{
+		ActivePane:  activePane,
+		State:       "frozen",
+		TopLeftCell: string(o),
+		XSplit:      float64(col - 1),
+		YSplit:      float64(row - 1),
+	}
+	view.Selection = []*xlsxSelection{{Pane: activePane, ActiveCell: string(o), SQRef: string(o)}}
+}
+
+func (o *FreezePanes) getSheetViewOption(view *xlsxSheetView) {
+	*o = ""
+	if view.Pane == nil || (view.Pane.State != "frozen" && view.Pane.State != "frozenSplit") {
+		return
+	}
+	cell, _ := CoordinatesToCellName(int(view.Pane.XSplit)+1, int(view.Pane.YSplit)+1)
+	*o = FreezePanes(cell)
+}
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheetName string, viewIndex int) (*xlsxSheetView, error) {
 	xlsx, err := f.workSheetReader(sheetName)
@@ -135,6 +180,9 @@ func (f *File) getSheetView(sheetName string, viewIndex int) (*xlsxSheetView, er
 //    ShowFormulas(bool)
 //    ShowGridLines(bool)
 //    ShowRowColHeaders(bool)
+//    ZoomScale(float64)
+//    TopLeftCell(string)
+//    FreezePanes(string)
 // Example:
 //    err = f.SetSheetViewOptions("Sheet1", -1, ShowGridLines(false))
 func (f *File) SetSheetViewOptions(name string, viewIndex int, opts ...SheetViewOption) error {
@@ -158,6 +206,9 @@ func (f *File) SetSheetViewOptions(name string, viewIndex int, opts ...SheetView
 //    ShowFormulas(bool)
 //    ShowGridLines(bool)
 //    ShowRowColHeaders(bool)
+//    ZoomScale(float64)
+//    TopLeftCell(string)
+//    FreezePanes(string)
 // Example:
 //    var showGridLines excelize.ShowGridLines
 //    err = f.GetSheetViewOptions("Sheet1", -1, &showGridLines)
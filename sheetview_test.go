@@ -16,6 +16,7 @@ var _ = []excelize.SheetViewOption{
 	excelize.ShowGridLines(true),
 	excelize.ShowRowColHeaders(true),
 	excelize.TopLeftCell("B2"),
+	excelize.FreezePanes("A3"),
 	// SheetViewOptionPtr are also SheetViewOption
 	new(excelize.DefaultGridColor),
 	new(excelize.RightToLeft),
@@ -23,6 +24,7 @@ var _ = []excelize.SheetViewOption{
 	new(excelize.ShowGridLines),
 	new(excelize.ShowRowColHeaders),
 	new(excelize.TopLeftCell),
+	new(excelize.FreezePanes),
 }
 
 var _ = []excelize.SheetViewOptionPtr{
@@ -32,6 +34,7 @@ var _ = []excelize.SheetViewOptionPtr{
 	(*excelize.ShowGridLines)(nil),
 	(*excelize.ShowRowColHeaders)(nil),
 	(*excelize.TopLeftCell)(nil),
+	(*excelize.FreezePanes)(nil),
 }
 
 func ExampleFile_SetSheetViewOptions() {
@@ -168,3 +171,34 @@ func TestSheetViewOptionsErrors(t *testing.T) {
 	assert.Error(t, f.SetSheetViewOptions(sheet, 1))
 	assert.Error(t, f.SetSheetViewOptions(sheet, -2))
 }
+
+func TestSheetViewFreezePanes(t *testing.T) {
+	f := excelize.NewFile()
+	const sheet = "Sheet1"
+
+	var freezePanes excelize.FreezePanes
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &freezePanes))
+	assert.Equal(t, excelize.FreezePanes(""), freezePanes)
+
+	// Test freeze the first 2 rows with zoom scale 150%, and insert row.
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, excelize.FreezePanes("A3"), excelize.ZoomScale(150)))
+	assert.NoError(t, f.InsertRow(sheet, 1))
+	var zoomScale excelize.ZoomScale
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &freezePanes, &zoomScale))
+	assert.Equal(t, excelize.FreezePanes("A4"), freezePanes)
+	assert.Equal(t, excelize.ZoomScale(150), zoomScale)
+
+	// Test freeze columns and rows, the invalid cell reference is ignored.
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, excelize.FreezePanes("C2"), excelize.FreezePanes("-")))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &freezePanes))
+	assert.Equal(t, excelize.FreezePanes("C2"), freezePanes)
+
+	// Test unfreeze panes.
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, excelize.FreezePanes("")))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &freezePanes))
+	assert.Equal(t, excelize.FreezePanes(""), freezePanes)
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, excelize.FreezePanes("B1")))
+	assert.NoError(t, f.SetSheetViewOptions(sheet, 0, excelize.FreezePanes("A1")))
+	assert.NoError(t, f.GetSheetViewOptions(sheet, 0, &freezePanes))
+	assert.Equal(t, excelize.FreezePanes(""), freezePanes)
+}
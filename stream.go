@@ -0,0 +1,223 @@
+// Copyright 2016 - 2019 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX files. Support reads and writes XLSX file generated by
+// Microsoft Excel™ 2007 and later. Support save file without losing original
+// charts of XLSX. This library needs Go version 1.8 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// StreamWriter defined the type of stream writer, which writes the rows of
+// the worksheet sequentially without keeping them in memory as cells.
+type StreamWriter struct {
+	File       *File
+	Sheet      string
+	lastRow    int
+	dimension  []int
+	flushed    bool
+	mergeCells []*xlsxMergeCell
+	rawData    bytes.Buffer
+}
+
+// NewStreamWriter provides a function to create a new stream writer by given
+// worksheet name. The rows must be written in ascending order, and the
+// existing cells of the worksheet will be replaced by the written rows after
+// flushing. For example, write 2 rows and merge the cells A1:B1 in Sheet1:
+//
+//    streamWriter, err := f.NewStreamWriter("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    if err = streamWriter.SetRow("A1", []interface{}{"Title"}); err != nil {
+//        fmt.Println(err)
+//    }
+//    if err = streamWriter.SetRow("A2", []interface{}{1, 2}); err != nil {
+//        fmt.Println(err)
+//    }
+//    if err = streamWriter.MergeCell("A1", "B1"); err != nil {
+//        fmt.Println(err)
+//    }
+//    if err = streamWriter.Flush(); err != nil {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return nil, err
+	}
+	sw := &StreamWriter{File: f, Sheet: sheet}
+	sw.rawData.WriteString("<sheetData>")
+	return sw, nil
+}
+
+// SetRow writes an array to the row by given starting cell reference and a
+// slice of values. The row must be after the rows already written, and the
+// nil values are skipped. It returns an error after flushing.
+func (sw *StreamWriter) SetRow(axis string, slice []interface{}) error {
+	if sw.flushed {
+		return errStreamWriterFlushed
+	}
+	col, row, err := CellNameToCoordinates(axis)
+	if err != nil {
+		return err
+	}
+	if row <= sw.lastRow {
+		return fmt.Errorf("row %d has already been written", row)
+	}
+	rowData := xlsxRow{R: row}
+	firstCol, lastCol := 0, 0
+	for i, value := range slice {
+		if col+i > TotalColumns {
+			return newInvalidColumnNumberError(col + i)
+		}
+		cell, err := CoordinatesToCellName(col+i, row)
+		if err != nil {
+			return err
+		}
+		c := xlsxC{R: cell}
+		if !setStreamCellValue(&c, value) {
+			continue
+		}
+		if firstCol == 0 {
+			firstCol = col + i
+		}
+		lastCol = col + i
+		rowData.C = append(rowData.C, c)
+	}
+	sw.lastRow = row
+	if firstCol != 0 {
+		if sw.dimension == nil {
+			sw.dimension = []int{firstCol, row, lastCol, row}
+		}
+		if firstCol < sw.dimension[0] {
+			sw.dimension[0] = firstCol
+		}
+		if lastCol > sw.dimension[2] {
+			sw.dimension[2] = lastCol
+		}
+		sw.dimension[3] = row
+	}
+	return xml.NewEncoder(&sw.rawData).EncodeElement(rowData, xml.StartElement{Name: xml.Name{Local: "row"}})
+}
+
+// MergeCell provides a function to merge the cells by given coordinate area
+// of the rows already written. The merged cells are recorded and written at
+// flushing. It returns an error after flushing.
+func (sw *StreamWriter) MergeCell(hcell, vcell string) error {
+	if sw.flushed {
+		return errStreamWriterFlushed
+	}
+	coordinates, err := areaRefToCoordinates(hcell + ":" + vcell)
+	if err != nil {
+		return err
+	}
+	if coordinates[3] > sw.lastRow {
+		return fmt.Errorf("row %d has not been written", coordinates[3])
+	}
+	if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+		return nil
+	}
+	hcell, _ = CoordinatesToCellName(coordinates[0], coordinates[1])
+	vcell, _ = CoordinatesToCellName(coordinates[2], coordinates[3])
+	ref := hcell + ":" + vcell
+	for _, mergeCell := range sw.mergeCells {
+		rect, _ := areaRefToCoordinates(mergeCell.Ref)
+		if areaOverlaps(coordinates, rect) {
+			return fmt.Errorf("merged cells %s overlaps with %s", ref, mergeCell.Ref)
+		}
+	}
+	sw.mergeCells = append(sw.mergeCells, &xlsxMergeCell{Ref: ref})
+	return nil
+}
+
+// Flush ending the streaming writing process, the written rows and the merged
+// cells replace the cells and the merged cells of the worksheet, and the
+// dimension of the worksheet is updated to the range of the written cells.
+// The stream writer can't be used any more after flushing.
+func (sw *StreamWriter) Flush() error {
+	if sw.flushed {
+		return errStreamWriterFlushed
+	}
+	xlsx, err := sw.File.workSheetReader(sw.Sheet)
+	if err != nil {
+		return err
+	}
+	sw.flushed = true
+	sw.rawData.WriteString("</sheetData>")
+	xlsx.SheetData = xlsxSheetData{}
+	xlsx.Dimension.Ref = "A1"
+	if sw.dimension != nil {
+		xlsx.Dimension.Ref = coordinatesToSqref(sw.dimension)
+	}
+	xlsx.MergeCells = nil
+	if len(sw.mergeCells) > 0 {
+		xlsx.MergeCells = &xlsxMergeCells{Count: len(sw.mergeCells), Cells: sw.mergeCells}
+	}
+	output, err := xml.Marshal(xlsx)
+	if err != nil {
+		return err
+	}
+	output = bytes.Replace(output, []byte("<sheetData></sheetData>"), sw.rawData.Bytes(), 1)
+	name := sw.File.sheetMap[trimSheetName(sw.Sheet)]
+	sw.File.saveFileList(name, replaceRelationshipsBytes(replaceWorkSheetsRelationshipsNameSpaceBytes(output)))
+	delete(sw.File.Sheet, name)
+	delete(sw.File.checked, name)
+	return nil
+}
+
+// errStreamWriterFlushed is returned when the stream writer is used after
+// flushing.
+var errStreamWriterFlushed = errors.New("stream writer has been flushed")
+
+// setStreamCellValue provides a function to set the value of the cell written
+// by the stream writer. It reports whether the cell has value.
+func setStreamCellValue(c *xlsxC, value interface{}) bool {
+	switch v := value.(type) {
+	case int:
+		c.V = strconv.Itoa(v)
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		c.V = fmt.Sprintf("%d", v)
+	case float32:
+		c.V = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		c.V = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		c.T, c.V = "str", v
+	case []byte:
+		c.T, c.V = "str", string(v)
+	case time.Time:
+		excelTime, err := timeToExcelTime(v)
+		if err != nil {
+			c.T, c.V = "str", v.String()
+			break
+		}
+		c.V = strconv.FormatFloat(excelTime, 'f', -1, 64)
+	case bool:
+		c.T, c.V = "b", "0"
+		if v {
+			c.V = "1"
+		}
+	case nil:
+		return false
+	default:
+		c.T, c.V = "str", fmt.Sprintf("%v", value)
+	}
+	if c.T == "str" && len(c.V) > 0 && c.V[0] == ' ' {
+		c.XMLSpace = xml.Attr{
+			Name:  xml.Name{Space: NameSpaceXML, Local: "space"},
+			Value: "preserve",
+		}
+	}
+	return true
+}
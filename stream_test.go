@@ -0,0 +1,74 @@
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamWriter(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A10", "replaced"))
+	streamWriter, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"Title", nil, " Note"}))
+	assert.NoError(t, streamWriter.SetRow("B3", []interface{}{1, int64(2), uint8(3), float32(0.5), 2.25, true, []byte("byte"), time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC), time.Second}))
+	assert.EqualError(t, streamWriter.SetRow("A3", []interface{}{1}), "row 3 has already been written")
+	assert.EqualError(t, streamWriter.SetRow("A", []interface{}{1}), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, streamWriter.SetRow("XFD4", []interface{}{1, 2}), "incorrect column number 16385")
+
+	// Test merge the cells of the rows already written.
+	assert.NoError(t, streamWriter.MergeCell("C1", "A1"))
+	assert.NoError(t, streamWriter.MergeCell("A2", "A3"))
+	assert.NoError(t, streamWriter.MergeCell("D3", "D3"))
+	assert.EqualError(t, streamWriter.MergeCell("A4", "B4"), "row 4 has not been written")
+	assert.EqualError(t, streamWriter.MergeCell("A3", "B3"), "merged cells A3:B3 overlaps with A2:A3")
+	assert.EqualError(t, streamWriter.MergeCell("A", "B1"), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.NoError(t, streamWriter.Flush())
+
+	// Test use the stream writer after flushing.
+	assert.EqualError(t, streamWriter.SetRow("A5", []interface{}{1}), "stream writer has been flushed")
+	assert.EqualError(t, streamWriter.MergeCell("A1", "B1"), "stream writer has been flushed")
+	assert.EqualError(t, streamWriter.Flush(), "stream writer has been flushed")
+	assert.Equal(t, 1, strings.Count(string(f.XLSX["xl/worksheets/sheet1.xml"]), "</sheetData>"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamWriter.xlsx")))
+
+	// Test read the rows and the merged cells back.
+	f, err = OpenFile(filepath.Join("test", "TestStreamWriter.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{
+		"A1": "Title", "C1": "Title", "C3": "2", "D3": "3", "E3": "0.5", "F3": "2.25",
+		"G3": "1", "H3": "byte", "I3": "43739", "J3": "1s", "A10": "",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, mergeCells, 2) {
+		assert.Equal(t, "A1:C1", mergeCells[0][0])
+		assert.Equal(t, "A2:A3", mergeCells[1][0])
+	}
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, " Note", xlsx.SheetData.Row[0].C[2].V)
+	assert.Equal(t, "preserve", xlsx.SheetData.Row[0].C[2].XMLSpace.Value)
+	assert.Equal(t, "A1:J3", xlsx.Dimension.Ref)
+
+	// Test flush the stream writer without cells.
+	streamWriter, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{nil}))
+	assert.NoError(t, streamWriter.Flush())
+	xlsx, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", xlsx.Dimension.Ref)
+
+	// Test create the stream writer on not exists worksheet.
+	_, err = f.NewStreamWriter("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
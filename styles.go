@@ -14,6 +14,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -2070,38 +2071,61 @@ func setLangNumFmt(style *xlsxStyleSheet, formatStyle *formatStyle) int {
 	return nf.NumFmtID
 }
 
+// fillPatterns defined the list of the pattern types of the fills sorted by
+// excelize index number.
+var fillPatterns = []string{
+	"none",
+	"solid",
+	"mediumGray",
+	"darkGray",
+	"lightGray",
+	"darkHorizontal",
+	"darkVertical",
+	"darkDown",
+	"darkUp",
+	"darkGrid",
+	"darkTrellis",
+	"lightHorizontal",
+	"lightVertical",
+	"lightDown",
+	"lightUp",
+	"lightGrid",
+	"lightTrellis",
+	"gray125",
+	"gray0625",
+}
+
+// fillVariants defined the list of the degrees of the gradient fills sorted
+// by excelize shading index number.
+var fillVariants = []float64{
+	90,
+	0,
+	45,
+	135,
+}
+
+// borderStyles defined the list of the border styles sorted by excelize
+// index number.
+var borderStyles = []string{
+	"none",
+	"thin",
+	"medium",
+	"dashed",
+	"dotted",
+	"thick",
+	"double",
+	"hair",
+	"mediumDashed",
+	"dashDot",
+	"mediumDashDot",
+	"dashDotDot",
+	"mediumDashDotDot",
+	"slantDashDot",
+}
+
 // setFills provides a function to add fill elements in the styles.xml by
 // given cell format settings.
 func setFills(formatStyle *formatStyle, fg bool) *xlsxFill {
-	var patterns = []string{
-		"none",
-		"solid",
-		"mediumGray",
-		"darkGray",
-		"lightGray",
-		"darkHorizontal",
-		"darkVertical",
-		"darkDown",
-		"darkUp",
-		"darkGrid",
-		"darkTrellis",
-		"lightHorizontal",
-		"lightVertical",
-		"lightDown",
-		"lightUp",
-		"lightGrid",
-		"lightTrellis",
-		"gray125",
-		"gray0625",
-	}
-
-	var variants = []float64{
-		90,
-		0,
-		45,
-		135,
-	}
-
 	var fill xlsxFill
 	switch formatStyle.Fill.Type {
 	case "gradient":
@@ -2111,7 +2135,7 @@ func setFills(formatStyle *formatStyle, fg bool) *xlsxFill {
 		var gradient xlsxGradientFill
 		switch formatStyle.Fill.Shading {
 		case 0, 1, 2, 3:
-			gradient.Degree = variants[formatStyle.Fill.Shading]
+			gradient.Degree = fillVariants[formatStyle.Fill.Shading]
 		case 4:
 			gradient.Type = "path"
 		case 5:
@@ -2140,7 +2164,7 @@ func setFills(formatStyle *formatStyle, fg bool) *xlsxFill {
 			break
 		}
 		var pattern xlsxPatternFill
-		pattern.PatternType = patterns[formatStyle.Fill.Pattern]
+		pattern.PatternType = fillPatterns[formatStyle.Fill.Pattern]
 		if fg {
 			pattern.FgColor.RGB = getPaletteColor(formatStyle.Fill.Color[0])
 		} else {
@@ -2187,23 +2211,6 @@ func setProtection(formatStyle *formatStyle) *xlsxProtection {
 // setBorders provides a function to add border elements in the styles.xml by
 // given borders format settings.
 func setBorders(formatStyle *formatStyle) *xlsxBorder {
-	var styles = []string{
-		"none",
-		"thin",
-		"medium",
-		"dashed",
-		"dotted",
-		"thick",
-		"double",
-		"hair",
-		"mediumDashed",
-		"dashDot",
-		"mediumDashDot",
-		"dashDotDot",
-		"mediumDashDotDot",
-		"slantDashDot",
-	}
-
 	var border xlsxBorder
 	for _, v := range formatStyle.Border {
 		if 0 <= v.Style && v.Style < 14 {
@@ -2211,23 +2218,23 @@ func setBorders(formatStyle *formatStyle) *xlsxBorder {
 			color.RGB = getPaletteColor(v.Color)
 			switch v.Type {
 			case "left":
-				border.Left.Style = styles[v.Style]
+				border.Left.Style = borderStyles[v.Style]
 				border.Left.Color = &color
 			case "right":
-				border.Right.Style = styles[v.Style]
+				border.Right.Style = borderStyles[v.Style]
 				border.Right.Color = &color
 			case "top":
-				border.Top.Style = styles[v.Style]
+				border.Top.Style = borderStyles[v.Style]
 				border.Top.Color = &color
 			case "bottom":
-				border.Bottom.Style = styles[v.Style]
+				border.Bottom.Style = borderStyles[v.Style]
 				border.Bottom.Color = &color
 			case "diagonalUp":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = borderStyles[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalUp = true
 			case "diagonalDown":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = borderStyles[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalDown = true
 			}
@@ -2263,6 +2270,72 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 	return style.CellXfs.Count - 1
 }
 
+// SetCellLocked provides a function to lock or unlock the cells in the range
+// by given worksheet name, range reference and locked flag, the other
+// settings of the styles of the cells are kept. The locked cells can't be
+// edited when the worksheet is protected, and all cells are locked by
+// default. For example, unlock the cells A1:A5 in Sheet1 to allow editing
+// them after protecting the worksheet:
+//
+//    err := f.SetCellLocked("Sheet1", "A1:A5", false)
+//
+// The unlocked region grows with the rows or columns inserted inside of it.
+func (f *File) SetCellLocked(sheet, rangeRef string, locked bool) error {
+	ref := rangeRef
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	coordinates, err := areaRefToCoordinates(ref)
+	if err != nil {
+		return err
+	}
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	prepareSheetXML(xlsx, coordinates[2], coordinates[3])
+	makeContiguousColumns(xlsx, coordinates[1], coordinates[3], coordinates[2])
+	styles := map[int]int{}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		cells := xlsx.SheetData.Row[row-1].C
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			style := f.prepareCellStyle(xlsx, col, cells[col-1].S)
+			if _, ok := styles[style]; !ok {
+				styles[style] = f.setLockedStyle(style, locked)
+			}
+			cells[col-1].S = styles[style]
+		}
+	}
+	return nil
+}
+
+// setLockedStyle provides a function to get the index of the style which is
+// the same as the style of given index except the locked flag. A new style
+// will be created if there is no such style.
+func (f *File) setLockedStyle(styleID int, locked bool) int {
+	s := f.stylesReader()
+	if s.CellXfs == nil || styleID >= len(s.CellXfs.Xf) {
+		return styleID
+	}
+	xf := s.CellXfs.Xf[styleID]
+	if (xf.Protection == nil && locked) || (xf.Protection != nil && xf.Protection.Locked == locked) {
+		return styleID
+	}
+	protection := xlsxProtection{Locked: locked}
+	if xf.Protection != nil {
+		protection.Hidden = xf.Protection.Hidden
+	}
+	xf.ApplyProtection, xf.Protection = true, &protection
+	for idx, cellXf := range s.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			return idx
+		}
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1
+}
+
 // GetCellStyle provides a function to get cell style index by given worksheet
 // name and cell coordinates.
 func (f *File) GetCellStyle(sheet, axis string) (int, error) {
@@ -2277,6 +2350,161 @@ func (f *File) GetCellStyle(sheet, axis string) (int, error) {
 	return f.prepareCellStyle(xlsx, col, cellData.S), err
 }
 
+// GetStyleDefinition provides a function to get the definition of the style
+// by given style index. The definition includes the font, fill, borders,
+// alignment, protection and number format of the style, in the same JSON
+// notation accepted by NewStyle. For example, get the definition of the style
+// of cell A1 on Sheet1:
+//
+//    style, err := f.GetCellStyle("Sheet1", "A1")
+//    if err != nil {
+//        fmt.Println(err)
+//    }
+//    definition, err := f.GetStyleDefinition(style)
+//
+func (f *File) GetStyleDefinition(styleID int) (string, error) {
+	s := f.stylesReader()
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return "", fmt.Errorf("invalid style ID %d", styleID)
+	}
+	xf := s.CellXfs.Xf[styleID]
+	fs := formatStyle{NumFmt: xf.NumFmtID}
+	if _, ok := builtInNumFmt[xf.NumFmtID]; !ok && s.NumFmts != nil {
+		for _, numFmt := range s.NumFmts.NumFmt {
+			if numFmt.NumFmtID == xf.NumFmtID {
+				code := numFmt.FormatCode
+				fs.NumFmt, fs.CustomNumFmt = 0, &code
+				break
+			}
+		}
+	}
+	if s.Fonts != nil && xf.FontID < len(s.Fonts.Font) {
+		fs.Font = extractFont(s.Fonts.Font[xf.FontID])
+	}
+	if s.Fills != nil && xf.FillID < len(s.Fills.Fill) {
+		fs.Fill = extractFill(s.Fills.Fill[xf.FillID])
+	}
+	if s.Borders != nil && xf.BorderID < len(s.Borders.Border) {
+		fs.Border = extractBorders(s.Borders.Border[xf.BorderID])
+	}
+	if xf.Alignment != nil {
+		fs.Alignment = &formatAlignment{
+			Horizontal:      xf.Alignment.Horizontal,
+			Indent:          xf.Alignment.Indent,
+			JustifyLastLine: xf.Alignment.JustifyLastLine,
+			ReadingOrder:    xf.Alignment.ReadingOrder,
+			RelativeIndent:  xf.Alignment.RelativeIndent,
+			ShrinkToFit:     xf.Alignment.ShrinkToFit,
+			TextRotation:    xf.Alignment.TextRotation,
+			Vertical:        xf.Alignment.Vertical,
+			WrapText:        xf.Alignment.WrapText,
+		}
+	}
+	if xf.Protection != nil {
+		fs.Protection = &formatProtection{Hidden: xf.Protection.Hidden, Locked: xf.Protection.Locked}
+	}
+	definition, err := json.Marshal(fs)
+	return string(definition), err
+}
+
+// extractFont provides a function to extract the font settings by given font
+// of the styles.
+func extractFont(xf *xlsxFont) *formatFont {
+	var fnt decodeFont
+	_ = xml.Unmarshal([]byte("<font>"+xf.Font+"</font>"), &fnt)
+	isTrue := func(v *decodeBoolVal) bool {
+		return v != nil && v.Val != "0" && v.Val != "false"
+	}
+	format := formatFont{Bold: isTrue(fnt.B), Italic: isTrue(fnt.I)}
+	if fnt.Name != nil {
+		format.Family = fnt.Name.Val
+	}
+	if fnt.Sz != nil {
+		format.Size = int(fnt.Sz.Val)
+	}
+	if fnt.Color != nil && fnt.Color.RGB != "" {
+		format.Color = getPaletteColorCode(fnt.Color.RGB)
+	}
+	if fnt.U != nil {
+		format.Underline = fnt.U.Val
+		if format.Underline == "" {
+			format.Underline = "single"
+		}
+	}
+	return &format
+}
+
+// extractFill provides a function to extract the fill settings by given fill
+// of the styles.
+func extractFill(fill *xlsxFill) formatFill {
+	var format formatFill
+	if fill.GradientFill != nil {
+		format.Type = "gradient"
+		switch {
+		case fill.GradientFill.Type == "path" && fill.GradientFill.Top != 0:
+			format.Shading = 5
+		case fill.GradientFill.Type == "path":
+			format.Shading = 4
+		default:
+			for idx, degree := range fillVariants {
+				if degree == fill.GradientFill.Degree {
+					format.Shading = idx
+				}
+			}
+		}
+		for _, stop := range fill.GradientFill.Stop {
+			format.Color = append(format.Color, getPaletteColorCode(stop.Color.RGB))
+		}
+		return format
+	}
+	if fill.PatternFill == nil || fill.PatternFill.PatternType == "" || fill.PatternFill.PatternType == "none" {
+		return format
+	}
+	format.Type = "pattern"
+	for idx, pattern := range fillPatterns {
+		if pattern == fill.PatternFill.PatternType {
+			format.Pattern = idx
+		}
+	}
+	if color := fill.PatternFill.FgColor.RGB; color != "" {
+		format.Color = []string{getPaletteColorCode(color)}
+	}
+	return format
+}
+
+// extractBorders provides a function to extract the borders settings by given
+// border of the styles.
+func extractBorders(border *xlsxBorder) []formatBorder {
+	var borders []formatBorder
+	for _, side := range []struct {
+		typ  string
+		line xlsxLine
+		ok   bool
+	}{
+		{"left", border.Left, true},
+		{"right", border.Right, true},
+		{"top", border.Top, true},
+		{"bottom", border.Bottom, true},
+		{"diagonalUp", border.Diagonal, border.DiagonalUp},
+		{"diagonalDown", border.Diagonal, border.DiagonalDown},
+	} {
+		if !side.ok || side.line.Style == "" || side.line.Style == "none" {
+			continue
+		}
+		format := formatBorder{Type: side.typ}
+		for idx, style := range borderStyles {
+			if style == side.line.Style {
+				format.Style = idx
+			}
+		}
+		if side.line.Color != nil && side.line.Color.RGB != "" {
+			format.Color = getPaletteColorCode(side.line.Color.RGB)
+		}
+		borders = append(borders, format)
+	}
+	return borders
+}
+
 // SetCellStyle provides a function to add style attribute for cells by given
 // worksheet name, coordinate area and style ID. Note that diagonalDown and
 // diagonalUp type border should be use same color in the same coordinate
@@ -2641,6 +2869,186 @@ func (f *File) SetConditionalFormat(sheet, area, formatSet string) error {
 	return err
 }
 
+// ConditionalFormat directly maps the cell ranges (sqref) of a conditional
+// format of the worksheet and the format settings in the same JSON notation
+// accepted by SetConditionalFormat.
+type ConditionalFormat struct {
+	SQRef  string
+	Format string
+}
+
+// GetConditionalFormats returns conditional format settings by given worksheet
+// name, in the order of the conditional formats in the worksheet. The
+// conditional formats with the same cell ranges are returned respectively.
+// The ranges reflect the current state of the worksheet, for example after
+// rows or columns have been inserted or deleted. For example, get conditional
+// formats of Sheet1:
+//
+//    formats, err := f.GetConditionalFormats("Sheet1")
+//
+func (f *File) GetConditionalFormats(sheet string) ([]ConditionalFormat, error) {
+	extractContFmtFunc := map[string]func(c *xlsxCfRule) *formatConditional{
+		"cellIs":          extractCondFmtCellIs,
+		"top10":           extractCondFmtTop10,
+		"aboveAverage":    extractCondFmtAboveAverage,
+		"duplicateValues": extractCondFmtDuplicateUniqueValues,
+		"uniqueValues":    extractCondFmtDuplicateUniqueValues,
+		"colorScale":      extractCondFmtColorScale,
+		"dataBar":         extractCondFmtDataBar,
+		"expression":      extractCondFmtExp,
+	}
+
+	var conditionalFormats []ConditionalFormat
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return conditionalFormats, err
+	}
+	for _, cf := range xlsx.ConditionalFormatting {
+		format := []*formatConditional{}
+		for _, cr := range cf.CfRule {
+			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
+				format = append(format, extractFunc(cr))
+			}
+		}
+		formatSet, _ := json.Marshal(format)
+		conditionalFormats = append(conditionalFormats, ConditionalFormat{SQRef: cf.SQRef, Format: string(formatSet)})
+	}
+	return conditionalFormats, err
+}
+
+// operatorType defined the list of valid conditional formatting rule
+// operators and the criteria type in the format settings.
+var operatorType = map[string]string{
+	"between":            "between",
+	"notBetween":         "not between",
+	"equal":              "equal to",
+	"notEqual":           "not equal to",
+	"greaterThan":        "greater than",
+	"lessThan":           "less than",
+	"greaterThanOrEqual": "greater than or equal to",
+	"lessThanOrEqual":    "less than or equal to",
+}
+
+// extractCondFmtCellIs provides a function to extract conditional format
+// settings for cell value (include between, not between, equal, not equal,
+// greater than and less than) by given conditional formatting rule.
+func extractCondFmtCellIs(c *xlsxCfRule) *formatConditional {
+	format := &formatConditional{Type: "cell", Criteria: operatorType[c.Operator]}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
+	}
+	if len(c.Formula) == 2 {
+		format.Minimum, format.Maximum = c.Formula[0], c.Formula[1]
+		return format
+	}
+	if len(c.Formula) == 1 {
+		format.Value = c.Formula[0]
+	}
+	return format
+}
+
+// extractCondFmtTop10 provides a function to extract conditional format
+// settings for top N (default is top 10) by given conditional formatting
+// rule.
+func extractCondFmtTop10(c *xlsxCfRule) *formatConditional {
+	format := &formatConditional{
+		Type:     "top",
+		Criteria: "=",
+		Percent:  c.Percent,
+		Value:    strconv.Itoa(c.Rank),
+	}
+	if c.Bottom {
+		format.Type = "bottom"
+	}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
+	}
+	return format
+}
+
+// extractCondFmtAboveAverage provides a function to extract conditional
+// format settings for above average and below average by given conditional
+// formatting rule.
+func extractCondFmtAboveAverage(c *xlsxCfRule) *formatConditional {
+	format := &formatConditional{
+		Type:         "average",
+		Criteria:     "=",
+		AboveAverage: defaultTrue(c.AboveAverage),
+	}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
+	}
+	return format
+}
+
+// extractCondFmtDuplicateUniqueValues provides a function to extract
+// conditional format settings for duplicate and unique values by given
+// conditional formatting rule.
+func extractCondFmtDuplicateUniqueValues(c *xlsxCfRule) *formatConditional {
+	format := &formatConditional{
+		Type:     map[string]string{"duplicateValues": "duplicate", "uniqueValues": "unique"}[c.Type],
+		Criteria: "=",
+	}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
+	}
+	return format
+}
+
+// extractCondFmtColorScale provides a function to extract conditional format
+// settings for color scale (include 2 color scale and 3 color scale) by given
+// conditional formatting rule.
+func extractCondFmtColorScale(c *xlsxCfRule) *formatConditional {
+	format := &formatConditional{Criteria: "="}
+	if c.ColorScale == nil {
+		return format
+	}
+	cfvo, color := c.ColorScale.Cfvo, c.ColorScale.Color
+	if len(cfvo) < 2 || len(color) < 2 {
+		return format
+	}
+	format.Type = "2_color_scale"
+	format.MinType, format.MinValue = cfvo[0].Type, cfvo[0].Val
+	format.MinColor = getPaletteColorCode(color[0].RGB)
+	if len(cfvo) == 3 && len(color) == 3 {
+		format.Type = "3_color_scale"
+		format.MidType, format.MidValue = cfvo[1].Type, cfvo[1].Val
+		format.MidColor = getPaletteColorCode(color[1].RGB)
+	}
+	format.MaxType, format.MaxValue = cfvo[len(cfvo)-1].Type, cfvo[len(cfvo)-1].Val
+	format.MaxColor = getPaletteColorCode(color[len(color)-1].RGB)
+	return format
+}
+
+// extractCondFmtDataBar provides a function to extract conditional format
+// settings for data bar by given conditional formatting rule.
+func extractCondFmtDataBar(c *xlsxCfRule) *formatConditional {
+	format := &formatConditional{Type: "data_bar", Criteria: "="}
+	if c.DataBar == nil {
+		return format
+	}
+	if len(c.DataBar.Cfvo) == 2 {
+		format.MinType, format.MaxType = c.DataBar.Cfvo[0].Type, c.DataBar.Cfvo[1].Type
+	}
+	if len(c.DataBar.Color) > 0 {
+		format.BarColor = getPaletteColorCode(c.DataBar.Color[0].RGB)
+	}
+	return format
+}
+
+// extractCondFmtExp provides a function to extract conditional format
+// settings for expression by given conditional formatting rule.
+func extractCondFmtExp(c *xlsxCfRule) *formatConditional {
+	format := &formatConditional{Type: "formula"}
+	if len(c.Formula) > 0 {
+		format.Criteria = c.Formula[0]
+	}
+	if c.DxfID != nil {
+		format.Format = *c.DxfID
+	}
+	return format
+}
+
 // drawCondFmtCellIs provides a function to create conditional formatting rule
 // for cell value (include between, not between, equal, not equal, greater
 // than and less than) by given priority, criteria type and format settings.
@@ -2671,6 +3079,7 @@ func drawCondFmtTop10(p int, ct string, format *formatConditional) *xlsxCfRule {
 	c := &xlsxCfRule{
 		Priority: p + 1,
 		Type:     validType[format.Type],
+		Bottom:   format.Type == "bottom",
 		Rank:     10,
 		DxfID:    &format.Format,
 		Percent:  format.Percent,
@@ -2773,6 +3182,15 @@ func getPaletteColor(color string) string {
 	return "FF" + strings.Replace(strings.ToUpper(color), "#", "", -1)
 }
 
+// getPaletteColorCode provides a function to convert the ARGB color which
+// created by getPaletteColor to the format "#RRGGBB".
+func getPaletteColorCode(color string) string {
+	if len(color) == 8 {
+		color = color[2:]
+	}
+	return "#" + color
+}
+
 // themeReader provides a function to get the pointer to the xl/theme/theme1.xml
 // structure after deserialization.
 func (f *File) themeReader() *xlsxTheme {
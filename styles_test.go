@@ -1,6 +1,8 @@
 package excelize
 
 import (
+	"encoding/json"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -165,3 +167,211 @@ func TestSetConditionalFormat(t *testing.T) {
 		assert.EqualValues(t, testCase.rules, cf[0].CfRule, testCase.label)
 	}
 }
+
+func TestGetConditionalFormats(t *testing.T) {
+	for _, format := range []string{
+		`[{"type":"cell","criteria":"greater than","format":1,"value":"6"}]`,
+		`[{"type":"cell","criteria":"between","format":1,"minimum":"6","maximum":"8"}]`,
+		`[{"type":"top","criteria":"=","format":1,"value":"6"}]`,
+		`[{"type":"bottom","criteria":"=","format":1,"value":"6"}]`,
+		`[{"type":"average","above_average":true,"criteria":"=","format":1}]`,
+		`[{"type":"duplicate","criteria":"=","format":1}]`,
+		`[{"type":"unique","criteria":"=","format":1}]`,
+		`[{"type":"3_color_scale","criteria":"=","min_type":"num","mid_type":"num","max_type":"num","min_value":"-10","mid_value":"50","max_value":"10","min_color":"#FF0000","mid_color":"#00FF00","max_color":"#0000FF"}]`,
+		`[{"type":"2_color_scale","criteria":"=","min_type":"num","max_type":"num","min_color":"#FF0000","max_color":"#0000FF"}]`,
+		`[{"type":"data_bar","criteria":"=","min_type":"min","max_type":"max","bar_color":"#638EC6"}]`,
+		`[{"type":"formula","criteria":"$A1<3","format":1}]`,
+	} {
+		f := NewFile()
+		err := f.SetConditionalFormat("Sheet1", "A1:A2", format)
+		assert.NoError(t, err)
+		formatSet, err := f.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		expected, actual := []*formatConditional{}, []*formatConditional{}
+		assert.NoError(t, json.Unmarshal([]byte(format), &expected))
+		assert.Len(t, formatSet, 1)
+		assert.Equal(t, "A1:A2", formatSet[0].SQRef)
+		assert.NoError(t, json.Unmarshal([]byte(formatSet[0].Format), &actual))
+		if expected[0].Type == "2_color_scale" {
+			// the default values are filled for the color scale.
+			expected[0].MinValue, expected[0].MaxValue = "0", "0"
+		}
+		assert.Equal(t, expected, actual, format)
+	}
+	// test get conditional formats on not exists worksheet.
+	f := NewFile()
+	_, err := f.GetConditionalFormats("SheetN")
+	assert.EqualError(t, err, "sheet SheetN is not exist")
+}
+
+func TestGetConditionalFormatsAfterAdjust(t *testing.T) {
+	f := NewFile()
+	format := `[{"type":"2_color_scale","criteria":"=","min_type":"min","max_type":"max","min_color":"#F8696B","max_color":"#63BE7B"}]`
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", format))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B2 C3:D4", format))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E20", 1))
+	sqrefs := func() []string {
+		formatSet, err := f.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		var sqrefs []string
+		for _, cf := range formatSet {
+			sqrefs = append(sqrefs, cf.SQRef)
+		}
+		return sqrefs
+	}
+
+	assert.NoError(t, f.InsertRow("Sheet1", 5))
+	assert.Equal(t, []string{"A1:A11", "B2 C3:D4"}, sqrefs())
+
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.Equal(t, []string{"A2:A12", "B3 C4:D5"}, sqrefs())
+
+	assert.NoError(t, f.RemoveRow("Sheet1", 3))
+	assert.Equal(t, []string{"A2:A11", "C3:D4"}, sqrefs())
+
+	assert.NoError(t, f.RemoveCol("Sheet1", "B"))
+	assert.Equal(t, []string{"A2:A11", "B3:C4"}, sqrefs())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetConditionalFormatsAfterAdjust.xlsx")))
+
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.Equal(t, []string{"A3:B4"}, sqrefs())
+}
+
+func TestGetConditionalFormatsDuplicateSQRef(t *testing.T) {
+	f := NewFile()
+	formats := []string{
+		`[{"type":"cell","criteria":"greater than","format":1,"value":"6"}]`,
+		`[{"type":"cell","criteria":"less than","format":1,"value":"3"}]`,
+	}
+	for _, format := range formats {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", format))
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", formats[0]))
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	// Test the conditional formats with the same ranges are returned in the
+	// order of the conditional formats.
+	formatSet, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, formatSet, 3) {
+		for i, expected := range []struct{ sqref, criteria string }{
+			{"A2:A11", "greater than"}, {"A2:A11", "less than"}, {"B2:B11", "greater than"},
+		} {
+			var actual []*formatConditional
+			assert.NoError(t, json.Unmarshal([]byte(formatSet[i].Format), &actual))
+			assert.Equal(t, expected.sqref, formatSet[i].SQRef)
+			assert.Equal(t, expected.criteria, actual[0].Criteria)
+		}
+	}
+}
+
+func TestGetStyleDefinition(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(`{"font":{"bold":true,"italic":true,"underline":"double","family":"Times New Roman","size":14,"color":"#777777"},"fill":{"type":"pattern","color":["#E0EBF5"],"pattern":1},"border":[{"type":"left","color":"0000FF","style":3},{"type":"diagonalUp","color":"A020F0","style":8}],"alignment":{"horizontal":"center","wrap_text":true},"protection":{"locked":true},"custom_number_format":"[$-380A]dddd\\,\\ dd\" de \"mmmm\" de \"yyyy;@"}`)
+	assert.NoError(t, err)
+	for col := 'A'; col <= 'C'; col++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", string(col)+"3", 1))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A3", "C3", style))
+	// Test read back the style of the row which is shifted by inserting rows.
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	cellStyle, err := f.GetCellStyle("Sheet1", "B5")
+	assert.NoError(t, err)
+	assert.Equal(t, style, cellStyle)
+	definition, err := f.GetStyleDefinition(cellStyle)
+	assert.NoError(t, err)
+	var format formatStyle
+	assert.NoError(t, json.Unmarshal([]byte(definition), &format))
+	assert.Equal(t, &formatFont{Bold: true, Italic: true, Underline: "double", Family: "Times New Roman", Size: 14, Color: "#777777"}, format.Font)
+	assert.Equal(t, formatFill{Type: "pattern", Pattern: 1, Color: []string{"#E0EBF5"}}, format.Fill)
+	assert.Equal(t, []formatBorder{{Type: "left", Color: "#0000FF", Style: 3}, {Type: "diagonalUp", Color: "#A020F0", Style: 8}}, format.Border)
+	assert.Equal(t, &formatAlignment{Horizontal: "center", WrapText: true}, format.Alignment)
+	assert.Equal(t, &formatProtection{Locked: true}, format.Protection)
+	if assert.NotNil(t, format.CustomNumFmt) {
+		assert.Equal(t, `[$-380A]dddd\,\ dd" de "mmmm" de "yyyy;@`, *format.CustomNumFmt)
+	}
+	// Test the definition can be used to create the same style.
+	_, err = f.NewStyle(definition)
+	assert.NoError(t, err)
+
+	// Test get the definition of the style with built-in number format and
+	// gradient fill.
+	style, err = f.NewStyle(`{"fill":{"type":"gradient","color":["#FFFFFF","#E0EBF5"],"shading":5},"number_format":14}`)
+	assert.NoError(t, err)
+	definition, err = f.GetStyleDefinition(style)
+	assert.NoError(t, err)
+	format = formatStyle{}
+	assert.NoError(t, json.Unmarshal([]byte(definition), &format))
+	assert.Equal(t, formatFill{Type: "gradient", Color: []string{"#FFFFFF", "#E0EBF5"}, Shading: 5}, format.Fill)
+	assert.Equal(t, 14, format.NumFmt)
+	assert.Nil(t, format.CustomNumFmt)
+
+	// Test get the definition of the font without the value of the properties.
+	assert.Equal(t, &formatFont{Bold: true, Underline: "single", Size: 10}, extractFont(&xlsxFont{Font: `<b/><i val="0"/><u/><sz val="10.5"/>`}))
+	assert.Equal(t, formatFill{}, extractFill(&xlsxFill{PatternFill: &xlsxPatternFill{PatternType: "none"}}))
+
+	// Test get the definition with invalid style ID.
+	_, err = f.GetStyleDefinition(-1)
+	assert.EqualError(t, err, "invalid style ID -1")
+	_, err = f.GetStyleDefinition(100)
+	assert.EqualError(t, err, "invalid style ID 100")
+}
+
+func TestSetCellLocked(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(`{"font":{"bold":true}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", style))
+	locked := func(cell string) bool {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		xf := f.stylesReader().CellXfs.Xf[styleID]
+		return xf.Protection == nil || xf.Protection.Locked
+	}
+
+	// Test unlock the cells and keep the other settings of the styles.
+	assert.NoError(t, f.SetCellLocked("Sheet1", "A1:A5", false))
+	for _, cell := range []string{"A1", "A2", "A5"} {
+		assert.False(t, locked(cell), cell)
+	}
+	assert.True(t, locked("B1"))
+	assert.True(t, locked("A6"))
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	definition, err := f.GetStyleDefinition(styleID)
+	assert.NoError(t, err)
+	assert.Contains(t, definition, `"bold":true`)
+	assert.Contains(t, definition, `"protection":{"hidden":false,"locked":false}`)
+	// Test the styles are reused.
+	count := len(f.stylesReader().CellXfs.Xf)
+	assert.NoError(t, f.SetCellLocked("Sheet1", "C3", false))
+	assert.False(t, locked("C3"))
+	assert.Len(t, f.stylesReader().CellXfs.Xf, count)
+
+	// Test the unlocked region grows with the inserted rows and columns.
+	assert.NoError(t, f.InsertRow("Sheet1", 3))
+	for _, cell := range []string{"A3", "A4", "A6"} {
+		assert.False(t, locked(cell), cell)
+	}
+	assert.True(t, locked("A7"))
+	assert.True(t, locked("B3"))
+	assert.NoError(t, f.InsertRow("Sheet1", 7))
+	assert.True(t, locked("A7"))
+	assert.NoError(t, f.SetCellLocked("Sheet1", "B1:B2", false))
+	assert.NoError(t, f.InsertCol("Sheet1", "B"))
+	assert.False(t, locked("B1"))
+	assert.False(t, locked("B2"))
+	assert.True(t, locked("B3"))
+
+	// Test lock the cells.
+	assert.NoError(t, f.SetCellLocked("Sheet1", "A1:A2", true))
+	assert.True(t, locked("A1"))
+	assert.True(t, locked("A2"))
+	assert.False(t, locked("A3"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellLocked.xlsx")))
+
+	// Test set the locked flag with invalid arguments.
+	assert.EqualError(t, f.SetCellLocked("Sheet1", "A", false), `cannot convert cell "A" to coordinates: invalid cell name "A"`)
+	assert.EqualError(t, f.SetCellLocked("SheetN", "A1", false), "sheet SheetN is not exist")
+	assert.Equal(t, 100, f.setLockedStyle(100, false))
+}
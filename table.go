@@ -87,18 +87,37 @@ func (f *File) AddTable(sheet, hcell, vcell, format string) error {
 	return err
 }
 
-// countTables provides a function to get table files count storage in the
-// folder xl/tables.
+// countTables provides a function to get the largest number of the table
+// files in the folder xl/tables, so that the number of the new table file
+// doesn't conflict with the existing ones if some of the tables have been
+// deleted.
 func (f *File) countTables() int {
 	count := 0
 	for k := range f.XLSX {
-		if strings.Contains(k, "xl/tables/table") {
-			count++
+		if !strings.HasPrefix(k, "xl/tables/table") || !strings.HasSuffix(k, ".xml") {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(k, "xl/tables/table"), ".xml")); err == nil && n > count {
+			count = n
 		}
 	}
 	return count
 }
 
+// deleteTable provides a function to delete the table by given worksheet
+// name, relationship index and the path of the table part.
+func (f *File) deleteTable(sheet, rID, tableXML string) {
+	f.deleteSheetRelationships(sheet, rID)
+	delete(f.XLSX, tableXML)
+	content := f.contentTypesReader()
+	for k, v := range content.Overrides {
+		if v.PartName == "/"+tableXML {
+			content.Overrides = append(content.Overrides[:k], content.Overrides[k+1:]...)
+			break
+		}
+	}
+}
+
 // addSheetTable provides a function to add tablePart element to
 // xl/worksheets/sheet%d.xml by given worksheet name and relationship index.
 func (f *File) addSheetTable(sheet string, rID int) {
@@ -341,6 +360,66 @@ func (f *File) autoFilter(sheet, ref string, refRange, col int, formatSet *forma
 	return nil
 }
 
+// GetAutoFilter provides a function to get the auto filter definition of the
+// worksheet by given worksheet name, including the filter range, the criteria
+// of the filter columns and the sort state. It returns nil if the worksheet
+// has no auto filter. For example, get the auto filter of Sheet1:
+//
+//    filter, err := f.GetAutoFilter("Sheet1")
+//    if err != nil {
+//        fmt.Println(err)
+//        return
+//    }
+//    if filter != nil {
+//        fmt.Println(filter.Ref)
+//    }
+//
+func (f *File) GetAutoFilter(sheet string) (*AutoFilterDefinition, error) {
+	xlsx, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if xlsx.AutoFilter == nil {
+		return nil, nil
+	}
+	filter := &AutoFilterDefinition{Ref: xlsx.AutoFilter.Ref}
+	coordinates, err := cellRefToCoordinates(xlsx.AutoFilter.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if filterColumn := xlsx.AutoFilter.FilterColumn; filterColumn != nil {
+		column := AutoFilterColumn{ColID: filterColumn.ColID}
+		if column.Column, err = ColumnNumberToName(coordinates[0] + filterColumn.ColID); err != nil {
+			return nil, err
+		}
+		if filterColumn.Filters != nil {
+			for _, item := range filterColumn.Filters.Filter {
+				column.Filters = append(column.Filters, item.Val)
+			}
+		}
+		if filterColumn.CustomFilters != nil {
+			column.And = filterColumn.CustomFilters.And
+			for _, customFilter := range filterColumn.CustomFilters.CustomFilter {
+				column.CustomFilters = append(column.CustomFilters, AutoFilterCustomFilter{
+					Operator: customFilter.Operator,
+					Val:      customFilter.Val,
+				})
+			}
+		}
+		filter.Columns = append(filter.Columns, column)
+	}
+	if sortState := xlsx.AutoFilter.SortState; sortState != nil {
+		filter.SortState = &AutoFilterSortState{Ref: sortState.Ref}
+		for _, condition := range sortState.SortCondition {
+			filter.SortState.Conditions = append(filter.SortState.Conditions, AutoFilterSortCondition{
+				Ref:        condition.Ref,
+				Descending: condition.Descending,
+			})
+		}
+	}
+	return filter, nil
+}
+
 // writeAutoFilter provides a function to check for single or double custom
 // filters as default filters and handle them accordingly.
 func (f *File) writeAutoFilter(filter *xlsxAutoFilter, exp []int, tokens []string) {
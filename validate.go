@@ -0,0 +1,219 @@
+// Copyright 2016 - 2019 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to
+// and read from XLSX files. Support reads and writes XLSX file generated by
+// Microsoft Excel™ 2007 and later. Support save file without losing original
+// charts of XLSX. This library needs Go version 1.8 or later.
+
+package excelize
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Validate provides a function to check the integrity of the workbook, it
+// returns the list of the violated invariants which the functions of
+// inserting and deleting rows and columns rely on. The list is empty if the
+// workbook is valid. The following checks are applied to each worksheet:
+//
+//    cell and row references are valid and located inside of the worksheet
+//    merged cells are valid and don't overlap with each other
+//    hyperlinks are valid and the relationships of external hyperlinks exist
+//    the relationships of hyperlinks are referenced by hyperlinks
+//    the count attributes of merged cells and data validations are consistent
+//
+// The worksheets which are not loaded yet are checked as they are in the XML
+// without being loaded. For example, check the workbook after editing:
+//
+//    for _, err := range f.Validate() {
+//        fmt.Println(err)
+//    }
+//
+func (f *File) Validate() []error {
+	var errs []error
+	for _, sheet := range f.workbookReader().Sheets.Sheet {
+		xlsx, err := f.validateWorkSheetReader(sheet.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, validate := range []func(sheet string, xlsx *xlsxWorksheet) []error{
+			f.validateSheetData,
+			f.validateMergeCells,
+			f.validateHyperlinks,
+			f.validateDataValidations,
+		} {
+			errs = append(errs, validate(sheet.Name, xlsx)...)
+		}
+	}
+	return errs
+}
+
+// validateWorkSheetReader provides a function to get the worksheet to be
+// checked by given worksheet name. The worksheet already loaded is returned
+// directly, otherwise it's parsed from the XML without being normalized and
+// cached, so that the worksheets not loaded are kept unchanged.
+func (f *File) validateWorkSheetReader(sheet string) (*xlsxWorksheet, error) {
+	name, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return nil, fmt.Errorf("sheet %s is not exist", sheet)
+	}
+	if xlsx := f.Sheet[name]; xlsx != nil {
+		return xlsx, nil
+	}
+	var xlsx xlsxWorksheet
+	if err := xml.Unmarshal(namespaceStrictToTransitional(f.readXML(name)), &xlsx); err != nil {
+		return nil, fmt.Errorf("sheet %s: %v", sheet, err)
+	}
+	return &xlsx, nil
+}
+
+// validateCoordinates provides a function to check if the given coordinates
+// are located inside of the worksheet.
+func validateCoordinates(col, row int) error {
+	if col < 1 || col > TotalColumns || row < 1 || row > TotalRows {
+		return fmt.Errorf("coordinates [%d, %d] out of range", col, row)
+	}
+	return nil
+}
+
+// validateArea provides a function to parse and check the area reference, a
+// single cell reference is treated as an area of one cell.
+func validateArea(ref string) ([]int, error) {
+	area := ref
+	if !strings.Contains(area, ":") {
+		area = ref + ":" + ref
+	}
+	coordinates, err := areaRefToCoordinates(area)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(coordinates); i += 2 {
+		if err = validateCoordinates(coordinates[i], coordinates[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	return coordinates, nil
+}
+
+// validateSheetData provides a function to check the references of the rows
+// and cells of the worksheet. The references which are omitted in the XML of
+// the worksheet not loaded are skipped.
+func (f *File) validateSheetData(sheet string, xlsx *xlsxWorksheet) []error {
+	var errs []error
+	for _, row := range xlsx.SheetData.Row {
+		if row.R == 0 {
+			continue
+		}
+		if row.R < 0 || row.R > TotalRows {
+			errs = append(errs, fmt.Errorf("sheet %s: invalid row number %d", sheet, row.R))
+			continue
+		}
+		for _, c := range row.C {
+			if c.R == "" {
+				continue
+			}
+			col, cellRow, err := CellNameToCoordinates(c.R)
+			if err == nil {
+				err = validateCoordinates(col, cellRow)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("sheet %s: cell %s: %v", sheet, c.R, err))
+				continue
+			}
+			if cellRow != row.R {
+				errs = append(errs, fmt.Errorf("sheet %s: cell %s is not in row %d", sheet, c.R, row.R))
+			}
+		}
+	}
+	return errs
+}
+
+// validateMergeCells provides a function to check the merged cells of the
+// worksheet.
+func (f *File) validateMergeCells(sheet string, xlsx *xlsxWorksheet) []error {
+	var errs []error
+	if xlsx.MergeCells == nil {
+		return errs
+	}
+	if xlsx.MergeCells.Count != 0 && xlsx.MergeCells.Count != len(xlsx.MergeCells.Cells) {
+		errs = append(errs, fmt.Errorf("sheet %s: merged cells count %d does not match %d merged cells",
+			sheet, xlsx.MergeCells.Count, len(xlsx.MergeCells.Cells)))
+	}
+	var areas [][]int
+	var refs []string
+	for _, mergeCell := range xlsx.MergeCells.Cells {
+		area, err := validateArea(mergeCell.Ref)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sheet %s: merged cell %s: %v", sheet, mergeCell.Ref, err))
+			continue
+		}
+		for i, rect := range areas {
+			if areaOverlaps(rect, area) {
+				errs = append(errs, fmt.Errorf("sheet %s: merged cell %s overlaps with %s", sheet, mergeCell.Ref, refs[i]))
+			}
+		}
+		areas = append(areas, area)
+		refs = append(refs, mergeCell.Ref)
+	}
+	return errs
+}
+
+// validateHyperlinks provides a function to check the hyperlinks of the
+// worksheet and the relationships of the external hyperlinks.
+func (f *File) validateHyperlinks(sheet string, xlsx *xlsxWorksheet) []error {
+	var errs []error
+	referenced := map[string]bool{}
+	if xlsx.Hyperlinks != nil {
+		for _, link := range xlsx.Hyperlinks.Hyperlink {
+			if _, err := validateArea(link.Ref); err != nil {
+				errs = append(errs, fmt.Errorf("sheet %s: hyperlink %s: %v", sheet, link.Ref, err))
+			}
+			if link.RID == "" {
+				continue
+			}
+			referenced[link.RID] = true
+			if f.getSheetRelationshipsTargetByID(sheet, link.RID) == "" {
+				errs = append(errs, fmt.Errorf("sheet %s: relationship %s of hyperlink %s does not exist", sheet, link.RID, link.Ref))
+			}
+		}
+	}
+	name, ok := f.sheetMap[trimSheetName(sheet)]
+	if !ok {
+		return errs
+	}
+	rels := "xl/worksheets/_rels/" + strings.TrimPrefix(name, "xl/worksheets/") + ".rels"
+	if sheetRels := f.workSheetRelsReader(rels); sheetRels != nil {
+		for _, rel := range sheetRels.Relationships {
+			if rel.Type == SourceRelationshipHyperLink && !referenced[rel.ID] {
+				errs = append(errs, fmt.Errorf("sheet %s: hyperlink relationship %s is not referenced", sheet, rel.ID))
+			}
+		}
+	}
+	return errs
+}
+
+// validateDataValidations provides a function to check the data validations
+// of the worksheet.
+func (f *File) validateDataValidations(sheet string, xlsx *xlsxWorksheet) []error {
+	var errs []error
+	if xlsx.DataValidations == nil {
+		return errs
+	}
+	if xlsx.DataValidations.Count != 0 && xlsx.DataValidations.Count != len(xlsx.DataValidations.DataValidation) {
+		errs = append(errs, fmt.Errorf("sheet %s: data validations count %d does not match %d data validations",
+			sheet, xlsx.DataValidations.Count, len(xlsx.DataValidations.DataValidation)))
+	}
+	for _, dv := range xlsx.DataValidations.DataValidation {
+		for _, ref := range strings.Fields(dv.Sqref) {
+			if _, err := validateArea(ref); err != nil {
+				errs = append(errs, fmt.Errorf("sheet %s: data validation %s: %v", sheet, ref, err))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,111 @@
+package excelize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	f := NewFile()
+	f.NewSheet("Sheet2")
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "A1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B2"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "C3", "https://github.com/360EntSecGroup-Skylar/excelize", "External"))
+	dvRange := NewDataValidation(true)
+	dvRange.Sqref = "A3:B4"
+	assert.NoError(t, dvRange.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dvRange))
+	assert.NoError(t, f.InsertRow("Sheet1", 1))
+	assert.NoError(t, f.RemoveCol("Sheet1", "A"))
+	assert.Empty(t, f.Validate())
+
+	xlsx, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	// overlapped merged cells.
+	assert.NoError(t, f.MergeCell("Sheet2", "A1", "B2"))
+	xlsx2, err := f.workSheetReader("Sheet2")
+	assert.NoError(t, err)
+	xlsx2.MergeCells.Cells = append(xlsx2.MergeCells.Cells, &xlsxMergeCell{Ref: "B2:C3"}, &xlsxMergeCell{Ref: "XFE1:XFE2"})
+	xlsx2.MergeCells.Count = 1
+	assert.Equal(t, []string{
+		"sheet Sheet2: merged cells count 1 does not match 3 merged cells",
+		"sheet Sheet2: merged cell B2:C3 overlaps with A1:B2",
+		"sheet Sheet2: merged cell XFE1:XFE2: coordinates [16385, 1] out of range",
+	}, errorsToStrings(f.Validate()))
+	xlsx2.MergeCells = nil
+
+	// out of range coordinates.
+	xlsx.SheetData.Row[0].C = append(xlsx.SheetData.Row[0].C, xlsxC{R: "A2"}, xlsxC{R: "A"})
+	xlsx.SheetData.Row = append(xlsx.SheetData.Row, xlsxRow{R: TotalRows + 1})
+	assert.Equal(t, []string{
+		"sheet Sheet1: cell A2 is not in row 1",
+		`sheet Sheet1: cell A: cannot convert cell "A" to coordinates: invalid cell name "A"`,
+		"sheet Sheet1: invalid row number 1048577",
+	}, errorsToStrings(f.Validate()))
+	xlsx.SheetData.Row[0].C = nil
+	xlsx.SheetData.Row = xlsx.SheetData.Row[:len(xlsx.SheetData.Row)-1]
+
+	// orphaned hyperlink relationships.
+	rID := xlsx.Hyperlinks.Hyperlink[0].RID
+	xlsx.Hyperlinks.Hyperlink[0].RID = "rId100"
+	xlsx.Hyperlinks.Hyperlink = append(xlsx.Hyperlinks.Hyperlink, xlsxHyperlink{Ref: "A0", Location: "Sheet1!A1"})
+	assert.Equal(t, []string{
+		"sheet Sheet1: relationship rId100 of hyperlink B4 does not exist",
+		`sheet Sheet1: hyperlink A0: cannot convert cell "A0" to coordinates: invalid cell name "A0"`,
+		"sheet Sheet1: hyperlink relationship " + rID + " is not referenced",
+	}, errorsToStrings(f.Validate()))
+	xlsx.Hyperlinks = nil
+	f.deleteSheetRelationships("Sheet1", rID)
+
+	// inconsistent data validations.
+	xlsx.DataValidations.Count = 2
+	xlsx.DataValidations.DataValidation[0].Sqref = "A1:A"
+	assert.Equal(t, []string{
+		"sheet Sheet1: data validations count 2 does not match 1 data validations",
+		`sheet Sheet1: data validation A1:A: cannot convert cell "A" to coordinates: invalid cell name "A"`,
+	}, errorsToStrings(f.Validate()))
+	xlsx.DataValidations = nil
+	assert.Empty(t, f.Validate())
+
+	// not exists worksheet.
+	wb := f.workbookReader()
+	wb.Sheets.Sheet = append(wb.Sheets.Sheet, xlsxSheet{Name: "SheetN"})
+	assert.Equal(t, []string{"sheet SheetN is not exist"}, errorsToStrings(f.Validate()))
+}
+
+func TestValidateUnloadedSheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", "C3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "C3", "D4"))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	name := f.sheetMap["Sheet1"]
+	raw := string(f.XLSX[name])
+
+	// Test validate the worksheet which is not loaded, the worksheet is
+	// neither loaded nor normalized.
+	assert.Empty(t, f.Validate())
+	assert.Nil(t, f.Sheet[name])
+	assert.Equal(t, raw, string(f.XLSX[name]))
+
+	// Test validate the worksheet which is not loaded with invalid references.
+	f.XLSX[name] = []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="2"><c r="A3"/><c/></row></sheetData><mergeCells count="2"><mergeCell ref="A1:B2"/></mergeCells></worksheet>`)
+	assert.Equal(t, []string{
+		"sheet Sheet1: cell A3 is not in row 2",
+		"sheet Sheet1: merged cells count 2 does not match 1 merged cells",
+	}, errorsToStrings(f.Validate()))
+	assert.Nil(t, f.Sheet[name])
+	f.XLSX[name] = []byte(`<worksheet`)
+	assert.Equal(t, []string{"sheet Sheet1: XML syntax error on line 1: unexpected EOF"}, errorsToStrings(f.Validate()))
+}
+
+func errorsToStrings(errs []error) []string {
+	var s []string
+	for _, err := range errs {
+		s = append(s, err.Error())
+	}
+	return s
+}
@@ -21,6 +21,7 @@ type vmlDrawing struct {
 	XMLNSmv     string           `xml:"xmlns:mv,attr"`
 	Shapelayout *xlsxShapelayout `xml:"o:shapelayout"`
 	Shapetype   *xlsxShapetype   `xml:"v:shapetype"`
+	Shapetypes  []vmlShapetype   `xml:",any"`
 	Shape       []xlsxShape      `xml:"v:shape"`
 }
 
@@ -35,19 +36,20 @@ type xlsxShapelayout struct {
 // xlsxIDmap directly maps the idmap element.
 type xlsxIDmap struct {
 	Ext  string `xml:"v:ext,attr"`
-	Data int    `xml:"data,attr"`
+	Data string `xml:"data,attr"`
 }
 
 // xlsxShape directly maps the shape element.
 type xlsxShape struct {
-	XMLName     xml.Name `xml:"v:shape"`
-	ID          string   `xml:"id,attr"`
-	Type        string   `xml:"type,attr"`
-	Style       string   `xml:"style,attr"`
-	Fillcolor   string   `xml:"fillcolor,attr"`
-	Insetmode   string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
-	Strokecolor string   `xml:"strokecolor,attr,omitempty"`
-	Val         string   `xml:",innerxml"`
+	XMLName     xml.Name   `xml:"v:shape"`
+	ID          string     `xml:"id,attr"`
+	Type        string     `xml:"type,attr"`
+	Style       string     `xml:"style,attr"`
+	Fillcolor   string     `xml:"fillcolor,attr"`
+	Insetmode   string     `xml:"o:insetmode,attr,omitempty"`
+	Strokecolor string     `xml:"strokecolor,attr,omitempty"`
+	Attr        []xml.Attr `xml:",any,attr"`
+	Val         string     `xml:",innerxml"`
 }
 
 // xlsxShapetype directly maps the shapetype element.
@@ -60,6 +62,15 @@ type xlsxShapetype struct {
 	VPath     *vPath      `xml:"v:path"`
 }
 
+// vmlShapetype directly maps the shapetype element of the existing drawing,
+// the attributes and the content of the shapetype are kept as they are.
+type vmlShapetype struct {
+	XMLName xml.Name   `xml:"v:shapetype"`
+	ID      string     `xml:"id,attr"`
+	Attr    []xml.Attr `xml:",any,attr"`
+	Val     string     `xml:",innerxml"`
+}
+
 // xlsxStroke directly maps the stroke element.
 type xlsxStroke struct {
 	Joinstyle string `xml:"joinstyle,attr"`
@@ -126,12 +137,36 @@ type xClientData struct {
 // decodeVmlDrawing defines the structure used to parse the file
 // xl/drawings/vmlDrawing%d.vml.
 type decodeVmlDrawing struct {
-	Shape []decodeShape `xml:"urn:schemas-microsoft-com:vml shape"`
+	Shapelayout *decodeShapelayout `xml:"urn:schemas-microsoft-com:office:office shapelayout"`
+	Shapetype   []decodeShapetype  `xml:"urn:schemas-microsoft-com:vml shapetype"`
+	Shape       []decodeShape      `xml:"urn:schemas-microsoft-com:vml shape"`
+}
+
+// decodeShapelayout defines the structure used to parse the shapelayout
+// element.
+type decodeShapelayout struct {
+	IDmap *struct {
+		Data string `xml:"data,attr"`
+	} `xml:"urn:schemas-microsoft-com:office:office idmap"`
+}
+
+// decodeShapetype defines the structure used to parse the shapetype element.
+type decodeShapetype struct {
+	ID   string     `xml:"id,attr"`
+	Attr []xml.Attr `xml:",any,attr"`
+	Val  string     `xml:",innerxml"`
 }
 
 // decodeShape defines the structure used to parse the particular shape element.
 type decodeShape struct {
-	Val string `xml:",innerxml"`
+	ID          string     `xml:"id,attr"`
+	Type        string     `xml:"type,attr"`
+	Style       string     `xml:"style,attr"`
+	Fillcolor   string     `xml:"fillcolor,attr"`
+	Insetmode   string     `xml:"urn:schemas-microsoft-com:office:office insetmode,attr"`
+	Strokecolor string     `xml:"strokecolor,attr"`
+	Attr        []xml.Attr `xml:",any,attr"`
+	Val         string     `xml:",innerxml"`
 }
 
 // encodeShape defines the structure used to re-serialization shape element.
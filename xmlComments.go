@@ -57,6 +57,36 @@ type xlsxText struct {
 	R []xlsxR `xml:"r"`
 }
 
+// xlsxThreadedComments directly maps the ThreadedComments element from the
+// namespace http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments.
+// The modern threaded comments of the worksheet are stored in this part, and
+// each thread has a legacy comment in the comments part for the applications
+// which don't support the threaded comments.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+	ExtLst          *xlsxExtLst           `xml:"extLst"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a single comment or reply of the thread attached to the cell.
+type xlsxThreadedComment struct {
+	Ref      string        `xml:"ref,attr,omitempty"`
+	DT       string        `xml:"dT,attr,omitempty"`
+	PersonID string        `xml:"personId,attr"`
+	ID       string        `xml:"id,attr"`
+	ParentID string        `xml:"parentId,attr,omitempty"`
+	Done     *bool         `xml:"done,attr"`
+	Text     string        `xml:"text"`
+	Mentions *xlsxInnerXML `xml:"mentions"`
+	ExtLst   *xlsxExtLst   `xml:"extLst"`
+}
+
+// xlsxInnerXML holds the raw content of the element which isn't mapped.
+type xlsxInnerXML struct {
+	Content string `xml:",innerxml"`
+}
+
 // formatComment directly maps the format settings of the comment.
 type formatComment struct {
 	Author string `json:"author"`
@@ -13,29 +13,37 @@ import "encoding/xml"
 
 // Source relationship and namespace.
 const (
-	SourceRelationship               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
-	SourceRelationshipChart          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
-	SourceRelationshipComments       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
-	SourceRelationshipImage          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
-	SourceRelationshipTable          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
-	SourceRelationshipDrawingML      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
-	SourceRelationshipDrawingVML     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
-	SourceRelationshipHyperLink      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
-	SourceRelationshipWorkSheet      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
-	SourceRelationshipChart201506    = "http://schemas.microsoft.com/office/drawing/2015/06/chart"
-	SourceRelationshipChart20070802  = "http://schemas.microsoft.com/office/drawing/2007/8/2/chart"
-	SourceRelationshipChart2014      = "http://schemas.microsoft.com/office/drawing/2014/chart"
-	SourceRelationshipCompatibility  = "http://schemas.openxmlformats.org/markup-compatibility/2006"
-	NameSpaceDrawingML               = "http://schemas.openxmlformats.org/drawingml/2006/main"
-	NameSpaceDrawingMLChart          = "http://schemas.openxmlformats.org/drawingml/2006/chart"
-	NameSpaceDrawingMLSpreadSheet    = "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
-	NameSpaceSpreadSheet             = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
-	NameSpaceXML                     = "http://www.w3.org/XML/1998/namespace"
-	StrictSourceRelationship         = "http://purl.oclc.org/ooxml/officeDocument/relationships"
-	StrictSourceRelationshipChart    = "http://purl.oclc.org/ooxml/officeDocument/relationships/chart"
-	StrictSourceRelationshipComments = "http://purl.oclc.org/ooxml/officeDocument/relationships/comments"
-	StrictSourceRelationshipImage    = "http://purl.oclc.org/ooxml/officeDocument/relationships/image"
-	StrictNameSpaceSpreadSheet       = "http://purl.oclc.org/ooxml/spreadsheetml/main"
+	SourceRelationship                = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	SourceRelationshipChart           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+	SourceRelationshipComments        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
+	SourceRelationshipCtrlProp        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/ctrlProp"
+	SourceRelationshipImage           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+	SourceRelationshipTable           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipDrawingML       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
+	SourceRelationshipDrawingVML      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing"
+	SourceRelationshipHyperLink       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
+	SourceRelationshipWorkSheet       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+	SourceRelationshipChart201506     = "http://schemas.microsoft.com/office/drawing/2015/06/chart"
+	SourceRelationshipChart20070802   = "http://schemas.microsoft.com/office/drawing/2007/8/2/chart"
+	SourceRelationshipChart2014       = "http://schemas.microsoft.com/office/drawing/2014/chart"
+	SourceRelationshipCompatibility   = "http://schemas.openxmlformats.org/markup-compatibility/2006"
+	SourceRelationshipThreadedComment = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
+	NameSpaceDrawingML                = "http://schemas.openxmlformats.org/drawingml/2006/main"
+	NameSpaceDrawingMLChart           = "http://schemas.openxmlformats.org/drawingml/2006/chart"
+	NameSpaceDrawingMLSpreadSheet     = "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"
+	NameSpaceSpreadSheet              = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	NameSpaceXML                      = "http://www.w3.org/XML/1998/namespace"
+	StrictSourceRelationship          = "http://purl.oclc.org/ooxml/officeDocument/relationships"
+	StrictSourceRelationshipChart     = "http://purl.oclc.org/ooxml/officeDocument/relationships/chart"
+	StrictSourceRelationshipComments  = "http://purl.oclc.org/ooxml/officeDocument/relationships/comments"
+	StrictSourceRelationshipImage     = "http://purl.oclc.org/ooxml/officeDocument/relationships/image"
+	StrictNameSpaceSpreadSheet        = "http://purl.oclc.org/ooxml/spreadsheetml/main"
+)
+
+// Excel specifications and limits
+const (
+	TotalRows    = 1048576
+	TotalColumns = 16384
 )
 
 var supportImageTypes = map[string]string{".gif": ".gif", ".jpg": ".jpeg", ".jpeg": ".jpeg", ".png": ".png"}
@@ -29,8 +29,10 @@ type xlsxSST struct {
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main - currently I have
 // not checked this for completeness - it does as much as I need.
 type xlsxSI struct {
-	T string  `xml:"t"`
-	R []xlsxR `xml:"r"`
+	T          string             `xml:"t"`
+	R          []xlsxR            `xml:"r"`
+	RPh        []*xlsxPhoneticRun `xml:"rPh"`
+	PhoneticPr *xlsxPhoneticPr    `xml:"phoneticPr"`
 }
 
 // xlsxR directly maps the r element from the namespace
@@ -41,6 +43,15 @@ type xlsxR struct {
 	T   string   `xml:"t"`
 }
 
+// xlsxPhoneticRun (Phonetic Run) directly maps the rPh element. This element
+// represents a run of text which displays a phonetic hint for the base text
+// from the start index to the end index of the characters of the string.
+type xlsxPhoneticRun struct {
+	Sb int    `xml:"sb,attr"`
+	Eb int    `xml:"eb,attr"`
+	T  string `xml:"t"`
+}
+
 // xlsxRPr (Run Properties) specifies a set of run properties which shall be
 // applied to the contents of the parent run after all style formatting has been
 // applied to the text. These properties are defined as direct formatting, since
@@ -100,6 +100,23 @@ type font struct {
 	Scheme   *attrValString `xml:"scheme"`
 }
 
+// decodeFont defines the structure used to parse the font element of the
+// cell styles. The boolean properties may be present without the value.
+type decodeFont struct {
+	Name  *attrValString `xml:"name"`
+	B     *decodeBoolVal `xml:"b"`
+	I     *decodeBoolVal `xml:"i"`
+	Color *xlsxColor     `xml:"color"`
+	Sz    *attrValFloat  `xml:"sz"`
+	U     *attrValString `xml:"u"`
+}
+
+// decodeBoolVal defines the structure used to parse the boolean property
+// element, the property is true if the val attribute is omitted.
+type decodeBoolVal struct {
+	Val string `xml:"val,attr"`
+}
+
 // xlsxFont directly maps the font element. This element defines the properties
 // for one of the fonts used in this workbook.
 type xlsxFont struct {
@@ -328,38 +345,50 @@ type formatFont struct {
 	Color     string `json:"color"`
 }
 
+// formatBorder directly maps the styles settings of the borders.
+type formatBorder struct {
+	Type  string `json:"type"`
+	Color string `json:"color"`
+	Style int    `json:"style"`
+}
+
+// formatFill directly maps the styles settings of the fills.
+type formatFill struct {
+	Type    string   `json:"type"`
+	Pattern int      `json:"pattern"`
+	Color   []string `json:"color"`
+	Shading int      `json:"shading"`
+}
+
+// formatAlignment directly maps the styles settings of the alignment.
+type formatAlignment struct {
+	Horizontal      string `json:"horizontal"`
+	Indent          int    `json:"indent"`
+	JustifyLastLine bool   `json:"justify_last_line"`
+	ReadingOrder    uint64 `json:"reading_order"`
+	RelativeIndent  int    `json:"relative_indent"`
+	ShrinkToFit     bool   `json:"shrink_to_fit"`
+	TextRotation    int    `json:"text_rotation"`
+	Vertical        string `json:"vertical"`
+	WrapText        bool   `json:"wrap_text"`
+}
+
+// formatProtection directly maps the styles settings of the protection.
+type formatProtection struct {
+	Hidden bool `json:"hidden"`
+	Locked bool `json:"locked"`
+}
+
 // formatStyle directly maps the styles settings of the cells.
 type formatStyle struct {
-	Border []struct {
-		Type  string `json:"type"`
-		Color string `json:"color"`
-		Style int    `json:"style"`
-	} `json:"border"`
-	Fill struct {
-		Type    string   `json:"type"`
-		Pattern int      `json:"pattern"`
-		Color   []string `json:"color"`
-		Shading int      `json:"shading"`
-	} `json:"fill"`
-	Font      *formatFont `json:"font"`
-	Alignment *struct {
-		Horizontal      string `json:"horizontal"`
-		Indent          int    `json:"indent"`
-		JustifyLastLine bool   `json:"justify_last_line"`
-		ReadingOrder    uint64 `json:"reading_order"`
-		RelativeIndent  int    `json:"relative_indent"`
-		ShrinkToFit     bool   `json:"shrink_to_fit"`
-		TextRotation    int    `json:"text_rotation"`
-		Vertical        string `json:"vertical"`
-		WrapText        bool   `json:"wrap_text"`
-	} `json:"alignment"`
-	Protection *struct {
-		Hidden bool `json:"hidden"`
-		Locked bool `json:"locked"`
-	} `json:"protection"`
-	NumFmt        int     `json:"number_format"`
-	DecimalPlaces int     `json:"decimal_places"`
-	CustomNumFmt  *string `json:"custom_number_format"`
-	Lang          string  `json:"lang"`
-	NegRed        bool    `json:"negred"`
+	Border        []formatBorder    `json:"border"`
+	Fill          formatFill        `json:"fill"`
+	Font          *formatFont       `json:"font"`
+	Alignment     *formatAlignment  `json:"alignment"`
+	Protection    *formatProtection `json:"protection"`
+	NumFmt        int               `json:"number_format"`
+	DecimalPlaces int               `json:"decimal_places"`
+	CustomNumFmt  *string           `json:"custom_number_format"`
+	Lang          string            `json:"lang"`
+	NegRed        bool              `json:"negred"`
 }
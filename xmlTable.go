@@ -46,6 +46,7 @@ type xlsxTable struct {
 type xlsxAutoFilter struct {
 	Ref          string            `xml:"ref,attr"`
 	FilterColumn *xlsxFilterColumn `xml:"filterColumn"`
+	SortState    *xlsxSortState    `xml:"sortState"`
 }
 
 // xlsxFilterColumn directly maps the filterColumn element. The filterColumn
@@ -158,6 +159,29 @@ type xlsxDateGroupItem struct {
 	Year             int    `xml:"year,attr,omitempty"`
 }
 
+// xlsxSortState directly maps the sortState element. This collection
+// preserves the AutoFilter sort state.
+type xlsxSortState struct {
+	ColumnSort    bool                 `xml:"columnSort,attr,omitempty"`
+	CaseSensitive bool                 `xml:"caseSensitive,attr,omitempty"`
+	SortMethod    string               `xml:"sortMethod,attr,omitempty"`
+	Ref           string               `xml:"ref,attr"`
+	SortCondition []*xlsxSortCondition `xml:"sortCondition"`
+}
+
+// xlsxSortCondition directly maps the sortCondition element. This collection
+// contains the sort condition of a column, it specifies the range of cells
+// being sorted and the sort criteria.
+type xlsxSortCondition struct {
+	Descending bool   `xml:"descending,attr,omitempty"`
+	SortBy     string `xml:"sortBy,attr,omitempty"`
+	Ref        string `xml:"ref,attr"`
+	CustomList string `xml:"customList,attr,omitempty"`
+	DxfID      *int   `xml:"dxfId,attr"`
+	IconSet    string `xml:"iconSet,attr,omitempty"`
+	IconID     *int   `xml:"iconId,attr"`
+}
+
 // xlsxTableColumns directly maps the element representing the collection of all
 // table columns for this table.
 type xlsxTableColumns struct {
@@ -168,18 +192,29 @@ type xlsxTableColumns struct {
 // xlsxTableColumn directly maps the element representing a single column for
 // this table.
 type xlsxTableColumn struct {
-	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
-	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
-	ID                 int    `xml:"id,attr"`
-	Name               string `xml:"name,attr"`
-	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
-	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
-	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
-	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
-	UniqueName         string `xml:"uniqueName,attr,omitempty"`
+	DataCellStyle           string            `xml:"dataCellStyle,attr,omitempty"`
+	DataDxfID               int               `xml:"dataDxfId,attr,omitempty"`
+	HeaderRowCellStyle      string            `xml:"headerRowCellStyle,attr,omitempty"`
+	HeaderRowDxfID          int               `xml:"headerRowDxfId,attr,omitempty"`
+	ID                      int               `xml:"id,attr"`
+	Name                    string            `xml:"name,attr"`
+	QueryTableFieldID       int               `xml:"queryTableFieldId,attr,omitempty"`
+	TotalsRowCellStyle      string            `xml:"totalsRowCellStyle,attr,omitempty"`
+	TotalsRowDxfID          int               `xml:"totalsRowDxfId,attr,omitempty"`
+	TotalsRowFunction       string            `xml:"totalsRowFunction,attr,omitempty"`
+	TotalsRowLabel          string            `xml:"totalsRowLabel,attr,omitempty"`
+	UniqueName              string            `xml:"uniqueName,attr,omitempty"`
+	CalculatedColumnFormula *xlsxTableFormula `xml:"calculatedColumnFormula"`
+	TotalsRowFormula        *xlsxTableFormula `xml:"totalsRowFormula"`
+}
+
+// xlsxTableFormula directly maps the calculatedColumnFormula and
+// totalsRowFormula elements. These elements specify the formula used for
+// the cells in the column of the table, and the custom formula used for the
+// totals row of the column.
+type xlsxTableFormula struct {
+	Array   bool   `xml:"array,attr,omitempty"`
+	Content string `xml:",chardata"`
 }
 
 // xlsxTableStyleInfo directly maps the tableStyleInfo element. This element
@@ -212,3 +247,42 @@ type formatAutoFilter struct {
 		Value  []int  `json:"value"`
 	} `json:"filter_list"`
 }
+
+// AutoFilterDefinition directly maps the auto filter definition of a
+// worksheet, including the filter range, the criteria of the filter columns
+// and the sort state.
+type AutoFilterDefinition struct {
+	Ref       string               `json:"ref"`
+	Columns   []AutoFilterColumn   `json:"columns"`
+	SortState *AutoFilterSortState `json:"sort_state"`
+}
+
+// AutoFilterColumn directly maps the filter criteria of a column in the auto
+// filter range. The ColID is the zero-based offset of the column from the
+// first column of the range.
+type AutoFilterColumn struct {
+	ColID         int                      `json:"col_id"`
+	Column        string                   `json:"column"`
+	Filters       []string                 `json:"filters"`
+	CustomFilters []AutoFilterCustomFilter `json:"custom_filters"`
+	And           bool                     `json:"and"`
+}
+
+// AutoFilterCustomFilter directly maps the operator and the value of a custom
+// filter criteria.
+type AutoFilterCustomFilter struct {
+	Operator string `json:"operator"`
+	Val      string `json:"val"`
+}
+
+// AutoFilterSortState directly maps the sort state of the auto filter.
+type AutoFilterSortState struct {
+	Ref        string                    `json:"ref"`
+	Conditions []AutoFilterSortCondition `json:"conditions"`
+}
+
+// AutoFilterSortCondition directly maps the sort condition of a column.
+type AutoFilterSortCondition struct {
+	Ref        string `json:"ref"`
+	Descending bool   `json:"descending"`
+}
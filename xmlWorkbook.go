@@ -224,6 +224,16 @@ type xlsxDefinedName struct {
 	Data              string `xml:",chardata"`
 }
 
+// DefinedName directly maps the name for a cell or cell range on a
+// worksheet. Scope is the worksheet name of the name, the name is global to
+// the workbook if the scope is empty.
+type DefinedName struct {
+	Name     string
+	Comment  string
+	RefersTo string
+	Scope    string
+}
+
 // xlsxCalcPr directly maps the calcPr element. This element defines the
 // collection of properties the application uses to record calculation status
 // and details. Calculation is the process of computing formulas and then
@@ -23,6 +23,8 @@ type xlsxWorksheet struct {
 	Cols                  *xlsxCols                    `xml:"cols,omitempty"`
 	SheetData             xlsxSheetData                `xml:"sheetData"`
 	SheetProtection       *xlsxSheetProtection         `xml:"sheetProtection"`
+	ProtectedRanges       *xlsxProtectedRanges         `xml:"protectedRanges"`
+	Scenarios             *xlsxScenarios               `xml:"scenarios"`
 	AutoFilter            *xlsxAutoFilter              `xml:"autoFilter"`
 	MergeCells            *xlsxMergeCells              `xml:"mergeCells"`
 	PhoneticPr            *xlsxPhoneticPr              `xml:"phoneticPr"`
@@ -33,6 +35,7 @@ type xlsxWorksheet struct {
 	PageMargins           *xlsxPageMargins             `xml:"pageMargins"`
 	PageSetUp             *xlsxPageSetUp               `xml:"pageSetup"`
 	HeaderFooter          *xlsxHeaderFooter            `xml:"headerFooter"`
+	IgnoredErrors         *xlsxIgnoredErrors           `xml:"ignoredErrors"`
 	Drawing               *xlsxDrawing                 `xml:"drawing"`
 	LegacyDrawing         *xlsxLegacyDrawing           `xml:"legacyDrawing"`
 	Picture               *xlsxPicture                 `xml:"picture"`
@@ -221,7 +224,8 @@ type xlsxSheetPr struct {
 // xlsxOutlinePr maps to the outlinePr element
 // SummaryBelow allows you to adjust the direction of grouper controls
 type xlsxOutlinePr struct {
-	SummaryBelow bool `xml:"summaryBelow,attr"`
+	SummaryBelow bool  `xml:"summaryBelow,attr"`
+	SummaryRight *bool `xml:"summaryRight,attr"`
 }
 
 // xlsxPageSetUpPr directly maps the pageSetupPr element in the namespace
@@ -360,11 +364,14 @@ type xlsxC struct {
 	R string `xml:"r,attr"`           // Cell ID, e.g. A1
 	S int    `xml:"s,attr,omitempty"` // Style reference.
 	// Str string `xml:"str,attr,omitempty"` // Style reference.
-	T        string   `xml:"t,attr,omitempty"` // Type.
-	F        *xlsxF   `xml:"f,omitempty"`      // Formula
-	V        string   `xml:"v,omitempty"`      // Value
+	T        string   `xml:"t,attr,omitempty"`  // Type.
+	Ph       bool     `xml:"ph,attr,omitempty"` // Show phonetic.
+	F        *xlsxF   `xml:"f,omitempty"`       // Formula
+	V        string   `xml:"v,omitempty"`       // Value
 	IS       *xlsxIS  `xml:"is"`
 	XMLSpace xml.Attr `xml:"space,attr,omitempty"`
+	Cm       *uint    `xml:"cm,attr"` // Cell metadata index, e.g. dynamic array formula.
+	Vm       *uint    `xml:"vm,attr"` // Value metadata index, e.g. image in cell.
 }
 
 // xlsxIS directly maps the t element. Cell containing an (inline) rich
@@ -372,7 +379,9 @@ type xlsxC struct {
 // used, then the cell value is in the is element rather than the v element in
 // the cell (c element).
 type xlsxIS struct {
-	T string `xml:"t"`
+	T          string             `xml:"t"`
+	RPh        []*xlsxPhoneticRun `xml:"rPh"`
+	PhoneticPr *xlsxPhoneticPr    `xml:"phoneticPr"`
 }
 
 // xlsxF directly maps the f element in the namespace
@@ -411,6 +420,61 @@ type xlsxSheetProtection struct {
 	SpinCount           int    `xml:"spinCount,attr,omitempty"`
 }
 
+// xlsxProtectedRanges directly maps the protectedRanges element in the
+// namespace http://schemas.openxmlformats.org/spreadsheetml/2006/main - This
+// collection of elements specifies the ranges which are allowed to be edited
+// when the sheet is protected.
+type xlsxProtectedRanges struct {
+	ProtectedRange []*xlsxProtectedRange `xml:"protectedRange"`
+}
+
+// xlsxProtectedRange directly maps the protectedRange element, it specifies
+// the range of cells which are allowed to be edited when the sheet is
+// protected, and the optional password and security descriptor of the range.
+type xlsxProtectedRange struct {
+	Password           string `xml:"password,attr,omitempty"`
+	Sqref              string `xml:"sqref,attr"`
+	Name               string `xml:"name,attr"`
+	SecurityDescriptor string `xml:"securityDescriptor,attr,omitempty"`
+	AlgorithmName      string `xml:"algorithmName,attr,omitempty"`
+	HashValue          string `xml:"hashValue,attr,omitempty"`
+	SaltValue          string `xml:"saltValue,attr,omitempty"`
+	SpinCount          int    `xml:"spinCount,attr,omitempty"`
+}
+
+// xlsxScenarios directly maps the scenarios element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main - This collection
+// of elements specifies the what-if scenarios of the worksheet, the current
+// and the shown scenario, and the result cells of the scenarios.
+type xlsxScenarios struct {
+	Current  int             `xml:"current,attr,omitempty"`
+	Show     int             `xml:"show,attr,omitempty"`
+	Sqref    string          `xml:"sqref,attr,omitempty"`
+	Scenario []*xlsxScenario `xml:"scenario"`
+}
+
+// xlsxScenario directly maps the scenario element, it specifies a what-if
+// scenario by a set of the input cells and their values.
+type xlsxScenario struct {
+	Name       string            `xml:"name,attr"`
+	Locked     bool              `xml:"locked,attr,omitempty"`
+	Hidden     bool              `xml:"hidden,attr,omitempty"`
+	Count      int               `xml:"count,attr,omitempty"`
+	User       string            `xml:"user,attr,omitempty"`
+	Comment    string            `xml:"comment,attr,omitempty"`
+	InputCells []*xlsxInputCells `xml:"inputCells"`
+}
+
+// xlsxInputCells directly maps the inputCells element, it specifies the cell
+// changed by the scenario and the value of the cell.
+type xlsxInputCells struct {
+	R        string `xml:"r,attr"`
+	Deleted  bool   `xml:"deleted,attr,omitempty"`
+	Undone   bool   `xml:"undone,attr,omitempty"`
+	Val      string `xml:"val,attr"`
+	NumFmtID int    `xml:"numFmtId,attr,omitempty"`
+}
+
 // xlsxPhoneticPr (Phonetic Properties) represents a collection of phonetic
 // properties that affect the display of phonetic text for this String Item
 // (si). Phonetic text is used to give hints as to the pronunciation of an East
@@ -504,9 +568,33 @@ type xlsxHyperlink struct {
 	Ref      string `xml:"ref,attr"`
 	Location string `xml:"location,attr,omitempty"`
 	Display  string `xml:"display,attr,omitempty"`
+	Tooltip  string `xml:"tooltip,attr,omitempty"`
 	RID      string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
 }
 
+// xlsxIgnoredErrors directly maps the ignoredErrors element. This collection
+// of elements specifies the ranges of cells in which the errors detected by
+// the error checking of the application are ignored.
+type xlsxIgnoredErrors struct {
+	IgnoredError []*xlsxIgnoredError `xml:"ignoredError"`
+	ExtLst       *xlsxExtLst         `xml:"extLst"`
+}
+
+// xlsxIgnoredError directly maps the ignoredError element. This element
+// specifies the kinds of the errors ignored in the range of cells.
+type xlsxIgnoredError struct {
+	Sqref              string `xml:"sqref,attr"`
+	EvalError          bool   `xml:"evalError,attr,omitempty"`
+	TwoDigitTextYear   bool   `xml:"twoDigitTextYear,attr,omitempty"`
+	NumberStoredAsText bool   `xml:"numberStoredAsText,attr,omitempty"`
+	Formula            bool   `xml:"formula,attr,omitempty"`
+	FormulaRange       bool   `xml:"formulaRange,attr,omitempty"`
+	UnlockedFormula    bool   `xml:"unlockedFormula,attr,omitempty"`
+	EmptyCellReference bool   `xml:"emptyCellReference,attr,omitempty"`
+	ListDataValidation bool   `xml:"listDataValidation,attr,omitempty"`
+	CalculatedColumn   bool   `xml:"calculatedColumn,attr,omitempty"`
+}
+
 // xlsxTableParts directly maps the tableParts element in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main - The table element
 // has several attributes applied to identify the table and the data range it